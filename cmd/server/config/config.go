@@ -0,0 +1,253 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the server settings that can change between environments.
+// Every value is read from an environment variable and falls back to a
+// default suitable for local development.
+type Config struct {
+	// JWTSecret signs the access tokens. It is required, except with
+	// StorageMemory, which falls back to a development secret.
+	JWTSecret     string
+	JWTExpiration time.Duration
+	// AdminUsername and AdminPassword, when the password is set, create an
+	// admin user at startup unless the username is already taken. No user
+	// ships with the seed data.
+	AdminUsername string
+	AdminPassword string
+	// Storage selects where the repositories keep their data, StorageMySQL,
+	// StorageSQLite or StorageMemory.
+	Storage string
+	// SQLitePath is the database file used with StorageSQLite. It is
+	// created, with the data of db.sql, when it doesn't exist.
+	SQLitePath string
+	// LogLevel is the lowest level logged: debug, info, warn or error.
+	LogLevel string
+	// TracingExporter is where spans are sent: none, stdout or otlp.
+	TracingExporter string
+	// TracingEndpoint is the host:port of the OTLP/HTTP collector used
+	// with the otlp exporter.
+	TracingEndpoint string
+	// IPRateLimit is the quota of each IP address on every route, checked
+	// before the token is, so requests with bad tokens count against it.
+	IPRateLimit RateLimit
+	// PublicRateLimit and APIRateLimit are the quotas of each client on the
+	// public routes, such as login, and on each group of authenticated
+	// routes, such as sellers. GroupRateLimits holds, by group, the quotas
+	// of the groups that don't use APIRateLimit.
+	PublicRateLimit RateLimit
+	APIRateLimit    RateLimit
+	GroupRateLimits map[string]RateLimit
+	// APIKeys are the keys clients may send in the X-API-Key header to get
+	// quotas of their own instead of those of their user or IP address.
+	APIKeys []string
+	// TrustedProxies are the addresses or CIDRs of the proxies whose
+	// X-Forwarded-For header is believed to find the IP address of clients.
+	// None by default, so the address is the one of the connection.
+	TrustedProxies []string
+	// MaxBodyBytes bounds the size of request bodies; MaxBulkBodyBytes
+	// bounds the bodies of the /bulk import routes instead. Zero means no
+	// bound.
+	MaxBodyBytes     int64
+	MaxBulkBodyBytes int64
+	// ReportCacheTTL is how long computed reports are served from the cache,
+	// which holds up to ReportCacheSize of them. A zero TTL turns the cache
+	// off.
+	ReportCacheTTL  time.Duration
+	ReportCacheSize int
+	// EventsPollInterval is how often the events of the outbox are delivered
+	// to the sinks. Zero turns delivery off, leaving the events stored.
+	EventsPollInterval time.Duration
+	// EventsMaxAttempts is how many times an event is tried before it is
+	// marked failed.
+	EventsMaxAttempts int
+	// EventsWebhookURL, when set, is posted every event as JSON.
+	EventsWebhookURL string
+	// EventsLogPath, when set, is the file every event is appended to as a
+	// line of JSON.
+	EventsLogPath string
+	// IdempotencyTTL is how long an Idempotency-Key is remembered. Zero
+	// remembers keys forever.
+	IdempotencyTTL time.Duration
+}
+
+// RateLimit lets a client make Requests requests every Per, all at once or
+// spread over the period. A RateLimit with no Requests is no limit.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Storage backends.
+const (
+	StorageMySQL  = "mysql"
+	StorageSQLite = "sqlite"
+	StorageMemory = "memory"
+)
+
+const (
+	devJWTSecret         = "meli-sprint-dev-secret"
+	defaultAdminUsername = "admin"
+	defaultJWTExpiration = 8 * time.Hour
+	defaultSQLitePath    = "melisprint.db"
+	defaultLogLevel      = "info"
+	defaultTracing       = "none"
+	defaultOTLPEndpoint  = "localhost:4318"
+	defaultMaxBody       = 1 << 20
+	defaultMaxBulkBody   = 10 << 20
+	defaultReportTTL     = 30 * time.Second
+	defaultReportEntries = 1000
+	defaultEventsPoll    = time.Second
+	defaultEventAttempts = 10
+	defaultIdempotency   = 24 * time.Hour
+)
+
+var (
+	defaultIPRateLimit     = RateLimit{Requests: 1200, Per: time.Minute}
+	defaultPublicRateLimit = RateLimit{Requests: 20, Per: time.Minute}
+	defaultAPIRateLimit    = RateLimit{Requests: 600, Per: time.Minute}
+)
+
+// ErrMissingJWTSecret is returned by Validate when no JWT_SECRET is set
+// for a persistent storage.
+var ErrMissingJWTSecret = errors.New("JWT_SECRET must be set unless STORAGE is memory")
+
+// Load reads the configuration from the environment.
+func Load() Config {
+	cfg := Config{
+		JWTSecret:        getEnv("JWT_SECRET", ""),
+		JWTExpiration:    getDuration("JWT_EXPIRATION", defaultJWTExpiration),
+		AdminUsername:    getEnv("ADMIN_USERNAME", defaultAdminUsername),
+		AdminPassword:    getEnv("ADMIN_PASSWORD", ""),
+		Storage:          getEnv("STORAGE", StorageMySQL),
+		SQLitePath:       getEnv("SQLITE_PATH", defaultSQLitePath),
+		LogLevel:         getEnv("LOG_LEVEL", defaultLogLevel),
+		TracingExporter:  getEnv("TRACING_EXPORTER", defaultTracing),
+		TracingEndpoint:  getEnv("TRACING_OTLP_ENDPOINT", defaultOTLPEndpoint),
+		IPRateLimit:      getRateLimit("RATE_LIMIT_IP", defaultIPRateLimit),
+		PublicRateLimit:  getRateLimit("RATE_LIMIT_PUBLIC", defaultPublicRateLimit),
+		APIRateLimit:     getRateLimit("RATE_LIMIT_API", defaultAPIRateLimit),
+		GroupRateLimits:  getRateLimits("RATE_LIMIT_GROUPS"),
+		APIKeys:          getList("API_KEYS"),
+		TrustedProxies:   getList("TRUSTED_PROXIES"),
+		MaxBodyBytes:     getInt("MAX_BODY_BYTES", defaultMaxBody),
+		MaxBulkBodyBytes: getInt("MAX_BULK_BODY_BYTES", defaultMaxBulkBody),
+		ReportCacheTTL:   getDuration("REPORT_CACHE_TTL", defaultReportTTL),
+		ReportCacheSize:  int(getInt("REPORT_CACHE_SIZE", defaultReportEntries)),
+
+		EventsPollInterval: getDuration("EVENTS_POLL_INTERVAL", defaultEventsPoll),
+		EventsMaxAttempts:  int(getInt("EVENTS_MAX_ATTEMPTS", defaultEventAttempts)),
+		EventsWebhookURL:   getEnv("EVENTS_WEBHOOK_URL", ""),
+		EventsLogPath:      getEnv("EVENTS_LOG_PATH", ""),
+		IdempotencyTTL:     getDuration("IDEMPOTENCY_TTL", defaultIdempotency),
+	}
+	if cfg.JWTSecret == "" && cfg.Storage == StorageMemory {
+		cfg.JWTSecret = devJWTSecret
+	}
+	return cfg
+}
+
+// Validate reports the settings the server can't safely start without.
+func (c Config) Validate() error {
+	if c.JWTSecret == "" {
+		return ErrMissingJWTSecret
+	}
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+func getInt(key string, fallback int64) int64 {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+// getList reads a comma separated list, leaving out empty items.
+func getList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getRateLimit reads a rate limit written as requests/period, such as
+// "100/1m". "0" or "off" turns the limit off.
+func getRateLimit(key string, fallback RateLimit) RateLimit {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	limit, ok := parseRateLimit(value)
+	if !ok {
+		return fallback
+	}
+	return limit
+}
+
+// getRateLimits reads a comma separated list of rate limits by name, such
+// as "sellers=100/1m,buyers=off", leaving out the malformed items.
+func getRateLimits(key string) map[string]RateLimit {
+	limits := map[string]RateLimit{}
+	for _, item := range getList(key) {
+		name, value, found := strings.Cut(item, "=")
+		if !found {
+			continue
+		}
+		if limit, ok := parseRateLimit(strings.TrimSpace(value)); ok {
+			limits[strings.TrimSpace(name)] = limit
+		}
+	}
+	return limits
+}
+
+func parseRateLimit(value string) (RateLimit, bool) {
+	if value == "0" || value == "off" {
+		return RateLimit{}, true
+	}
+	requests, period, found := strings.Cut(value, "/")
+	if !found {
+		return RateLimit{}, false
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 0 {
+		return RateLimit{}, false
+	}
+	per, err := time.ParseDuration(period)
+	if err != nil || per <= 0 {
+		return RateLimit{}, false
+	}
+	return RateLimit{Requests: n, Per: per}, true
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+type Audit struct {
+	auditService audit.Service
+}
+
+func NewAudit(a audit.Service) *Audit {
+	return &Audit{
+		auditService: a,
+	}
+}
+
+// GetAll godoc
+// @Summary List audit entries
+// @Tags Audit
+// @Description list recorded changes, optionally filtered by entity, entity id and time range. from and to accept RFC 3339 timestamps or YYYY-MM-DD dates; a date in to includes the whole day. With Accept text/csv or application/x-ndjson the entries are streamed in that format.
+// @Produce json,text/csv,application/x-ndjson
+// @Param entity query string false "entity name, e.g. seller"
+// @Param id query int false "entity id"
+// @Param from query string false "lower bound"
+// @Param to query string false "upper bound"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /audit [get]
+func (a *Audit) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f := domain.AuditFilter{Entity: c.Query("entity")}
+
+		if id := c.Query("id"); id != "" {
+			entityID, err := strconv.Atoi(id)
+			if err != nil {
+				web.Error(c, http.StatusBadRequest, "id must be an integer")
+				return
+			}
+			f.EntityID = entityID
+		}
+
+		dates, ok := dateRange(c)
+		if !ok {
+			return
+		}
+		f.From, f.To = dates.From, dates.To
+
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.AuditEntry{}, func(write func(interface{}) error) error {
+				return a.auditService.Stream(c, f, func(e domain.AuditEntry) error { return write(e) })
+			})
+			return
+		}
+
+		entries, err := a.auditService.Find(c, f)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, http.StatusOK, entries)
+	}
+}
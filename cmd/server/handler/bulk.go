@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// dryRunParam is the query parameter that makes bulk imports validate the
+// rows without saving them.
+const dryRunParam = "dry_run"
+
+// bulkRows reads the dry_run parameter and the rows of a bulk import,
+// decoding each into a value from newRow. It answers 400 or 415 and returns
+// false when the request can't be read as a whole.
+func bulkRows(c *gin.Context, newRow func() interface{}) (bool, []web.BulkRow, bool) {
+	dryRun := false
+	if value := c.Query(dryRunParam); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			web.Error(c, http.StatusBadRequest, "invalid dry_run, must be boolean")
+			return false, nil, false
+		}
+	}
+	rows, err := web.BulkRows(c, newRow)
+	if err != nil {
+		if errors.Is(err, web.ErrUnsupportedBulkType) {
+			web.Error(c, http.StatusUnsupportedMediaType, "%s", err.Error())
+			return false, nil, false
+		}
+		if limit, ok := web.BodyTooLarge(err); ok {
+			web.RequestTooLarge(c, limit)
+			return false, nil, false
+		}
+		web.Error(c, http.StatusBadRequest, "%s", err.Error())
+		return false, nil, false
+	}
+	return dryRun, rows, true
+}
+
+// validRows returns the indexes of the rows that passed validation.
+func validRows(rows []web.BulkRow) []int {
+	var valid []int
+	for i, row := range rows {
+		if row.Err == nil {
+			valid = append(valid, i)
+		}
+	}
+	return valid
+}
+
+// bulkReport answers the report of a bulk import. results holds the outcome
+// of the valid rows, in the order given by valid; the other rows are
+// reported as invalid with their field errors.
+func bulkReport(c *gin.Context, dryRun bool, rows []web.BulkRow, valid []int, results []domain.BulkResult) {
+	report := make([]domain.BulkResult, len(rows))
+	for i, row := range rows {
+		if row.Err != nil {
+			report[i] = domain.BulkResult{Status: domain.BulkInvalid, Message: row.Err.Error()}
+			if fields := web.FieldErrors(row.Err); fields != nil {
+				report[i].Message = fmt.Sprintf("field %s %s", fields[0].Field, fields[0].Message)
+				report[i].Details = fields
+			}
+		}
+	}
+	for j, i := range valid {
+		report[i] = results[j]
+	}
+	for i, row := range rows {
+		report[i].Row = row.Row
+	}
+	web.Success(c, http.StatusOK, domain.NewBulkReport(dryRun, report))
+}
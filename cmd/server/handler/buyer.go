@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
 	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer"
@@ -15,11 +17,6 @@ type requestBuyer struct {
 	LastName     string `json:"last_name" binding:"required"`
 }
 
-type requestToUpdate struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-}
-
 type Buyer struct {
 	buyerService buyer.Service
 }
@@ -60,17 +57,32 @@ func (b *Buyer) Get() gin.HandlerFunc {
 //List of Buyers
 //@Summary Obtain list of buyers.
 //@Tags Buyer
-//@description Get all buyers.
-//@Produce json
+//@description Get all buyers, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson.
+//@Produce json,text/csv,application/x-ndjson
+//@Param include_deleted query bool false "Include deleted buyers"
 //@Success 200 {object} web.response
+//@Failed 400 {object} web.errorResponse
 //@Failed 500 {object} web.errorResponse
 //@Router /buyers [get]
 func (b *Buyer) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		b, err := b.buyerService.GetAll(c)
+		withDeleted, err := includeDeleted(c)
+		if err != nil {
+			web.Error(c, 400, "error: include_deleted must be a boolean")
+			return
+		}
+
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.Buyer{}, func(write func(interface{}) error) error {
+				return b.buyerService.StreamAll(c, withDeleted, func(buyer domain.Buyer) error { return write(buyer) })
+			})
+			return
+		}
+
+		b, err := b.buyerService.GetAll(c, withDeleted)
 		if err != nil {
-			web.Error(c, 500, err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 		if len(b) == 0 {
@@ -96,7 +108,7 @@ func (b *Buyer) Create() gin.HandlerFunc {
 
 		var req requestBuyer
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "error: JSON keys required are not included.")
+			buyerValidationError(c, err)
 			return
 		}
 
@@ -122,16 +134,59 @@ func (b *Buyer) Create() gin.HandlerFunc {
 	}
 }
 
+//Import Buyers
+//@Summary Create many buyers at once
+//@Tags Buyer
+//@Description Create buyers from a CSV file with a header of JSON member names, or from NDJSON. Every row is validated and the valid ones are saved in batched transactions; with dry_run nothing is saved.
+//@Accept text/csv,application/x-ndjson
+//@Produce json
+//@Param dry_run query bool false "validate the rows without saving them"
+//@Success 200 {object} web.response
+//@Failure 400 {object} web.errorResponse
+//@Failure 415 {object} web.errorResponse
+//@Failure 500 {object} web.errorResponse
+//@Router /buyers/bulk [post]
+func (b *Buyer) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		dryRun, rows, ok := bulkRows(c, func() interface{} { return &requestBuyer{} })
+		if !ok {
+			return
+		}
+
+		valid := validRows(rows)
+		buyers := make([]domain.Buyer, len(valid))
+		for j, i := range valid {
+			req := rows[i].Value.(*requestBuyer)
+			buyers[j] = domain.Buyer{
+				CardNumberID: req.CardNumberID,
+				FirstName:    req.FirstName,
+				LastName:     req.LastName,
+			}
+		}
+
+		results, err := b.buyerService.Import(c, buyers, dryRun)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		bulkReport(c, dryRun, rows, valid, results)
+	}
+}
+
 //Update Buyer Patch
 //@Summary Update by id
 //@Tags Buyer
-//@Description Update buyer modifying only name and lastname parameters.
-//@Accept json
+//@Description Update buyer applying a JSON merge patch (RFC 7396) over its fields.
+//@Accept json,application/merge-patch+json
 //@Produce json
-//@Param buyer body requestToUpdate true "Buyer to uptdate"
+//@Param buyer body requestBuyer true "Buyer fields to update"
 //@Param id path string true "id"
 //@Success 200 {object} web.response
 //@Failed 404 {object} web.errorResponse
+//@Failed 409 {object} web.errorResponse
+//@Failed 415 {object} web.errorResponse
+//@Failed 422 {object} web.errorResponse
 //@Router /buyers/{id} [patch]
 func (b *Buyer) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -148,24 +203,25 @@ func (b *Buyer) Update() gin.HandlerFunc {
 			return
 		}
 
-		var req requestToUpdate
-		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "%s", err.Error())
+		var req requestBuyer
+		if !mergePatch(c, buyer, &req, 422) {
 			return
 		}
 
-		if req.FirstName == "" && req.LastName == "" {
-			web.Error(c, 400, "error: both keys {first_name, last_name} are empty. At least one of them need be included.")
+		if err := web.Validate(&req); err != nil {
+			buyerValidationError(c, err)
 			return
 		}
 
-		if req.FirstName != "" {
-			buyer.FirstName = req.FirstName
-		}
-		if req.LastName != "" {
-			buyer.LastName = req.LastName
+		if req.CardNumberID != buyer.CardNumberID && b.buyerService.Exists(c, req.CardNumberID) {
+			web.Error(c, 409, "error: buyer with card_number_id:%s already exist", req.CardNumberID)
+			return
 		}
 
+		buyer.CardNumberID = req.CardNumberID
+		buyer.FirstName = req.FirstName
+		buyer.LastName = req.LastName
+
 		error := b.buyerService.Update(c, buyer)
 		if error != nil {
 			web.Error(c, 404, "error: buyer with id:%v not found", id)
@@ -185,6 +241,7 @@ func (b *Buyer) Update() gin.HandlerFunc {
 //@Param id path string true "id"
 //@Success 204 {object} web.response
 //@Failed 404 {object} web.errorResponse
+//@Failed 409 {object} web.errorResponse
 //@Router /buyers/{id} [delete]
 func (b *Buyer) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -196,21 +253,67 @@ func (b *Buyer) Delete() gin.HandlerFunc {
 		}
 
 		error := b.buyerService.Delete(c, id)
-		if error != nil {
+		if dependentsConflict(c, error) {
+			return
+		}
+		if errors.Is(error, buyer.ErrNotFound) {
 			web.Error(c, 404, "error: buyer with id:%v not found", id)
 			return
 		}
+		if error != nil {
+			web.ServerError(c, error, "internal server error")
+			return
+		}
 
 		web.Success(c, 204, nil)
 
 	}
 }
 
+//Restore Buyer
+//@Summary Restore a deleted buyer by id
+//@Tags Buyer
+//@Description Restore a deleted buyer indicating its id.
+//@Produce json
+//@Param id path string true "id"
+//@Success 200 {object} web.response
+//@Failed 404 {object} web.errorResponse
+//@Router /buyers/{id}/restore [post]
+func (b *Buyer) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, "%s", err.Error())
+			return
+		}
+
+		if err := b.buyerService.Restore(c, id); err != nil {
+			switch {
+			case errors.Is(err, buyer.ErrNotFound):
+				web.Error(c, 404, "error: deleted buyer with id:%v not found", id)
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+
+		buyer, err := b.buyerService.Get(c, id)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		web.Success(c, 200, buyer)
+
+	}
+}
+
 //Get Purchase Orders
 //@Summary Get Purchase Orders by id or Get All
 //@Tags Buyer
-//@Description Get purchase orders by id or get all non indicating an id.
-//@Produce json
+//@Description Get purchase orders by id or get all non indicating an id. The full report is streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson.
+//@Produce json,text/csv,application/x-ndjson
 //@Param id query int false "Purchase Order Id"
 //@Success 200 {object} web.response
 //@Failed 404 {object} web.errorResponse
@@ -231,7 +334,11 @@ func (b *Buyer) PurchaseOrders() gin.HandlerFunc {
 				web.Error(c, 404, "error: buyer with id:%v not found", id)
 				return
 			}
-			web.Success(c, 200, buyersOrders)
+			web.SuccessCacheable(c, 200, buyersOrders)
+		} else if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.BuyerOrders{}, func(write func(interface{}) error) error {
+				return b.buyerService.StreamPurchaseOrders(c, func(report domain.BuyerOrders) error { return write(report) })
+			})
 		} else {
 			id := 0
 			buyersOrders, err := b.buyerService.GetPurchaseOrders(c, id)
@@ -239,9 +346,85 @@ func (b *Buyer) PurchaseOrders() gin.HandlerFunc {
 				web.Error(c, 404, "error: buyer with id:%v not found", id)
 				return
 			}
-			web.Success(c, 200, buyersOrders)
+			web.SuccessCacheable(c, 200, buyersOrders)
 
 		}
 
 	}
 }
+
+//Purchase History
+//@Summary Get the purchase history of a buyer
+//@Tags Buyer
+//@Description Get the purchase orders of a buyer, newest first, with their line items, status and total, plus the total spent, the average order value and the date of the last order.
+//@Produce json
+//@Param id path int true "Buyer Id"
+//@Success 200 {object} web.response
+//@Failure 400 {object} web.errorResponse
+//@Failure 404 {object} web.errorResponse
+//@Failure 500 {object} web.errorResponse
+//@Router /buyers/{id}/purchaseOrders [get]
+func (b *Buyer) PurchaseHistory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, "%s", err.Error())
+			return
+		}
+
+		if _, err := b.buyerService.Get(c, id); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				web.Error(c, 404, "error: buyer with id:%v not found", id)
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+
+		history, err := b.buyerService.PurchaseHistory(c, id)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, history)
+	}
+}
+
+//Top Buyers
+//@Summary Rank buyers by spend
+//@Tags Buyer
+//@Description Get a page of the buyers with purchase orders, ranked by the total they spent.
+//@Produce json
+//@Param page query int false "Page number, from 1"
+//@Param page_size query int false "Buyers per page, at most 100"
+//@Success 200 {object} web.response
+//@Failure 400 {object} web.errorResponse
+//@Failure 500 {object} web.errorResponse
+//@Router /buyers/topBuyers [get]
+func (b *Buyer) TopBuyers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, ok := pagination(c)
+		if !ok {
+			return
+		}
+
+		ranking, err := b.buyerService.TopBuyers(c, page, pageSize)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, ranking)
+	}
+}
+
+// buyerValidationError answers 422, listing the fields that failed
+// validation when there are any.
+func buyerValidationError(c *gin.Context, err error) {
+	const msg = "error: JSON keys required are not included."
+	if fields := web.FieldErrors(err); fields != nil {
+		web.ErrorWithDetails(c, 422, fields, msg)
+		return
+	}
+	web.Error(c, 422, msg)
+}
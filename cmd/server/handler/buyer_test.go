@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
@@ -19,7 +20,8 @@ func createBuyerServer(mockData []domain.Buyer) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 
 	b := NewBuyer(&mocks.MockBuyerService{
-		DataMock: mockData,
+		DataMock:    mockData,
+		ErrNotFound: buyer.ErrNotFound,
 	})
 
 	r := gin.Default()
@@ -344,14 +346,14 @@ func TestUpdateKeysRequiredBuyer(t *testing.T) {
 	// assert
 
 	// Verificación código
-	assert.Equal(t, 400, rr.Code)
+	assert.Equal(t, 422, rr.Code)
 
 	// Verificación contenido válido
 	err := json.Unmarshal(rr.Body.Bytes(), &objRes)
 	assert.Nil(t, err)
 
 	// Verificación contenido
-	msg := "error: both keys {first_name, last_name} are empty. At least one of them need be included."
+	msg := "error: JSON keys required are not included."
 	assert.ErrorContains(t, errors.New(msg), objRes.Msg)
 }
 
@@ -445,3 +447,57 @@ func TestFindAllDbEmptyBuyer(t *testing.T) {
 	// Verificación contenido
 	assert.Equal(t, mockEmpty, objRes.Data)
 }
+
+func TestPurchaseHistoryBuyer(t *testing.T) {
+	lastOrder := "2022-03-01 10:00:00"
+	history := domain.BuyerHistory{
+		BuyerSpend: domain.BuyerSpend{BuyerID: 1, CardNumberID: "ABC1234", OrdersCount: 1, TotalSpent: 30, AverageOrderValue: 30, LastOrderDate: &lastOrder},
+		Orders: []domain.BuyerOrder{{
+			ID: 7, OrderNumber: "ORD7", OrderDate: lastOrder, OrderStatusID: 1, Status: "paid", Total: 30,
+			Items: []domain.OrderItem{{ID: 1, ProductRecordID: 4, ProductID: 2, Quantity: 3, UnitPrice: 10, Total: 30}},
+		}},
+	}
+	spend := []domain.BuyerSpend{history.BuyerSpend, {BuyerID: 2, CardNumberID: "XYZ1234"}}
+
+	cases := []struct {
+		name      string
+		url       string
+		errLookup error
+		code      int
+		data      interface{}
+	}{
+		{name: "history", url: "/buyers/1/purchaseOrders", code: 200, data: history},
+		{name: "unknown buyer", url: "/buyers/9/purchaseOrders", code: 404},
+		{name: "buyer lookup fails", url: "/buyers/1/purchaseOrders", errLookup: errors.New("connection refused"), code: 500},
+		{name: "id not int", url: "/buyers/a/purchaseOrders", code: 400},
+		{name: "first page", url: "/buyers/topBuyers", code: 200, data: domain.Page{Page: 1, PageSize: 20, Total: 2, Items: spend}},
+		{name: "second page", url: "/buyers/topBuyers?page=2&page_size=1", code: 200, data: domain.Page{Page: 2, PageSize: 1, Total: 2, Items: spend[1:]}},
+		{name: "invalid page", url: "/buyers/topBuyers?page=0", code: 400},
+		{name: "page size too big", url: "/buyers/topBuyers?page_size=500", code: 400},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			b := NewBuyer(&mocks.MockBuyerService{
+				DataMock:    []domain.Buyer{{ID: 1, CardNumberID: "ABC1234"}, {ID: 2, CardNumberID: "XYZ1234"}},
+				MockHistory: map[int]domain.BuyerHistory{1: history},
+				MockSpend:   spend,
+				ErrLookup:   tc.errLookup,
+			})
+			r := gin.New()
+			r.GET("/buyers/topBuyers", b.TopBuyers())
+			r.GET("/buyers/:id/purchaseOrders", b.PurchaseHistory())
+
+			req, rr := tests.CreateRequestTest(http.MethodGet, tc.url, nil)
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.code, rr.Code)
+			assert.NotContains(t, rr.Body.String(), "connection refused")
+			if tc.data != nil {
+				want, err := json.Marshal(map[string]interface{}{"data": tc.data})
+				assert.Nil(t, err)
+				assert.JSONEq(t, string(want), rr.Body.String())
+			}
+		})
+	}
+}
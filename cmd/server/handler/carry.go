@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -48,9 +47,9 @@ func (c *Carry) Create() gin.HandlerFunc {
 
 		// Obtengo el Request del body y si hay error lo retorno
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			// Si falta un campo requerido retorno un 422
-			if strings.Contains(err.Error(), "'required' tag") {
-				web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			// Si algun campo no es valido retorno un 422 con el detalle
+			if web.FieldErrors(err) != nil {
+				web.ValidationError(ctx, http.StatusUnprocessableEntity, err)
 				return
 			}
 			// Cualquier otro error en el body retorno un 400
@@ -77,9 +76,8 @@ func (c *Carry) Create() gin.HandlerFunc {
 				web.Error(ctx, http.StatusConflict, err.Error())
 				return
 			}
-			// Otro error de BBDD retorno un 500 y muestro por consola el error.
-			web.Error(ctx, http.StatusInternalServerError, "internal server error")
-			fmt.Printf("[SERVER INFO] error in carryService.Save: %v\n", err.Error())
+			// Otro error de BBDD retorno un 500 y registro el error en el log.
+			web.ServerError(ctx, err, "internal server error")
 			return
 		}
 
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// versionMatches answers 412 when the request's If-Match header does not
+// match the version of the record it was read at.
+func versionMatches(c *gin.Context, version int) bool {
+	if web.IfMatch(c, version) {
+		return true
+	}
+	web.Error(c, http.StatusPreconditionFailed, "the record is at version %s, which does not match If-Match", web.ETag(version))
+	return false
+}
+
+// versionConflict answers 412 when err reports that the record changed
+// between reading and writing it. It returns false, writing nothing, for any
+// other error.
+func versionConflict(c *gin.Context, err error) bool {
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		return false
+	}
+	web.Error(c, http.StatusPreconditionFailed, "%s", err.Error())
+	return true
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// timeBoundLayout is the layout datetime columns are compared with.
+const timeBoundLayout = "2006-01-02 15:04:05"
+
+// dateRange reads the from and to query parameters, each an RFC 3339
+// timestamp or a YYYY-MM-DD date. It answers 400 and returns false when
+// either can't be parsed.
+func dateRange(c *gin.Context) (domain.DateRange, bool) {
+	var dates domain.DateRange
+	var err error
+	if dates.From, err = parseTimeBound(c.Query("from"), false); err != nil {
+		web.Error(c, http.StatusBadRequest, "from must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return dates, false
+	}
+	if dates.To, err = parseTimeBound(c.Query("to"), true); err != nil {
+		web.Error(c, http.StatusBadRequest, "to must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return dates, false
+	}
+	return dates, true
+}
+
+// parseTimeBound converts a query bound to the database layout. A bare date
+// used as an upper bound covers the whole day.
+func parseTimeBound(value string, upper bool) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Local().Format(timeBoundLayout), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", err
+	}
+	if upper {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return t.Format(timeBoundLayout), nil
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// dependentsConflict answers 409 listing the blocking dependents when err
+// reports that the entity is still referenced. It returns false, writing
+// nothing, for any other error.
+func dependentsConflict(c *gin.Context, err error) bool {
+	var depErr *domain.DependentsError
+	if !errors.As(err, &depErr) {
+		return false
+	}
+	web.ErrorWithDetails(c, http.StatusConflict, depErr.Dependents, "%s", depErr.Error())
+	return true
+}
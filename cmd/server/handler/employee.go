@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
@@ -14,16 +15,11 @@ type Employee struct {
 }
 
 type postEmployee struct {
-	CardNumberID string `json:"card_number_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	WarehouseID  int    `json:"warehouse_id"`
-}
-
-type patchEmployee struct {
-	FirstName   string `json:"first_name"`
-	LastName    string `json:"last_name"`
-	WarehouseID int    `json:"warehouse_id"`
+	CardNumberID string `json:"card_number_id" binding:"required"`
+	FirstName    string `json:"first_name" binding:"required"`
+	LastName     string `json:"last_name" binding:"required"`
+	WarehouseID  int    `json:"warehouse_id" binding:"required,positive"`
+	UserID       *int   `json:"user_id" binding:"omitempty,positive"`
 }
 
 func NewEmployee(e employee.Service) *Employee {
@@ -48,27 +44,15 @@ func (e *Employee) Create() gin.HandlerFunc {
 		var req postEmployee
 		err := c.ShouldBindJSON(&req)
 		if err != nil {
-			web.Error(c, 422, err.Error())
+			web.ValidationError(c, 422, err)
 			return
 		}
 		if e.employeeService.Exists(c, req.CardNumberID) {
 			web.Error(c, 409, "El employee ya existe")
 			return
 		}
-		if req.CardNumberID == "" {
-			web.Error(c, 422, "El CardNumberID es requerido")
-			return
-		}
-		if req.FirstName == "" {
-			web.Error(c, 422, "El FirstName es requerido")
-			return
-		}
-		if req.LastName == "" {
-			web.Error(c, 422, "El LastName es requerido")
-			return
-		}
-		if req.WarehouseID == 0 {
-			web.Error(c, 422, "El WarehouseID es requerido")
+		if req.UserID != nil && e.userLinked(c, *req.UserID, 0) {
+			web.Error(c, 409, "El user ya esta vinculado a otro employee")
 			return
 		}
 
@@ -77,11 +61,12 @@ func (e *Employee) Create() gin.HandlerFunc {
 			FirstName:    req.FirstName,
 			LastName:     req.LastName,
 			WarehouseID:  req.WarehouseID,
+			UserID:       req.UserID,
 		}
 
 		id, err := e.employeeService.Save(c, emp)
 		if err != nil {
-			web.Error(c, 500, "internal server error")
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
@@ -93,17 +78,30 @@ func (e *Employee) Create() gin.HandlerFunc {
 //ListEmployees godoc
 //@Summary List employees
 //@Tags Employees
-//@Description get all employees
+//@Description get all employees, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
 //@Accept json
-//@Produce json
+//@Produce json,text/csv,application/x-ndjson
+//@Param include_deleted query bool false "include deleted employees"
 //@Succes 200 {object} web.Response
+//@Failure 400 {object} web.errorResponse
 //@Failure 500 {object} web.errorResponse
 //@Router /employees [get]
 func (e *Employee) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		employees, err := e.employeeService.GetAll(c)
+		withDeleted, err := includeDeleted(c)
+		if err != nil {
+			web.Error(c, 400, "El include_deleted es invalido")
+			return
+		}
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.Employee{}, func(write func(interface{}) error) error {
+				return e.employeeService.StreamAll(c, withDeleted, func(emp domain.Employee) error { return write(emp) })
+			})
+			return
+		}
+		employees, err := e.employeeService.GetAll(c, withDeleted)
 		if err != nil {
-			web.Error(c, 500, err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 		if len(employees) == 0 {
@@ -136,11 +134,14 @@ func (e *Employee) Get() gin.HandlerFunc {
 			web.Error(c, 404, "El id no existe")
 			return
 		}
+		web.SetETag(c, emp.Version)
 		web.Success(c, 200, emp)
 	}
 }
 
-// GetInboundOrders
+// GetInboundOrders returns the inbound orders count of an employee, or of
+// every employee when no id is given. The full report is streamed as CSV or
+// NDJSON when the Accept header asks for it.
 func (e *Employee) GetInboundOrders() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -155,22 +156,31 @@ func (e *Employee) GetInboundOrders() gin.HandlerFunc {
 
 			reportInbOrd, err := e.employeeService.GetInboundOrders(c, id)
 			if err != nil {
-				web.Error(c, 404, err.Error())
+				switch {
+				case errors.Is(err, employee.ErrNotFound):
+					web.Error(c, 404, "El id no existe")
+				default:
+					web.ServerError(c, err, "internal server error")
+				}
 				return
 			}
 
 			// Return data Report and Success 200
-			web.Success(c, 200, reportInbOrd)
+			web.SuccessCacheable(c, 200, reportInbOrd)
 
+		} else if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.EmployeeOrders{}, func(write func(interface{}) error) error {
+				return e.employeeService.StreamInboundOrders(c, func(report domain.EmployeeOrders) error { return write(report) })
+			})
 		} else {
 			id := 0
 			reportInbOrd, err := e.employeeService.GetInboundOrders(c, id)
 			if err != nil {
-				web.Error(c, 500, err.Error())
+				web.ServerError(c, err, "internal server error")
 				return
 			}
 			// Return data Report and Success 200
-			web.Success(c, 200, reportInbOrd)
+			web.SuccessCacheable(c, 200, reportInbOrd)
 		}
 	}
 }
@@ -178,19 +188,24 @@ func (e *Employee) GetInboundOrders() gin.HandlerFunc {
 //ModifyEmployees godoc
 //@Summary Modify employees
 //@Tags Employees
-//@Description Modify employees by ID
-//@Accept json
+//@Description Modify employees by ID applying a JSON merge patch (RFC 7396)
+//@Accept json,application/merge-patch+json
 //@Produce json
 //@Param id path int true "employees id"
-//@Param employees body patchEmployee true "employees to update"
+//@Param employees body postEmployee true "employees fields to update"
 //@Succes 200 {object} web.Response
 //@Failure 400 {object} web.errorResponse
 //@Failure 404 {object} web.errorResponse
 //@Failure 500 {object} web.errorResponse
+//@Param If-Match header string false "ETag of the version being changed"
+//@Failure 409 {object} web.errorResponse
+//@Failure 412 {object} web.errorResponse
+//@Failure 415 {object} web.errorResponse
+//@Failure 422 {object} web.errorResponse
 //@Router /employees/{id} [patch]
 func (e *Employee) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req patchEmployee
+		var req postEmployee
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
 			web.Error(c, 400, "El id es invalido")
@@ -201,23 +216,38 @@ func (e *Employee) Update() gin.HandlerFunc {
 			web.Error(c, 404, "El id no existe")
 			return
 		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 404, err.Error())
+		if !versionMatches(c, emp.Version) {
 			return
 		}
-		if req.FirstName != "" {
-			emp.FirstName = req.FirstName
+		if !mergePatch(c, emp, &req, 422) {
+			return
 		}
-		if req.LastName != "" {
-			emp.LastName = req.LastName
+		if err := web.Validate(&req); err != nil {
+			web.ValidationError(c, 422, err)
+			return
 		}
-		if req.WarehouseID != 0 {
-			emp.WarehouseID = req.WarehouseID
+		if req.CardNumberID != emp.CardNumberID && e.employeeService.Exists(c, req.CardNumberID) {
+			web.Error(c, 409, "El employee ya existe")
+			return
 		}
+		if req.UserID != nil && e.userLinked(c, *req.UserID, emp.ID) {
+			web.Error(c, 409, "El user ya esta vinculado a otro employee")
+			return
+		}
+		emp.CardNumberID = req.CardNumberID
+		emp.FirstName = req.FirstName
+		emp.LastName = req.LastName
+		emp.WarehouseID = req.WarehouseID
+		emp.UserID = req.UserID
 		if err := e.employeeService.Update(c, emp); err != nil {
-			web.Error(c, 500, err.Error())
+			if versionConflict(c, err) {
+				return
+			}
+			web.ServerError(c, err, "internal server error")
 			return
 		}
+		emp.Version++
+		web.SetETag(c, emp.Version)
 		web.Success(c, 200, emp)
 	}
 }
@@ -230,6 +260,8 @@ func (e *Employee) Update() gin.HandlerFunc {
 //@Succes 204 {object} nil
 //@Failure 400 {object} web.errorResponse
 //@Failure 404 {object} web.errorResponse
+//@Param If-Match header string false "ETag of the version being changed"
+//@Failure 412 {object} web.errorResponse
 //@Router /employees/{id} [delete]
 func (e *Employee) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -238,11 +270,78 @@ func (e *Employee) Delete() gin.HandlerFunc {
 			web.Error(c, 400, "El id es invalido")
 			return
 		}
-		err = e.employeeService.Delete(c, id)
+		version := 0
+		if c.GetHeader(web.IfMatchHeader) != "" {
+			emp, err := e.employeeService.Get(c, id)
+			if err != nil {
+				switch {
+				case errors.Is(err, employee.ErrNotFound):
+					web.Error(c, 404, "El id no existe")
+				default:
+					web.ServerError(c, err, "internal server error")
+				}
+				return
+			}
+			if !versionMatches(c, emp.Version) {
+				return
+			}
+			version = emp.Version
+		}
+		err = e.employeeService.Delete(c, id, version)
+		if versionConflict(c, err) {
+			return
+		}
 		if err != nil {
-			web.Error(c, 404, "El id no existe")
+			switch {
+			case errors.Is(err, employee.ErrNotFound):
+				web.Error(c, 404, "El id no existe")
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
 			return
 		}
 		web.Success(c, 204, nil)
 	}
 }
+
+//RestoreEmployees godoc
+//@Summary Restore employee by ID
+//@Tags Employees
+//@Description Restore a deleted employee by ID
+//@Produce json
+//@Param id path int true "employees id"
+//@Succes 200 {object} web.Response
+//@Failure 400 {object} web.errorResponse
+//@Failure 404 {object} web.errorResponse
+//@Router /employees/{id}/restore [post]
+func (e *Employee) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, "El id es invalido")
+			return
+		}
+		if err := e.employeeService.Restore(c, id); err != nil {
+			switch {
+			case errors.Is(err, employee.ErrNotFound):
+				web.Error(c, 404, "El id no existe o no esta eliminado")
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+		emp, err := e.employeeService.Get(c, id)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, emp)
+	}
+}
+
+// userLinked reports whether the user is already linked to an employee other
+// than employeeID.
+func (e *Employee) userLinked(c *gin.Context, userID, employeeID int) bool {
+	linked, err := e.employeeService.GetByUserID(c, userID)
+	return err == nil && linked.ID != employeeID
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -25,6 +26,7 @@ func createServerEmployee(employeeService employee.Service) *gin.Engine {
 		employeeRoutes.GET("/:id", handler.Get())
 		employeeRoutes.PATCH("/:id", handler.Update())
 		employeeRoutes.DELETE("/:id", handler.Delete())
+		employeeRoutes.GET("/reportInboundOrders", handler.GetInboundOrders())
 	}
 
 	return r
@@ -323,7 +325,8 @@ func TestDeleteNonExistentEmployee(t *testing.T) {
 
 	r := createServerEmployee(&mocks.MockEmployeeService{
 		MockRepository: mocks.MockEmployeeRepository{
-			MockData: mocks.MockEmployees,
+			MockData:    mocks.MockEmployees,
+			ErrNotFound: employee.ErrNotFound,
 		},
 	})
 
@@ -387,3 +390,50 @@ func TestDeleteIDNonIntEmployee(t *testing.T) {
 	assert.Equal(t, "bad_request", resp.Code)
 	assert.Equal(t, "El id es invalido", resp.Message)
 }
+
+func TestDeleteEmployeeFailure(t *testing.T) {
+	r := createServerEmployee(&mocks.MockEmployeeService{
+		MockRepository: mocks.MockEmployeeRepository{
+			MockData:  mocks.MockEmployees,
+			ErrDelete: errors.New("connection refused"),
+		},
+	})
+
+	req, rr := tests.CreateRequestTest(http.MethodDelete, "/employees/1", nil)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, 500, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "connection refused")
+}
+
+func TestGetInboundOrdersEmployeeErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{name: "not found", err: employee.ErrNotFound, code: 404, message: "El id no existe"},
+		{name: "failure", err: errors.New("connection refused"), code: 500, message: "internal server error"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createServerEmployee(&mocks.MockEmployeeService{
+				MockRepository: mocks.MockEmployeeRepository{
+					MockData:         mocks.MockEmployees,
+					ErrInboundOrders: tc.err,
+				},
+			})
+			resp := struct {
+				Message string `json:"message"`
+			}{}
+
+			req, rr := tests.CreateRequestTest(http.MethodGet, "/employees/reportInboundOrders?id=8", nil)
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.code, rr.Code)
+			assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, tc.message, resp.Message)
+		})
+	}
+}
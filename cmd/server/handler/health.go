@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+type Health struct {
+	db *sql.DB
+}
+
+type healthStatus struct {
+	Status     string                  `json:"status"`
+	Statements *storage.StatementStats `json:"statements,omitempty"`
+}
+
+func NewHealth(db *sql.DB) *Health {
+	return &Health{
+		db: db,
+	}
+}
+
+// Health godoc
+// @Summary Health check
+// @Tags Health
+// @Description report whether the server can reach its database, with the counters of its prepared statements. A server keeping its data in memory is always healthy.
+// @Produce json
+// @Success 200 {object} web.response
+// @Failure 503 {object} web.errorResponse
+// @Router /health [get]
+func (h *Health) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.db == nil {
+			web.Success(c, http.StatusOK, healthStatus{Status: "ok"})
+			return
+		}
+		if err := h.db.PingContext(c); err != nil {
+			web.Error(c, http.StatusServiceUnavailable, "database unavailable")
+			return
+		}
+		stats := storage.StatementsOf(h.db).Stats()
+		web.Success(c, http.StatusOK, healthStatus{Status: "ok", Statements: &stats})
+	}
+}
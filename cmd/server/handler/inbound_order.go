@@ -13,11 +13,11 @@ type InboundOrder struct {
 }
 
 type postInboundOrder struct {
-	OrderDate      string `json:"order_date"`
-	OrderNumber    string `json:"order_number"`
-	EmployeeID     int    `json:"employee_id"`
-	ProductBatchID int    `json:"product_batch_id"`
-	WarehouseID    int    `json:"warehouse_id"`
+	OrderDate      string `json:"order_date" binding:"required,date"`
+	OrderNumber    string `json:"order_number" binding:"required"`
+	EmployeeID     int    `json:"employee_id" binding:"required,positive"`
+	ProductBatchID int    `json:"product_batch_id" binding:"required,positive"`
+	WarehouseID    int    `json:"warehouse_id" binding:"required,positive"`
 }
 
 // NewInboundOrder
@@ -43,32 +43,16 @@ func (i *InboundOrder) Create() gin.HandlerFunc {
 		var req postInboundOrder
 
 		if err := c.ShouldBindJSON(&req); err != nil {
+			if web.FieldErrors(err) != nil {
+				web.ValidationError(c, 422, err)
+				return
+			}
 			web.Error(c, 400, err.Error())
 			return
 		}
-		if req.OrderDate == "" {
-			web.Error(c, 422, "El OrderDate es requerido")
-			return
-		}
-		if req.OrderNumber == "" {
-			web.Error(c, 422, "El OrderNumber es requerido")
-			return
-		}
-		if req.EmployeeID == 0 {
-			web.Error(c, 422, "El EmployeeID es requerido")
-			return
-		}
-		if req.ProductBatchID == 0 {
-			web.Error(c, 422, "El ProductBatchID es requerido")
-			return
-		}
-		if req.WarehouseID == 0 {
-			web.Error(c, 422, "El WarehouseID es requerido")
-			return
-		}
 		exist, err := i.inboundOrderService.Exists(c, req.EmployeeID)
 		if err != nil {
-			web.Error(c, 500, err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 		if !exist {
@@ -86,7 +70,7 @@ func (i *InboundOrder) Create() gin.HandlerFunc {
 
 		id, err := i.inboundOrderService.Save(c, inbOrd)
 		if err != nil {
-			web.Error(c, 500, err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
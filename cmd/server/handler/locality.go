@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -55,8 +54,8 @@ func (l *Locality) CreateLocality() gin.HandlerFunc {
 		var req RequestLocalityPost
 
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			if strings.Contains(err.Error(), "'required' tag") {
-				web.Error(ctx, 422, err.Error())
+			if web.FieldErrors(err) != nil {
+				web.ValidationError(ctx, 422, err)
 				return
 			}
 			web.Error(ctx, 400, err.Error())
@@ -80,7 +79,7 @@ func (l *Locality) CreateLocality() gin.HandlerFunc {
 				return
 			}
 
-			web.Error(ctx, 500, "internal server error")
+			web.ServerError(ctx, err, "internal server error")
 			return
 		}
 		//return and add id
@@ -93,8 +92,8 @@ func (l *Locality) CreateLocality() gin.HandlerFunc {
 // ReportSellers godoc
 // @Summary Get Report of Sellers by Locality
 // @Tags Localities
-// @Description Get Report of Sellers by Locality
-// @Produce json
+// @Description Get Report of Sellers by Locality. The full report is streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
+// @Produce json,text/csv,application/x-ndjson
 // @Param id query int false "locality id"
 // @Success 200 {object} web.response
 // @Failure 400 {object} web.errorResponse
@@ -119,30 +118,38 @@ func (l *Locality) ReportSellers() gin.HandlerFunc {
 					web.Error(c, 404, err.Error())
 					return
 				}
-				web.Error(c, 500, "internal server error")
+				web.ServerError(c, err, "internal server error")
 				return
 			}
-			web.Success(c, 200, lc)
+			web.SuccessCacheable(c, 200, lc)
+			return
+		}
+
+		//stream all reports when CSV or NDJSON is accepted
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.ReportSeller{}, func(write func(interface{}) error) error {
+				return l.localityService.StreamSellerReports(c, func(lc domain.ReportSeller) error { return write(lc) })
+			})
 			return
 		}
 
 		lcs, err := l.localityService.GetAllSellerReports(c)
 
 		if err != nil {
-			web.Error(c, 500, "internal Server Error")
+			web.ServerError(c, err, "internal Server Error")
 			return
 		}
 
 		//return all reports
-		web.Success(c, 200, lcs)
+		web.SuccessCacheable(c, 200, lcs)
 	}
 }
 
 // ReportCarries godoc
 // @Summary Get Report of Carries by Locality
 // @Tags Localities
-// @Description Get Report of Carries by Locality
-// @Produce json
+// @Description Get Report of Carries by Locality. The full report is streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
+// @Produce json,text/csv,application/x-ndjson
 // @Param id query int false "locality id"
 // @Success 200 {object} web.response
 // @Failure 400 {object} web.errorResponse
@@ -169,26 +176,32 @@ func (l *Locality) ReportCarries() gin.HandlerFunc {
 					web.Error(c, http.StatusNotFound, err.Error())
 					return
 				}
-				// Otro error de BBDD retorno un 500 y muestro por consola el error
-				web.Error(c, http.StatusInternalServerError, "internal server error")
-				fmt.Printf("[SERVER INFO] error in localityService.GetCarryReport: %v\n", err.Error())
+				// Otro error de BBDD retorno un 500 y registro el error en el log
+				web.ServerError(c, err, "internal server error")
 				return
 			}
 			// Retorno el reporte encontrado en caso de exito
-			web.Success(c, http.StatusOK, lc)
+			web.SuccessCacheable(c, http.StatusOK, lc)
+			return
+		}
+
+		// Si el Accept pide CSV o NDJSON envio los reportes a medida que se leen
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.LocalityCarries{}, func(write func(interface{}) error) error {
+				return l.localityService.StreamCarryReports(c, func(lc domain.LocalityCarries) error { return write(lc) })
+			})
 			return
 		}
 
 		// Si el id no existe obtengo todos los reportes
 		lcs, err := l.localityService.GetAllCarryReports(c)
 
-		// En caso de error de BBDD retorno un 500 y muestro por consola el error
+		// En caso de error de BBDD retorno un 500 y registro el error en el log
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "internal Server Error")
-			fmt.Printf("[SERVER INFO] error in localityService.GetAllCarryReports: %v\n", err.Error())
+			web.ServerError(c, err, "internal Server Error")
 			return
 		}
 		// Retorno todos los reportes encontrados
-		web.Success(c, http.StatusOK, lcs)
+		web.SuccessCacheable(c, http.StatusOK, lcs)
 	}
 }
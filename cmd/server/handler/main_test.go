@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"os"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+)
+
+// TestMain registers the validations the router registers before serving,
+// since the tests build their own engines.
+func TestMain(m *testing.M) {
+	if err := web.RegisterValidators(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// mergePatch applies the merge patch in the request body over current and
+// decodes the merged document into target. It answers 415 for bodies that
+// are not JSON and status for patches that cannot be applied.
+func mergePatch(c *gin.Context, current, target interface{}, status int) bool {
+	err := web.MergePatch(c, current, target)
+	if errors.Is(err, web.ErrUnsupportedMediaType) {
+		web.Error(c, http.StatusUnsupportedMediaType, "%s", err.Error())
+		return false
+	}
+	if limit, ok := web.BodyTooLarge(err); ok {
+		web.RequestTooLarge(c, limit)
+		return false
+	}
+	if err != nil {
+		web.Error(c, status, "%s", err.Error())
+		return false
+	}
+	return true
+}
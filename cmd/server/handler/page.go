@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pagination reads the page and page_size query parameters, defaulting to
+// the first page of defaultPageSize entries. It answers 400 and returns
+// false when either is not a positive integer or page_size is above
+// maxPageSize.
+func pagination(c *gin.Context) (int, int, bool) {
+	page, pageSize := 1, defaultPageSize
+	if value := c.Query("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			web.Error(c, http.StatusBadRequest, "page must be a positive integer")
+			return 0, 0, false
+		}
+		page = n
+	}
+	if value := c.Query("page_size"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPageSize {
+			web.Error(c, http.StatusBadRequest, "page_size must be an integer between 1 and %d", maxPageSize)
+			return 0, 0, false
+		}
+		pageSize = n
+	}
+	return page, pageSize, true
+}
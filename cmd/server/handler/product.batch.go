@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
@@ -10,11 +9,6 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-var (
-	EMPTY_FIELD = "field %s cant be empty"
-	ZERO_FIELD  = "field %s cant be empty or zero"
-)
-
 type ProductBatch struct {
 	product_batch_service product_batch.Service
 }
@@ -26,16 +20,16 @@ func NewProductBatch(pb product_batch.Service) *ProductBatch {
 }
 
 type requestBatches struct {
-	BatchNumber        *int
-	CurrentQuantity    *int
-	CurrentTemperature *int
-	DueDate            *string
-	InitialQuantity    *int
-	ManufacturingDate  *string
-	ManufacturingHour  *string
-	MinumumTemperature *int
-	ProductId          *int
-	SectionId          *int
+	BatchNumber        *int    `binding:"required,positive"`
+	CurrentQuantity    *int    `binding:"required,min=0"`
+	CurrentTemperature *int    `binding:"omitempty,temperature"`
+	DueDate            *string `binding:"required,date"`
+	InitialQuantity    *int    `binding:"required,min=0"`
+	ManufacturingDate  *string `binding:"required,date"`
+	ManufacturingHour  *string `binding:"required,date"`
+	MinumumTemperature *int    `binding:"required,temperature"`
+	ProductId          *int    `binding:"required,positive"`
+	SectionId          *int    `binding:"required,positive"`
 }
 
 func (rq *requestBatches) Parse() domain.ProductBatches {
@@ -52,34 +46,6 @@ func (rq *requestBatches) Parse() domain.ProductBatches {
 	}
 }
 
-func ValidateBatch(validated requestBatches) error {
-	if validated.BatchNumber == nil {
-		return fmt.Errorf(ZERO_FIELD, "batch_number")
-	}
-	if validated.CurrentQuantity == nil {
-		return fmt.Errorf(ZERO_FIELD, "current_quantity")
-	}
-	if validated.DueDate == nil {
-		return fmt.Errorf(EMPTY_FIELD, "due_date")
-	}
-	if validated.InitialQuantity == nil {
-		return fmt.Errorf(ZERO_FIELD, "initial_quantity")
-	}
-	if validated.ManufacturingHour == nil {
-		return fmt.Errorf(ZERO_FIELD, "manufacturing_hour")
-	}
-	if validated.MinumumTemperature == nil {
-		return fmt.Errorf(ZERO_FIELD, "minimun_temperature")
-	}
-	if validated.ProductId == nil {
-		return fmt.Errorf(ZERO_FIELD, "product_id")
-	}
-	if validated.SectionId == nil {
-		return fmt.Errorf(ZERO_FIELD, "section_id")
-	}
-	return nil
-}
-
 // CreateProductBatch godoc
 // @Summary Create product batch
 // @Tags ProductBatch
@@ -96,19 +62,18 @@ func (s *ProductBatch) Create() gin.HandlerFunc {
 		var product_batch requestBatches
 		err := c.ShouldBindJSON(&product_batch)
 		if err != nil {
+			if web.FieldErrors(err) != nil {
+				web.ValidationError(c, http.StatusUnprocessableEntity, err)
+				return
+			}
 			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		err = ValidateBatch(product_batch)
-		if err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
 		new_batch := product_batch.Parse()
 		createdInt, err := s.product_batch_service.Save(c, new_batch)
 		new_batch.Id = createdInt
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, CantSave)
+			web.ServerError(c, err, CantSave)
 			return
 		}
 		web.Success(c, http.StatusCreated, new_batch)
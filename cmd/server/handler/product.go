@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
@@ -16,28 +16,33 @@ type Product struct {
 }
 
 type postReq struct {
-	Description    string  `json:"description"`
-	ExpirationRate int     `json:"expiration_rate"`
-	FreezingRate   int     `json:"freezing_rate" `
-	Height         float32 `json:"height"`
-	Length         float32 `json:"length"`
-	Netweight      float32 `json:"netweight"`
-	ProductCode    string  `json:"product_code"`
-	RecomFreezTemp float32 `json:"recommended_freezing_temperature"`
-	Width          float32 `json:"width"`
-	ProductTypeID  int     `json:"product_type_id"`
-	SellerID       int     `json:"seller_id"`
+	Description    string  `json:"description" binding:"required"`
+	ExpirationRate int     `json:"expiration_rate" binding:"positive"`
+	FreezingRate   int     `json:"freezing_rate" binding:"positive"`
+	Height         float32 `json:"height" binding:"positive"`
+	Length         float32 `json:"length" binding:"positive"`
+	Netweight      float32 `json:"netweight" binding:"positive"`
+	ProductCode    string  `json:"product_code" binding:"required,product_code"`
+	RecomFreezTemp float32 `json:"recommended_freezing_temperature" binding:"temperature"`
+	Width          float32 `json:"width" binding:"positive"`
+	ProductTypeID  int     `json:"product_type_id" binding:"positive"`
+	SellerID       int     `json:"seller_id" binding:"positive"`
 }
 
-type patchReq struct {
-	Description    string   `json:"description"`
-	ExpirationRate *int     `json:"expiration_rate"`
-	FreezingRate   *int     `json:"freezing_rate"`
-	Height         *float32 `json:"height"`
-	Length         *float32 `json:"length"`
-	Netweight      *float32 `json:"netweight"`
-	ProductCode    string   `json:"product_code"`
-	Width          *float32 `json:"width"`
+func (req postReq) product() domain.Product {
+	return domain.Product{
+		Description:    req.Description,
+		ExpirationRate: req.ExpirationRate,
+		FreezingRate:   req.FreezingRate,
+		Height:         req.Height,
+		Length:         req.Length,
+		Netweight:      req.Netweight,
+		ProductCode:    req.ProductCode,
+		RecomFreezTemp: req.RecomFreezTemp,
+		Width:          req.Width,
+		ProductTypeID:  req.ProductTypeID,
+		SellerID:       req.SellerID,
+	}
 }
 
 // NewProduct ...
@@ -50,16 +55,29 @@ func NewProduct(p product.Service) *Product {
 // Get | List-Products godoc
 // @Summary List of all products from database
 // @Tags Products
-// @Description Get all products from database
-// @Produce  json
+// @Description Get all products from database. With Accept text/csv or application/x-ndjson the products are streamed in that format.
+// @Produce  json,text/csv,application/x-ndjson
+// @Param include_deleted query bool false "include deleted products"
 // @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
 // @Failure 500 {object} web.errorResponse
 // @Router /products [GET]
 func (p *Product) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		prd, err := p.productService.GetAll(c)
+		withDeleted, err := includeDeleted(c)
+		if err != nil {
+			web.Error(c, 400, "error. include_deleted must be of type *boolean*")
+			return
+		}
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.Product{}, func(write func(interface{}) error) error {
+				return p.productService.StreamAll(c, withDeleted, func(prd domain.Product) error { return write(prd) })
+			})
+			return
+		}
+		prd, err := p.productService.GetAll(c, withDeleted)
 		if err != nil {
-			web.Error(c, 500, "%s", err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 		if len(prd) == 0 {
@@ -94,6 +112,7 @@ func (p *Product) Get() gin.HandlerFunc {
 			return
 		}
 
+		web.SetETag(c, prd.Version)
 		web.Success(c, 200, prd)
 	}
 }
@@ -116,7 +135,7 @@ func (p *Product) Create() gin.HandlerFunc {
 		var req postReq
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "%s", err.Error())
+			web.ValidationError(c, 422, err)
 			return
 		}
 
@@ -125,23 +144,11 @@ func (p *Product) Create() gin.HandlerFunc {
 			return
 		}
 
-		prd := domain.Product{}
-
-		errs := validatePost(&req, &prd)
-		if len(errs) > 0 {
-			errors := ""
-			for _, error := range errs {
-				errors += error.Error()
-			}
-
-			web.Error(c, 400, "error: %s", errors)
-
-			return
-		}
+		prd := req.product()
 
 		id, err := p.productService.Save(c, prd)
 		if err != nil {
-			web.Error(c, 500, "error. %s", err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
@@ -150,115 +157,61 @@ func (p *Product) Create() gin.HandlerFunc {
 	}
 }
 
-func validatePost(req *postReq, pr *domain.Product) []error {
-	errors := []error{}
-
-	if req.Description != "" {
-		pr.Description = req.Description
-	} else {
-		errors = append(errors, fmt.Errorf("\n*Description* field must not be null"))
-	}
-
-	if &req.ExpirationRate != nil {
-		if req.ExpirationRate > 0 {
-			pr.ExpirationRate = req.ExpirationRate
-		} else {
-			errors = append(errors, fmt.Errorf("\n*ExpirationRate* field must not be null & greater than [zero]"))
-		}
-	}
-
-	if &req.FreezingRate != nil {
-		if req.FreezingRate > 0 {
-			pr.FreezingRate = req.FreezingRate
-		} else {
-			errors = append(errors, fmt.Errorf("\n*FreezingRate* field must not be null"))
-		}
-	}
-
-	if &req.Height != nil {
-		if req.Length > 0 {
-			pr.Height = req.Height
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Height* field must not be null"))
-		}
-	}
-
-	if &req.Length != nil {
-		if req.Length > 0 {
-			pr.Length = req.Length
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Lenght* field must not be null & greater than [zero]"))
-		}
-	}
-
-	if &req.Netweight != nil {
-		if req.Netweight > 0 {
-			pr.Netweight = req.Netweight
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Netweight* field must not be null & greater than [zero]"))
-		}
-	}
-
-	if req.ProductCode != "" {
-		pr.ProductCode = req.ProductCode
-	} else {
-		errors = append(errors, fmt.Errorf("\n*ProductCode* field must not be null"))
-	}
-
-	if &req.RecomFreezTemp != nil {
-		if req.RecomFreezTemp > 0 {
-			pr.RecomFreezTemp = req.RecomFreezTemp
-		} else {
-			errors = append(errors, fmt.Errorf("\n*RecomFreezTemp* field must not be null"))
+// Post | Import products godoc
+// @Summary Import products in bulk
+// @Tags Products
+// @Description Create many products from a CSV file with a header of JSON member names, or from NDJSON. Every row is validated and the valid ones are saved in batched transactions; with dry_run nothing is saved.
+// @Accept text/csv,application/x-ndjson
+// @Produce  json
+// @Param dry_run query bool false "validate the rows without saving them"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 415 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /products/bulk [POST]
+func (p *Product) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun, rows, ok := bulkRows(c, func() interface{} { return &postReq{} })
+		if !ok {
+			return
 		}
-	}
 
-	if &req.Width != nil {
-		if req.Width > 0 {
-			pr.Width = req.Width
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Width* field must not be null & greater than [zero]"))
+		valid := validRows(rows)
+		products := make([]domain.Product, len(valid))
+		for j, i := range valid {
+			products[j] = rows[i].Value.(*postReq).product()
 		}
-	}
 
-	if &req.ProductTypeID != nil {
-		if req.ProductTypeID > 0 {
-			pr.ProductTypeID = req.ProductTypeID
-		} else {
-			errors = append(errors, fmt.Errorf("\n*ProductTypeID* field must not be null & greater than [zero]"))
-		}
-	}
-
-	if &req.SellerID != nil {
-		if req.SellerID > 0 {
-			pr.SellerID = req.SellerID
-		} else {
-			errors = append(errors, fmt.Errorf("\n*SellerID* field must not be null & greater than [zero]"))
+		results, err := p.productService.Import(c, products, dryRun)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
 		}
+		bulkReport(c, dryRun, rows, valid, results)
 	}
-
-	return errors
-
 }
 
 // Patch | Update a product godoc
 // @Summary Update a Product with Service
 // @Tags Products
-// @Description Update a product with the requirements required.
-// @Accept json
+// @Description Update a product applying a JSON merge patch (RFC 7396). Members set to null are cleared and the result must pass the create validations.
+// @Accept json,application/merge-patch+json
 // @Produce  json
 // @Param id path int true "id"
-// @Param product body patchReq true "Create a product"
+// @Param product body postReq true "Fields of the product to change"
 // @Success 200 {object} web.response
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 422 {object} web.errorResponse
 // @Failure 409 {object} web.errorResponse
 // @Failure 500 {object} web.errorResponse
+// @Param If-Match header string false "ETag of the version being changed"
+// @Failure 412 {object} web.errorResponse
+// @Failure 415 {object} web.errorResponse
 // @Router /products/{id} [PATCH]
 func (p *Product) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req patchReq
+		var req postReq
 
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
@@ -272,98 +225,40 @@ func (p *Product) Update() gin.HandlerFunc {
 			return
 		}
 
-		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "%s", err.Error())
+		if !versionMatches(c, prd.Version) {
 			return
 		}
 
-		if req.ProductCode != "" {
-			if p.productService.Exists(c, req.ProductCode) {
-				web.Error(c, 409, "error. the product with code: %v, already exists", req.ProductCode)
-				return
-			}
-			prd.ProductCode = req.ProductCode
-		}
-
-		errs := validatePatch(&req, &prd)
-		if len(errs) > 0 {
-			errors := ""
-			for _, error := range errs {
-				errors += error.Error()
-			}
-
-			web.Error(c, 400, "error: %s", errors)
-
+		if !mergePatch(c, prd, &req, 422) {
 			return
 		}
 
-		if err := p.productService.Update(c, prd); err != nil {
-			web.Error(c, 500, "error: %s", err.Error())
+		if err := web.Validate(&req); err != nil {
+			web.ValidationError(c, 422, err)
 			return
 		}
 
-		web.Success(c, 200, prd)
-	}
-}
-
-func validatePatch(req *patchReq, p *domain.Product) []error {
-	errors := []error{}
-
-	if req.Description != "" {
-		p.Description = req.Description
-	} else {
-		errors = append(errors, fmt.Errorf("\n*Description* field must not be null"))
-	}
-
-	if req.ExpirationRate != nil {
-		if *req.ExpirationRate > 0 {
-			p.ExpirationRate = *req.ExpirationRate
-		} else {
-			errors = append(errors, fmt.Errorf("\n*ExpirationRate* field must not be null & greater than [zero]"))
-		}
-	}
-
-	if req.FreezingRate != nil {
-		if *req.FreezingRate > 0 {
-			p.FreezingRate = *req.FreezingRate
-		} else {
-			errors = append(errors, fmt.Errorf("\n*FreezingRate* field must not be null"))
-		}
-	}
-
-	if req.Height != nil {
-		if *req.Length > 0 {
-			p.Height = *req.Height
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Height* field must not be null"))
+		if req.ProductCode != prd.ProductCode && p.productService.Exists(c, req.ProductCode) {
+			web.Error(c, 409, "error. the product with code: %v, already exists", req.ProductCode)
+			return
 		}
-	}
 
-	if req.Length != nil {
-		if *req.Length > 0 {
-			p.Length = *req.Length
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Lenght* field must not be null & greater than [zero]"))
-		}
-	}
+		id, version := prd.ID, prd.Version
+		prd = req.product()
+		prd.ID, prd.Version = id, version
 
-	if req.Netweight != nil {
-		if *req.Netweight > 0 {
-			p.Netweight = *req.Netweight
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Netweight* field must not be null & greater than [zero]"))
+		if err := p.productService.Update(c, prd); err != nil {
+			if versionConflict(c, err) {
+				return
+			}
+			web.ServerError(c, err, "internal server error")
+			return
 		}
-	}
 
-	if req.Width != nil {
-		if *req.Width > 0 {
-			p.Width = *req.Width
-		} else {
-			errors = append(errors, fmt.Errorf("\n*Width* field must not be null & greater than [zero]"))
-		}
+		prd.Version++
+		web.SetETag(c, prd.Version)
+		web.Success(c, 200, prd)
 	}
-
-	return errors
 }
 
 // Delete | Delete a product godoc
@@ -374,6 +269,9 @@ func validatePatch(req *patchReq, p *domain.Product) []error {
 // @Success 204 {object} web.response
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Param If-Match header string false "ETag of the version being changed"
+// @Failure 412 {object} web.errorResponse
 // @Router /products/{id} [DELETE]
 func (p *Product) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -382,10 +280,64 @@ func (p *Product) Delete() gin.HandlerFunc {
 			web.Error(c, 400, "error. The entered id must be of type *integer*")
 			return
 		}
-		if err := p.productService.Delete(c, id); err != nil {
-			web.Error(c, 404, "error. No product found with the entered id: %d", id)
+		version := 0
+		if c.GetHeader(web.IfMatchHeader) != "" {
+			prd, err := p.productService.Get(c, id)
+			if err != nil {
+				web.Error(c, 404, "error. No product found with the entered id: %d", id)
+				return
+			}
+			if !versionMatches(c, prd.Version) {
+				return
+			}
+			version = prd.Version
+		}
+		if err := p.productService.Delete(c, id, version); err != nil {
+			switch {
+			case dependentsConflict(c, err):
+			case versionConflict(c, err):
+			case errors.Is(err, product.ErrNotFound):
+				web.Error(c, 404, "error. No product found with the entered id: %d", id)
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
 			return
 		}
 		web.Success(c, 204, nil)
 	}
 }
+
+// Restore | Restore a deleted product godoc
+// @Summary Restore a Product with Service
+// @Tags Products
+// @Description Restore a deleted product
+// @Produce  json
+// @Param id path int true "id"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /products/{id}/restore [POST]
+func (p *Product) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, "error. The entered id must be of type *integer*")
+			return
+		}
+		if err := p.productService.Restore(c, id); err != nil {
+			switch {
+			case errors.Is(err, product.ErrNotFound):
+				web.Error(c, 404, "error. No deleted product found with the entered id: %d", id)
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+		prd, err := p.productService.Get(c, id)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, prd)
+	}
+}
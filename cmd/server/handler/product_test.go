@@ -2,10 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
 	"github.com/gin-gonic/gin"
@@ -17,7 +22,8 @@ func CreateServerProduct(data []domain.Product) *gin.Engine {
 
 	// act
 	p := NewProduct(&mocks.MockRepositoryProduct{
-		Data: data,
+		Data:        data,
+		ErrNotFound: product.ErrNotFound,
 	})
 
 	gin.SetMode(gin.ReleaseMode)
@@ -82,6 +88,41 @@ func TestCreateFailProduct(t *testing.T) {
 	assert.Equal(t, "unprocessable_entity", resp.ErrCode)
 }
 
+func TestCreateInvalidFieldsProduct(t *testing.T) {
+	resp := struct {
+		ErrCode string           `json:"code"`
+		Message string           `json:"message"`
+		Details []web.FieldError `json:"details"`
+	}{}
+
+	body := map[string]interface{}{
+		"description":                      "test #1",
+		"expiration_rate":                  1,
+		"freezing_rate":                    5,
+		"height":                           -6.2,
+		"length":                           4.2,
+		"netweight":                        8.1,
+		"product_code":                     "ard uino",
+		"recommended_freezing_temperature": 250,
+		"width":                            4.4,
+		"product_type_id":                  3,
+		"seller_id":                        3,
+	}
+	r := CreateServerProduct([]domain.Product{})
+	req, rr := tests.CreateRequestTest(http.MethodPost, "/products/", body)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, 422, rr.Code)
+	err := json.Unmarshal(rr.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+	assert.Equal(t, "field height must be greater than zero", resp.Message)
+	assert.Equal(t, []web.FieldError{
+		{Field: "height", Message: "must be greater than zero"},
+		{Field: "product_code", Message: "must be letters and digits, optionally separated by - or _"},
+		{Field: "recommended_freezing_temperature", Message: "must be a temperature between -100 and 100"},
+	}, resp.Details)
+}
+
 func TestCreateConflictProduct(t *testing.T) {
 
 	resp := struct {
@@ -307,11 +348,11 @@ func TestUpdateIdFailProduct(t *testing.T) {
 	r.ServeHTTP(rr, req)
 
 	// Validation
-	assert.Equal(t, 400, rr.Code)
+	assert.Equal(t, 422, rr.Code)
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.Nil(t, err)
 	// The message and the code are the same as expected.
-	assert.Equal(t, "bad_request", resp.ErrCode, resp.Message)
+	assert.Equal(t, "unprocessable_entity", resp.ErrCode, resp.Message)
 }
 
 func TestUpdateConflictProductCode(t *testing.T) {
@@ -323,7 +364,7 @@ func TestUpdateConflictProductCode(t *testing.T) {
 	// crear el Server y definir las Rutas
 	r := CreateServerProduct(mocks.MockListProducts)
 	// crear Request del tipo GET y Response para obtener el resultado
-	req, rr := tests.CreateRequestTest(http.MethodPatch, "/products/2", &mocks.MockListProducts[1])
+	req, rr := tests.CreateRequestTest(http.MethodPatch, "/products/1", map[string]interface{}{"product_code": "raspberry"})
 	// indicar al servidor que pueda atender la solicitud
 	r.ServeHTTP(rr, req)
 
@@ -412,3 +453,169 @@ func TestDeleteOkProduct(t *testing.T) {
 	// Validation code request
 	assert.Equal(t, 204, rr.Code)
 }
+
+func TestUpdateMergePatchProduct(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"patch a field create requires", "application/merge-patch+json", `{"seller_id": 7}`, 200},
+		{"patch as plain json", "application/json", `{"seller_id": 7}`, 200},
+		{"null clears a required field", "application/merge-patch+json", `{"description": null}`, 422},
+		{"unknown field", "application/merge-patch+json", `{"colour": "red"}`, 422},
+		{"patch is not an object", "application/merge-patch+json", `[1]`, 422},
+		{"unsupported content type", "text/plain", `{"seller_id": 7}`, 415},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := struct {
+				Data domain.Product `json:"data"`
+			}{}
+
+			data := []domain.Product{{
+				ID:             1,
+				Description:    "test",
+				ExpirationRate: 1,
+				FreezingRate:   2,
+				Height:         6.4,
+				Length:         4.5,
+				Netweight:      3.4,
+				ProductCode:    "ssd",
+				RecomFreezTemp: 1.3,
+				Width:          1.2,
+				ProductTypeID:  2,
+				SellerID:       2,
+			}}
+			r := CreateServerProduct(data)
+			req, rr := tests.CreateRequestTest(http.MethodPatch, "/products/1", nil)
+			req.Body = ioutil.NopCloser(strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.status, rr.Code)
+			if tc.status == 200 {
+				err := json.Unmarshal(rr.Body.Bytes(), &resp)
+				assert.Nil(t, err)
+				expected := data[0]
+				expected.SellerID = 7
+				assert.Equal(t, expected, resp.Data)
+			}
+		})
+	}
+}
+
+func TestImportProduct(t *testing.T) {
+	csvBody := "description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id\n" +
+		"milk,1,2,1.5,2.5,1,milk-1,-5,3,1,1\n" +
+		"cheese,1,2,1.5,2.5,1,ssd,-5,3,1,1\n" +
+		"butter,1,2,-1.5,2.5,1,butter-1,-5,3,1,1\n" +
+		"cream,1,2,1.5,2.5,1,milk-1,-5,3,1,1\n"
+	ndjsonBody := `{"description":"milk","expiration_rate":1,"freezing_rate":2,"height":1.5,"length":2.5,"netweight":1,"product_code":"milk-1","recommended_freezing_temperature":-5,"width":3,"product_type_id":1,"seller_id":1}` + "\n\n" +
+		`{"description":"cheese","expiration_rate":"x"}` + "\n"
+	unknownSellerBody := "description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id\n" +
+		"milk,1,2,1.5,2.5,1,milk-1,-5,3,1,9\n" +
+		"cream,1,2,1.5,2.5,1,cream-1,-5,3,1,1\n"
+
+	cases := []struct {
+		name        string
+		url         string
+		contentType string
+		body        string
+		code        int
+		statuses    []string
+		stored      int
+	}{
+		{"csv", "/products/bulk", "text/csv", csvBody, 200, []string{"created", "conflict", "invalid", "conflict"}, 2},
+		{"csv dry run", "/products/bulk?dry_run=true", "text/csv", csvBody, 200, []string{"valid", "conflict", "invalid", "conflict"}, 1},
+		{"ndjson", "/products/bulk", "application/x-ndjson", ndjsonBody, 200, []string{"created", "invalid"}, 2},
+		{"csv unknown seller", "/products/bulk", "text/csv", unknownSellerBody, 200, []string{"invalid", "created"}, 2},
+		{"unknown column", "/products/bulk", "text/csv", "description,color\nmilk,white\n", 400, nil, 1},
+		{"json", "/products/bulk", "application/json", "[]", 415, nil, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := struct {
+				Data domain.BulkReport `json:"data"`
+			}{}
+			repo := &mocks.MockRepositoryProduct{
+				Data:               []domain.Product{{ID: 1, ProductCode: "ssd"}},
+				MockMissingSellers: map[int]bool{9: true},
+			}
+			r := gin.New()
+			r.POST("/products/bulk", NewProduct(repo).Import())
+
+			req := httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.code, rr.Code)
+			assert.Len(t, repo.Data, tc.stored)
+			if tc.statuses == nil {
+				return
+			}
+			assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			var statuses []string
+			for i, result := range resp.Data.Results {
+				assert.Equal(t, i+1, result.Row)
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tc.statuses, statuses)
+			assert.Equal(t, len(tc.statuses), resp.Data.Total)
+		})
+	}
+}
+
+func TestGetAllExportProduct(t *testing.T) {
+	products := []domain.Product{
+		{ID: 1, Description: "milk, whole", ProductCode: "milk-1", Height: 1.5, SellerID: 2},
+		{ID: 2, Description: "cheese", ProductCode: "cheese-1", RecomFreezTemp: -4},
+	}
+	cases := []struct {
+		name        string
+		data        []domain.Product
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "csv",
+			data:        products,
+			accept:      "text/csv",
+			contentType: "text/csv; charset=utf-8",
+			body: "id,description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at\n" +
+				"1,\"milk, whole\",0,0,1.5,0,0,milk-1,0,0,0,2,\n" +
+				"2,cheese,0,0,0,0,0,cheese-1,-4,0,0,0,\n",
+		},
+		{
+			name:        "ndjson",
+			data:        products,
+			accept:      "application/x-ndjson",
+			contentType: "application/x-ndjson; charset=utf-8",
+			body: `{"id":1,"description":"milk, whole","expiration_rate":0,"freezing_rate":0,"height":1.5,"length":0,"netweight":0,"product_code":"milk-1","recommended_freezing_temperature":0,"width":0,"product_type_id":0,"seller_id":2}` + "\n" +
+				`{"id":2,"description":"cheese","expiration_rate":0,"freezing_rate":0,"height":0,"length":0,"netweight":0,"product_code":"cheese-1","recommended_freezing_temperature":-4,"width":0,"product_type_id":0,"seller_id":0}` + "\n",
+		},
+		{
+			name:        "csv without products",
+			accept:      "text/csv",
+			contentType: "text/csv; charset=utf-8",
+			body:        "id,description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := CreateServerProduct(tc.data)
+			req := httptest.NewRequest(http.MethodGet, "/products/", nil)
+			req.Header.Set("Accept", tc.accept)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, 200, rr.Code)
+			assert.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, tc.body, rr.Body.String())
+		})
+	}
+}
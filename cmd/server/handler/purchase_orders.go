@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
 	purchaseOrder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/purchase_orders"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
@@ -9,13 +12,18 @@ import (
 
 type requestPurchaseOrders struct {
 	OrderNumber     string `json:"order_number" binding:"required"`
-	OrderDate       string `json:"order_date" binding:"required"`
+	OrderDate       string `json:"order_date" binding:"required,date"`
 	TrackingCode    string `json:"tracking_code" binding:"required"`
 	BuyerId         int    `json:"buyer_id" binding:"required"`
 	ProductRecordId int    `json:"product_record_id" binding:"required"`
+	Quantity        int    `json:"quantity" binding:"omitempty,positive"`
 	OrderStatusId   int    `json:"order_status_id" binding:"required"`
 }
 
+type requestPurchaseOrderStatus struct {
+	OrderStatusId int `json:"order_status_id" binding:"required"`
+}
+
 type PurchaseOrder struct {
 	purchaseOrderService purchaseOrder.Service
 }
@@ -41,13 +49,13 @@ func (po *PurchaseOrder) Create() gin.HandlerFunc {
 
 		var req requestPurchaseOrders
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "error: JSON keys required are not included.")
+			buyerValidationError(c, err)
 			return
 		}
 
 		exist, err := po.purchaseOrderService.Exists(c, req.OrderNumber)
 		if err != nil {
-			web.Error(c, 500, err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 		if exist {
@@ -61,8 +69,13 @@ func (po *PurchaseOrder) Create() gin.HandlerFunc {
 			TrackingCode:    req.TrackingCode,
 			BuyerId:         req.BuyerId,
 			ProductRecordId: req.ProductRecordId,
+			Quantity:        req.Quantity,
 			OrderStatusId:   req.OrderStatusId,
 		}
+		//an order without quantity is for a single unit
+		if purchaseOrder.Quantity == 0 {
+			purchaseOrder.Quantity = 1
+		}
 
 		id, err := po.purchaseOrderService.Save(c, purchaseOrder)
 		if err != nil {
@@ -73,3 +86,43 @@ func (po *PurchaseOrder) Create() gin.HandlerFunc {
 		web.Success(c, 201, purchaseOrder)
 	}
 }
+
+//Update the status of a Purchase Order
+//@Summary Update the order status of a purchase order
+//@Tags Purchase Order
+//@description Move a purchase order to another order status.
+//@Accept json
+//@Produce json
+//@Param id path int true "Purchase order id"
+//@Param status body requestPurchaseOrderStatus true "New order status"
+//@Success 200 {object} web.response
+//@Failed 404 {object} web.errorResponse
+//@Failed 422 {object} web.errorResponse
+//@Router /purchaseOrders/{id}/status [patch]
+func (po *PurchaseOrder) UpdateStatus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, "%s", err.Error())
+			return
+		}
+
+		var req requestPurchaseOrderStatus
+		if err := c.ShouldBindJSON(&req); err != nil {
+			buyerValidationError(c, err)
+			return
+		}
+
+		_, err = po.purchaseOrderService.UpdateStatus(c, id, req.OrderStatusId)
+		switch {
+		case errors.Is(err, purchaseOrder.ErrNotFound):
+			web.Error(c, 404, "error: purchase order %d not found", id)
+		case errors.Is(err, purchaseOrder.ErrStatusNotFound):
+			web.Error(c, 422, "error: order status %d does not exist", req.OrderStatusId)
+		case err != nil:
+			web.ServerError(c, err, "internal server error")
+		default:
+			web.Success(c, 200, req)
+		}
+	}
+}
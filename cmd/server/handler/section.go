@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,7 +13,7 @@ import (
 )
 
 var (
-	EmptyField     = "field %s cant be empty "
+	EmptyField     = "field %s is required"
 	CantFind       = "cant find section: %d"
 	InvalidId      = "ID given isnt valid"
 	CantGet        = "Cant get sections"
@@ -21,6 +21,9 @@ var (
 	CantSave       = "Cant save section"
 	CantUpdate     = "Cant update section: %d"
 	CantDelete     = "Cant delete section: %d"
+
+	CantFindDeleted       = "cant find deleted section: %d"
+	InvalidIncludeDeleted = "include_deleted given isnt a boolean"
 )
 
 type Section struct {
@@ -34,28 +37,40 @@ func NewSection(s section.Service) *Section {
 }
 
 type request struct {
-	SectionNumber      *int `json:"section_number"`
-	CurrentTemperature *int `json:"current_temperature"`
-	MinimumTemperature *int `json:"minimum_temperature"`
-	CurrentCapacity    *int `json:"current_capacity"`
-	MinimumCapacity    *int `json:"minimum_capacity"`
-	MaximumCapacity    *int `json:"maximum_capacity"`
-	WarehouseID        *int `json:"warehouse_id"`
-	ProductTypeID      *int `json:"product_type_id"`
+	SectionNumber      *int `json:"section_number" binding:"required,positive"`
+	CurrentTemperature *int `json:"current_temperature" binding:"required,temperature"`
+	MinimumTemperature *int `json:"minimum_temperature" binding:"required,temperature"`
+	CurrentCapacity    *int `json:"current_capacity" binding:"required,min=0"`
+	MinimumCapacity    *int `json:"minimum_capacity" binding:"required,min=0"`
+	MaximumCapacity    *int `json:"maximum_capacity" binding:"required,min=0"`
+	WarehouseID        *int `json:"warehouse_id" binding:"required,positive"`
+	ProductTypeID      *int `json:"product_type_id" binding:"required,positive"`
 }
 
 // ListSections godoc
 // @Summary List sections
 // @Tags Sections
-// @Description get all registered sections
-// @Produce  json
+// @Description get all registered sections, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
+// @Produce  json,text/csv,application/x-ndjson
+// @Param include_deleted query bool false "include deleted sections"
 // @Success 200 {object} web.response
 // @Router /sections [get]
 func (s *Section) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		allSections, err := s.sectionService.GetAll(c)
+		withDeleted, err := includeDeleted(c)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, CantGet)
+			web.Error(c, http.StatusBadRequest, InvalidIncludeDeleted)
+			return
+		}
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.Section{}, func(write func(interface{}) error) error {
+				return s.sectionService.StreamAll(c, withDeleted, func(sec domain.Section) error { return write(sec) })
+			})
+			return
+		}
+		allSections, err := s.sectionService.GetAll(c, withDeleted)
+		if err != nil {
+			web.ServerError(c, err, CantGet)
 			return
 		}
 		if len(allSections) == 0 {
@@ -86,6 +101,7 @@ func (s *Section) Get() gin.HandlerFunc {
 			web.Error(c, http.StatusNotFound, CantFind, id)
 			return
 		}
+		web.SetETag(c, section.Version)
 		web.Success(c, http.StatusOK, section)
 	}
 }
@@ -103,29 +119,19 @@ func (s *Section) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var section request
 		err := c.ShouldBindJSON(&section)
-		if err != nil {
-			web.Error(c, http.StatusBadRequest, err.Error())
+		if web.FieldErrors(err) != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
-		errs := Validate(section)
-		if len(errs) > 0 {
-			web.Error(c, http.StatusUnprocessableEntity, errs[0].Error())
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		newSection := domain.Section{
-			SectionNumber:      *section.SectionNumber,
-			CurrentTemperature: *section.CurrentTemperature,
-			MinimumTemperature: *section.MinimumTemperature,
-			CurrentCapacity:    *section.CurrentCapacity,
-			MinimumCapacity:    *section.MinimumCapacity,
-			MaximumCapacity:    *section.MaximumCapacity,
-			WarehouseID:        *section.WarehouseID,
-			ProductTypeID:      *section.ProductTypeID,
-		}
+		newSection := sectionFromRequest(section)
 		createdInt, err := s.sectionService.Save(c, newSection)
 		newSection.ID = createdInt
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, CantSave)
+			web.ServerError(c, err, CantSave)
 			return
 		}
 		web.Success(c, http.StatusCreated, newSection)
@@ -135,11 +141,16 @@ func (s *Section) Create() gin.HandlerFunc {
 // UpdateSection godoc
 // @Summary Update Section
 // @Tags Sections
-// @Description search a section given a valid id
+// @Description update a section applying a JSON merge patch (RFC 7396)
+// @Accept json,application/merge-patch+json
 // @Produce  json
 // @Param id path int true "id"
 // @Param product body request true "New data for section"
 // @Success 200 {object} web.response
+// @Param If-Match header string false "ETag of the version being changed"
+// @Failure 412 {object} web.errorResponse
+// @Failure 415 {object} web.errorResponse
+// @Failure 422 {object} web.errorResponse
 // @Router /sections/{id} [patch]
 func (s *Section) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -153,20 +164,30 @@ func (s *Section) Update() gin.HandlerFunc {
 			web.Error(c, http.StatusNotFound, CantFind, id)
 			return
 		}
+		if !versionMatches(c, oldSection.Version) {
+			return
+		}
 
 		var section request
-		err = c.ShouldBindJSON(&section)
-		if err != nil {
-			web.Error(c, http.StatusBadRequest, err.Error())
+		if !mergePatch(c, oldSection, &section, http.StatusBadRequest) {
+			return
+		}
+		if err := web.Validate(&section); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
-		updated := partialUpdate(oldSection, section)
-		oldSection.ID = id
+		updated := sectionFromRequest(section)
+		updated.ID = id
+		updated.Version = oldSection.Version
 		upSection, err := s.sectionService.Update(c, updated)
+		if versionConflict(c, err) {
+			return
+		}
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, CantUpdate, id)
+			web.ServerError(c, err, CantUpdate, id)
 			return
 		}
+		web.SetETag(c, upSection.Version)
 		web.Success(c, http.StatusOK, upSection)
 	}
 }
@@ -178,6 +199,9 @@ func (s *Section) Update() gin.HandlerFunc {
 // @Produce  json
 // @Param id path int true "id"
 // @Success 204
+// @Failure 409 {object} web.errorResponse
+// @Param If-Match header string false "ETag of the version being changed"
+// @Failure 412 {object} web.errorResponse
 // @Router /sections/{id} [delete]
 func (s *Section) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -186,25 +210,73 @@ func (s *Section) Delete() gin.HandlerFunc {
 			web.Error(c, http.StatusBadRequest, InvalidId)
 			return
 		}
-		_, err = s.sectionService.Get(c, id)
+		sec, err := s.sectionService.Get(c, id)
 		if err != nil {
 			web.Error(c, http.StatusNotFound, CantFind, id)
 			return
 		}
-		err = s.sectionService.Delete(c, id)
+		if !versionMatches(c, sec.Version) {
+			return
+		}
+		version := 0
+		if c.GetHeader(web.IfMatchHeader) != "" {
+			version = sec.Version
+		}
+		err = s.sectionService.Delete(c, id, version)
+		if dependentsConflict(c, err) || versionConflict(c, err) {
+			return
+		}
+		if errors.Is(err, section.ErrNotFound) {
+			web.Error(c, http.StatusNotFound, CantFind, id)
+			return
+		}
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, CantDelete, id)
+			web.ServerError(c, err, CantDelete, id)
 			return
 		}
 		web.Success(c, http.StatusNoContent, nil)
 	}
 }
 
+// RestoreSection godoc
+// @Summary Restore section
+// @Tags Sections
+// @Description restore a deleted section given a valid id
+// @Produce  json
+// @Param id path int true "id"
+// @Success 200 {object} web.response
+// @Router /sections/{id}/restore [post]
+func (s *Section) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, InvalidId)
+			return
+		}
+		err = s.sectionService.Restore(c, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, section.ErrNotFound):
+				web.Error(c, http.StatusNotFound, CantFindDeleted, id)
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+		section, err := s.sectionService.Get(c, id)
+		if err != nil {
+			web.ServerError(c, err, CantFind, id)
+			return
+		}
+		web.Success(c, http.StatusOK, section)
+	}
+}
+
 // GetSection godoc
 // @Summary Get Product Reports
 // @Tags Sections
-// @Description Get all products Reports
-// @Produce  json
+// @Description Get all products Reports, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
+// @Produce  json,text/csv,application/x-ndjson
 // @Success 200 {object} web.response
 // @Param id query int false "id"
 // @Router /sections/reportProducts [get]
@@ -212,12 +284,18 @@ func (s *Section) GetProductReport() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		idParam, ok := c.GetQuery("id")
 		if !ok {
+			if format := web.ExportFormat(c); format != "" {
+				web.Export(c, format, domain.ProductReport{}, func(write func(interface{}) error) error {
+					return s.sectionService.StreamReportProducts(c, func(report domain.ProductReport) error { return write(report) })
+				})
+				return
+			}
 			reportParam, err := s.sectionService.ReportProductsGetAll(c)
 			if err != nil {
-				web.Error(c, http.StatusInternalServerError, CantGetReports)
+				web.ServerError(c, err, CantGetReports)
 				return
 			}
-			web.Success(c, http.StatusOK, reportParam)
+			web.SuccessCacheable(c, http.StatusOK, reportParam)
 			return
 		}
 		id, err := strconv.Atoi(idParam)
@@ -234,63 +312,20 @@ func (s *Section) GetProductReport() gin.HandlerFunc {
 			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		web.Success(c, http.StatusOK, report)
+		web.SuccessCacheable(c, http.StatusOK, report)
 	}
 }
 
-func Validate(section request) []error {
-	errors := []error{}
-	if section.SectionNumber == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "section_number"))
-	}
-	if section.CurrentTemperature == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "current_temperature"))
-	}
-	if section.MinimumTemperature == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "minimum_temperature"))
-	}
-	if section.CurrentCapacity == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "current_capacity"))
-	}
-	if section.MinimumCapacity == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "minimum_capacity"))
-	}
-	if section.MaximumCapacity == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "maximum_capacity"))
-	}
-	if section.WarehouseID == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "warehouse_id"))
-	}
-	if section.ProductTypeID == nil {
-		errors = append(errors, fmt.Errorf(EmptyField, "product_type_id"))
-	}
-	return errors
-}
-
-func partialUpdate(oldSection domain.Section, newSection request) domain.Section {
-	if newSection.SectionNumber != nil {
-		oldSection.SectionNumber = *newSection.SectionNumber
-	}
-	if newSection.CurrentTemperature != nil {
-		oldSection.CurrentTemperature = *newSection.CurrentTemperature
-	}
-	if newSection.MinimumTemperature != nil {
-		oldSection.MinimumTemperature = *newSection.MinimumTemperature
-	}
-	if newSection.CurrentCapacity != nil {
-		oldSection.CurrentCapacity = *newSection.CurrentCapacity
-	}
-	if newSection.MinimumCapacity != nil {
-		oldSection.MinimumCapacity = *newSection.MinimumCapacity
-	}
-	if newSection.MaximumCapacity != nil {
-		oldSection.MaximumCapacity = *newSection.MaximumCapacity
-	}
-	if newSection.WarehouseID != nil {
-		oldSection.WarehouseID = *newSection.WarehouseID
-	}
-	if newSection.ProductTypeID != nil {
-		oldSection.ProductTypeID = *newSection.ProductTypeID
+// sectionFromRequest builds a section from a request that passed validation.
+func sectionFromRequest(section request) domain.Section {
+	return domain.Section{
+		SectionNumber:      *section.SectionNumber,
+		CurrentTemperature: *section.CurrentTemperature,
+		MinimumTemperature: *section.MinimumTemperature,
+		CurrentCapacity:    *section.CurrentCapacity,
+		MinimumCapacity:    *section.MinimumCapacity,
+		MaximumCapacity:    *section.MaximumCapacity,
+		WarehouseID:        *section.WarehouseID,
+		ProductTypeID:      *section.ProductTypeID,
 	}
-	return oldSection
 }
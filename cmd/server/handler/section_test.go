@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
 	"github.com/gin-gonic/gin"
@@ -18,7 +20,7 @@ type mockSectionService struct {
 	mockData []domain.Section
 }
 
-func (mk *mockSectionService) GetAll(ctx context.Context) ([]domain.Section, error) {
+func (mk *mockSectionService) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
 	return mk.mockData, nil
 }
 
@@ -48,7 +50,7 @@ func (mk *mockSectionService) Update(ctx context.Context, s domain.Section) (dom
 	return domain.Section{}, fmt.Errorf("no se encontro seccion: %d", s.ID)
 }
 
-func (mk *mockSectionService) Delete(ctx context.Context, id int) error {
+func (mk *mockSectionService) Delete(ctx context.Context, id, version int) error {
 	for i, section := range mk.mockData {
 		if section.ID == id {
 			mk.mockData = append(mk.mockData[:i], mk.mockData[i+1:]...)
@@ -58,18 +60,39 @@ func (mk *mockSectionService) Delete(ctx context.Context, id int) error {
 	return fmt.Errorf("no se encontro seccion: %d", id)
 }
 
+func (mk *mockSectionService) Restore(ctx context.Context, id int) error {
+	for i, section := range mk.mockData {
+		if section.ID == id && section.DeletedAt != nil {
+			mk.mockData[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return section.ErrNotFound
+}
+
 func (mk *mockSectionService) ReportProductsGetAll(ctx context.Context) ([]domain.ProductReport, error) {
 	return []domain.ProductReport{}, nil
 }
 func (mk *mockSectionService) ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error) {
 	return domain.ProductReport{}, nil
 }
+func (mk *mockSectionService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	for _, section := range mk.mockData {
+		if err := fn(section); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (mk *mockSectionService) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	return nil
+}
 
 type mockSectionErrorService struct {
 	mockData []domain.Section
 }
 
-func (mk *mockSectionErrorService) GetAll(ctx context.Context) ([]domain.Section, error) {
+func (mk *mockSectionErrorService) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
 	return []domain.Section{}, fmt.Errorf("no se pueden obtener las secciones")
 }
 
@@ -90,16 +113,26 @@ func (mk *mockSectionErrorService) Update(ctx context.Context, s domain.Section)
 	return domain.Section{}, fmt.Errorf("no se actualizo seccion: %d", s.ID)
 }
 
-func (mk *mockSectionErrorService) Delete(ctx context.Context, id int) error {
+func (mk *mockSectionErrorService) Delete(ctx context.Context, id, version int) error {
 	return fmt.Errorf("no se encontro elimino: %d", id)
 }
 
+func (mk *mockSectionErrorService) Restore(ctx context.Context, id int) error {
+	return fmt.Errorf("no se restauro seccion: %d", id)
+}
+
 func (mk *mockSectionErrorService) ReportProductsGetAll(ctx context.Context) ([]domain.ProductReport, error) {
 	return []domain.ProductReport{}, fmt.Errorf("Err")
 }
 func (mk *mockSectionErrorService) ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error) {
 	return domain.ProductReport{}, fmt.Errorf("Err")
 }
+func (mk *mockSectionErrorService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	return fmt.Errorf("no se pueden obtener las secciones")
+}
+func (mk *mockSectionErrorService) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	return fmt.Errorf("Err")
+}
 
 func createSectionServer() *gin.Engine {
 	mService := mockSectionService{mocks.MockListaSections}
@@ -115,6 +148,7 @@ func createSectionServer() *gin.Engine {
 		productos.POST("/", s.Create())
 		productos.PATCH("/:id", s.Update())
 		productos.DELETE("/:id", s.Delete())
+		productos.POST("/:id/restore", s.Restore())
 	}
 	return router
 }
@@ -133,6 +167,7 @@ func createSectionEmptyServer() *gin.Engine {
 		productos.POST("/", s.Create())
 		productos.PATCH("/:id", s.Update())
 		productos.DELETE("/:id", s.Delete())
+		productos.POST("/:id/restore", s.Restore())
 	}
 	return router
 }
@@ -151,6 +186,7 @@ func createSectionErrorServer() *gin.Engine {
 		productos.POST("/", s.Create())
 		productos.PATCH("/:id", s.Update())
 		productos.DELETE("/:id", s.Delete())
+		productos.POST("/:id/restore", s.Restore())
 	}
 	return router
 }
@@ -174,11 +210,15 @@ func TestCreateConflictHandler(t *testing.T) {
 	req, res := tests.CreateRequestTest(http.MethodPost, "/section/", mocks.MockNuevaSectionRequestConflict)
 	r.ServeHTTP(res, req)
 	assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
-	resData := map[string]string{}
+	resData := struct {
+		Message string           `json:"message"`
+		Details []web.FieldError `json:"details"`
+	}{}
 	jsonErr := json.Unmarshal(res.Body.Bytes(), &resData)
 	assert.Nil(t, jsonErr)
-	responseErr := fmt.Errorf(resData["message"])
+	responseErr := fmt.Errorf(resData.Message)
 	assert.EqualError(t, responseErr, expectedMessage)
+	assert.Len(t, resData.Details, 8)
 }
 
 func TestCreateInvalidJSONHandler(t *testing.T) {
@@ -367,3 +407,43 @@ func TestDeleteHandler(t *testing.T) {
 	r.ServeHTTP(res, req)
 	assert.Equal(t, http.StatusNoContent, res.Code)
 }
+
+func TestGetAllInvalidIncludeDeletedHandler(t *testing.T) {
+	r := createSectionServer()
+	req, res := tests.CreateRequestTest(http.MethodGet, "/section/?include_deleted=maybe", nil)
+	r.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestRestoreOkHandler(t *testing.T) {
+	deletedAt := "2022-01-01 00:00:00"
+	deleted := mocks.MockListaSections[0]
+	deleted.DeletedAt = &deletedAt
+	mService := mockSectionService{[]domain.Section{deleted}}
+	s := NewSection(&mService)
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.POST("/section/:id/restore", s.Restore())
+
+	req, res := tests.CreateRequestTest(http.MethodPost, fmt.Sprintf("/section/%d/restore", deleted.ID), nil)
+	r.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	resData := map[string]domain.Section{}
+	jsonErr := json.Unmarshal(res.Body.Bytes(), &resData)
+	assert.Nil(t, jsonErr)
+	assert.Nil(t, resData["data"].DeletedAt)
+}
+
+func TestRestoreNotDeletedHandler(t *testing.T) {
+	r := createSectionServer()
+	req, res := tests.CreateRequestTest(http.MethodPost, "/section/1/restore", nil)
+	r.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusNotFound, res.Code)
+}
+
+func TestRestoreErrorHandler(t *testing.T) {
+	r := createSectionErrorServer()
+	req, res := tests.CreateRequestTest(http.MethodPost, "/section/1/restore", nil)
+	r.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -23,13 +24,6 @@ type postSeller struct {
 	Telephone   string `json:"telephone" binding:"required"`
 }
 
-type patchSeller struct {
-	CID         *int   `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-}
-
 func NewSeller(s seller.Service) *Seller {
 	return &Seller{sellerService: s}
 }
@@ -37,18 +31,32 @@ func NewSeller(s seller.Service) *Seller {
 // GetAllSeller godoc
 // @Summary Get all Sellers
 // @Tags Sellers
-// @Description get all Sellers
-// @Produce json
+// @Description get all Sellers, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
+// @Produce json,text/csv,application/x-ndjson
+// @Param include_deleted query bool false "include deleted sellers"
 // @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 500 {object} web.errorResponse
 // @Router /sellers [get]
 func (s *Seller) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		p, err := s.sellerService.GetAll(c)
+		withDeleted, err := includeDeleted(c)
+		if err != nil {
+			web.Error(c, 400, "invalid include_deleted, must be boolean")
+			return
+		}
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.Seller{}, func(write func(interface{}) error) error {
+				return s.sellerService.StreamAll(c, withDeleted, func(se domain.Seller) error { return write(se) })
+			})
+			return
+		}
+
+		p, err := s.sellerService.GetAll(c, withDeleted)
 
 		if err != nil {
-			web.Error(c, 500, "internal server error")
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
@@ -97,8 +105,8 @@ func (s *Seller) Create() gin.HandlerFunc {
 		var req RequestSellerPost
 
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			if strings.Contains(err.Error(), "'required' tag") {
-				web.Error(ctx, 422, err.Error())
+			if web.FieldErrors(err) != nil {
+				web.ValidationError(ctx, 422, err)
 				return
 			}
 			web.Error(ctx, 400, err.Error())
@@ -120,7 +128,7 @@ func (s *Seller) Create() gin.HandlerFunc {
 				web.Error(ctx, 409, err.Error())
 				return
 			}
-			web.Error(ctx, 500, "internal server error")
+			web.ServerError(ctx, err, "internal server error")
 			return
 		}
 		//return and add id
@@ -129,23 +137,66 @@ func (s *Seller) Create() gin.HandlerFunc {
 	}
 }
 
+// ImportSellers godoc
+// @Summary Import Sellers
+// @Tags Sellers
+// @Description create many Sellers from a CSV file with a header of JSON member names, or from NDJSON. Every row is validated and the valid ones are saved in batched transactions; with dry_run nothing is saved
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param dry_run query bool false "validate the rows without saving them"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 415 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /sellers/bulk [post]
+func (s *Seller) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun, rows, ok := bulkRows(c, func() interface{} { return &RequestSellerPost{} })
+		if !ok {
+			return
+		}
+
+		valid := validRows(rows)
+		sellers := make([]domain.Seller, len(valid))
+		for j, i := range valid {
+			req := rows[i].Value.(*RequestSellerPost)
+			sellers[j] = domain.Seller{
+				CID:         req.CID,
+				CompanyName: req.CompanyName,
+				Address:     req.Address,
+				Telephone:   req.Telephone,
+				LocalityId:  req.LocalityId,
+			}
+		}
+
+		results, err := s.sellerService.Import(c, sellers, dryRun)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		bulkReport(c, dryRun, rows, valid, results)
+	}
+}
+
 // UpadteSellers godoc
 // @Summary Update Sellers
 // @Tags Sellers
-// @Description update Sellers by id
-// @Accept json
+// @Description update Sellers by id applying a JSON merge patch (RFC 7396)
+// @Accept json,application/merge-patch+json
 // @Produce json
 // @Param id path int true "sellers id"
-// @Param sellers body patchSeller true "sellers to update"
+// @Param sellers body RequestSellerPost true "fields of the seller to change"
 // @Success 200 {object} web.response
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 409 {object} web.errorResponse
+// @Failure 415 {object} web.errorResponse
+// @Failure 422 {object} web.errorResponse
 // @Failure 500 {object} web.errorResponse
 // @Router /sellers/{id} [patch]
 func (s *Seller) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req patchSeller
+		var req RequestSellerPost
 
 		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 
@@ -160,33 +211,26 @@ func (s *Seller) Update() gin.HandlerFunc {
 			return
 		}
 
-		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 404, err.Error())
+		if !mergePatch(c, se, &req, 422) {
 			return
 		}
-		if req.CID != nil {
-			if *req.CID != se.CID {
-				if s.sellerService.Exists(c, *req.CID) {
-					web.Error(c, 409, "there is already a seller with that cid")
-					return
-				}
-				se.CID = *req.CID
-			}
-		}
-
-		if req.CompanyName != "" {
-			se.CompanyName = req.CompanyName
-		}
-		if req.Address != "" {
-			se.Address = req.Address
+		if err := web.Validate(&req); err != nil {
+			web.ValidationError(c, 422, err)
+			return
 		}
-		if req.Telephone != "" {
-			se.Telephone = req.Telephone
+		if req.CID != se.CID && s.sellerService.Exists(c, req.CID) {
+			web.Error(c, 409, "there is already a seller with that cid")
+			return
 		}
 
+		se.CID = req.CID
+		se.CompanyName = req.CompanyName
+		se.Address = req.Address
+		se.Telephone = req.Telephone
+		se.LocalityId = req.LocalityId
+
 		if err := s.sellerService.Update(c, se); err != nil {
-			web.Error(c, 500, "internal server error")
-			fmt.Printf("Error: %v\n", err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
@@ -203,6 +247,7 @@ func (s *Seller) Update() gin.HandlerFunc {
 // @Success 204 {object} nil
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
 // @Router /sellers/{id} [delete]
 func (s *Seller) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -215,15 +260,130 @@ func (s *Seller) Delete() gin.HandlerFunc {
 		}
 
 		err = s.sellerService.Delete(c, int(id))
-		if err != nil {
+		if dependentsConflict(c, err) {
+			return
+		}
+		if errors.Is(err, seller.ErrNotFound) {
 			web.Error(c, 404, "no seller with the id was found %d", id)
 			return
 		}
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
 
 		web.Success(c, 204, nil)
 	}
 }
 
+// RestoreSeller godoc
+// @Summary Restore Seller by id
+// @Tags Sellers
+// @Description restore a deleted Seller by id
+// @Produce json
+// @Param id path int true "Seller id"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /sellers/{id}/restore [post]
+func (s *Seller) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := strconv.Atoi(c.Param("id"))
+
+		if err != nil {
+			web.Error(c, 400, "invalid id, must be integer")
+			return
+		}
+
+		err = s.sellerService.Restore(c, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, seller.ErrNotFound):
+				web.Error(c, 404, "no deleted seller with the id was found %d", id)
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+
+		se, err := s.sellerService.Get(c, id)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		web.Success(c, 200, se)
+	}
+}
+
+// ReportSeller godoc
+// @Summary Get the report of a Seller
+// @Tags Sellers
+// @Description get the products count, on-hand stock, purchase orders count and revenue of a Seller. Only the purchase orders placed between from and to are counted; they accept RFC 3339 timestamps or YYYY-MM-DD dates and a date in to includes the whole day
+// @Produce json
+// @Param id path int true "Seller id"
+// @Param from query string false "lower bound of the order date"
+// @Param to query string false "upper bound of the order date"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /sellers/{id}/report [get]
+func (s *Seller) Report() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		se, errCode, err := getSellerByParamID(s, c)
+		if err != nil {
+			web.Error(c, errCode, err.Error())
+			return
+		}
+		dates, ok := dateRange(c)
+		if !ok {
+			return
+		}
+
+		report, err := s.sellerService.Report(c, se.ID, dates)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, report)
+	}
+}
+
+// ReportSellers godoc
+// @Summary Rank Sellers
+// @Tags Sellers
+// @Description get the report of every Seller ranked by revenue, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson. Only the purchase orders placed between from and to are counted
+// @Produce json,text/csv,application/x-ndjson
+// @Param from query string false "lower bound of the order date"
+// @Param to query string false "upper bound of the order date"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /sellers/report [get]
+func (s *Seller) Reports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dates, ok := dateRange(c)
+		if !ok {
+			return
+		}
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.SellerReport{}, func(write func(interface{}) error) error {
+				return s.sellerService.StreamReports(c, dates, func(sr domain.SellerReport) error { return write(sr) })
+			})
+			return
+		}
+
+		reports, err := s.sellerService.Reports(c, dates)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, reports)
+	}
+}
+
 func getSellerByParamID(s *Seller, c *gin.Context) (domain.Seller, int, error) {
 	// Convierto id en entero y en caso de error lo retorno
 	se := domain.Seller{}
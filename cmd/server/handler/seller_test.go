@@ -25,6 +25,8 @@ func createServerSeller(sellerService seller.Service) *gin.Engine {
 	{
 		sellersGroup.GET("/", seller.GetAll())
 		sellersGroup.GET("/:id", seller.Get())
+		sellersGroup.GET("/report", seller.Reports())
+		sellersGroup.GET("/:id/report", seller.Report())
 		sellersGroup.POST("/", seller.Create())
 		sellersGroup.PATCH("/:id", seller.Update())
 		sellersGroup.DELETE("/:id", seller.Delete())
@@ -311,6 +313,7 @@ func TestUpdateConflictSellerHandler(t *testing.T) {
 		CompanyName: "MELI",
 		Address:     "Argentina",
 		Telephone:   "123",
+		LocalityId:  1,
 	}
 
 	objRes := struct {
@@ -362,7 +365,8 @@ func TestDeleteNonExistentSellerHandler(t *testing.T) {
 	//arrange
 	r := createServerSeller(&mocks.MockServiceSeller{
 		MockRepo: mocks.MockSellerRepo{
-			MockSeller: mocks.MockListSellers,
+			MockSeller:  mocks.MockListSellers,
+			ErrNotFound: seller.ErrNotFound,
 		},
 	})
 
@@ -444,7 +448,7 @@ func TestUpdateBadJsonSellerHandler(t *testing.T) {
 	r.ServeHTTP(rr, req)
 
 	//Test de código de respuesta válido
-	assert.Equal(t, 404, rr.Code)
+	assert.Equal(t, 422, rr.Code)
 
 	// Test cuerpo de respuesta válido
 	err := json.Unmarshal(rr.Body.Bytes(), &objRes)
@@ -475,3 +479,46 @@ func TestFindIDNonIntSellerHandler(t *testing.T) {
 	assert.Equal(t, "bad_request", objRes.Code)
 	assert.Equal(t, "invalid id, must be integer", objRes.Message)
 }
+
+func TestReportSellerHandler(t *testing.T) {
+	reports := []domain.SellerReport{
+		{SellerID: 2, CID: 3, CompanyName: "DIGITAL HOUSE", ProductsCount: 4, Stock: 120, PurchaseOrders: 3, Revenue: 450.5},
+		{SellerID: 1, CID: 2, CompanyName: "MELI", ProductsCount: 1, Stock: 10},
+	}
+	cases := []struct {
+		name string
+		url  string
+		code int
+		data interface{}
+	}{
+		{name: "seller", url: "/sellers/2/report?from=2022-01-01&to=2022-12-31", code: 200, data: reports[0]},
+		{name: "ranking", url: "/sellers/report", code: 200, data: reports},
+		{name: "unknown seller", url: "/sellers/9/report", code: 404},
+		{name: "id not int", url: "/sellers/notInt/report", code: 400},
+		{name: "invalid from", url: "/sellers/2/report?from=yesterday", code: 400},
+		{name: "invalid to", url: "/sellers/report?to=2022-13-01", code: 400},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createServerSeller(&mocks.MockServiceSeller{
+				MockRepo: mocks.MockSellerRepo{
+					MockSeller: []domain.Seller{
+						{ID: 1, CID: 2, CompanyName: "MELI"},
+						{ID: 2, CID: 3, CompanyName: "DIGITAL HOUSE"},
+					},
+					MockReports: reports,
+				},
+			})
+
+			req, rr := tests.CreateRequestTest(http.MethodGet, tc.url, nil)
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.code, rr.Code)
+			if tc.data != nil {
+				want, err := json.Marshal(map[string]interface{}{"data": tc.data})
+				assert.Nil(t, err)
+				assert.JSONEq(t, string(want), rr.Body.String())
+			}
+		})
+	}
+}
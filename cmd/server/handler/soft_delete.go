@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// includeDeletedParam is the query parameter that makes list endpoints also
+// return soft deleted records.
+const includeDeletedParam = "include_deleted"
+
+// includeDeleted reads the include_deleted query parameter, which defaults to
+// false.
+func includeDeleted(c *gin.Context) (bool, error) {
+	value := c.Query(includeDeletedParam)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
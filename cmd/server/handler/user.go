@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/role"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/user"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	userService user.Service
+	roleService role.Service
+	tokens      *auth.Tokens
+}
+
+type requestCredentials struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type loginResponse struct {
+	Token     string `json:"token"`
+	TokenType string `json:"token_type"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type requestAssignRole struct {
+	RoleID int `json:"rol_id" binding:"required"`
+}
+
+func NewUser(u user.Service, r role.Service, t *auth.Tokens) *User {
+	return &User{
+		userService: u,
+		roleService: r,
+		tokens:      t,
+	}
+}
+
+// Login godoc
+// @Summary Login
+// @Tags Users
+// @Description exchange username and password for a signed token
+// @Accept json
+// @Produce json
+// @Param credentials body requestCredentials true "User credentials"
+// @Success 200 {object} web.response
+// @Failure 401 {object} web.errorResponse
+// @Failure 422 {object} web.errorResponse
+// @Router /login [post]
+func (u *User) Login() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requestCredentials
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		usr, err := u.userService.Login(c, req.Username, req.Password)
+		if err != nil {
+			if errors.Is(err, user.ErrInvalidCredentials) {
+				web.Error(c, http.StatusUnauthorized, err.Error())
+				return
+			}
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		roles, err := u.roleService.Names(c, usr.ID)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		token, expiresAt, err := u.tokens.Issue(usr.ID, usr.Username, roles)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		web.Success(c, http.StatusOK, loginResponse{
+			Token:     token,
+			TokenType: "Bearer",
+			ExpiresAt: expiresAt.Unix(),
+		})
+	}
+}
+
+// CreateUser godoc
+// @Summary Create user
+// @Tags Users
+// @Description create a user able to log in
+// @Accept json
+// @Produce json
+// @Param user body requestCredentials true "User to store"
+// @Success 201 {object} web.response
+// @Failure 409 {object} web.errorResponse
+// @Failure 422 {object} web.errorResponse
+// @Router /users [post]
+func (u *User) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requestCredentials
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if strings.TrimSpace(req.Username) == "" {
+			web.Error(c, http.StatusUnprocessableEntity, "field username cant be empty")
+			return
+		}
+
+		usr, err := u.userService.Register(c, req.Username, req.Password)
+		if err != nil {
+			if errors.Is(err, user.ErrUsernameTaken) {
+				web.Error(c, http.StatusConflict, err.Error())
+				return
+			}
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		web.Success(c, http.StatusCreated, usr)
+	}
+}
+
+// ListRoles godoc
+// @Summary List roles
+// @Tags Users
+// @Description get all roles that can be assigned to users
+// @Produce json
+// @Success 200 {object} web.response
+// @Failure 500 {object} web.errorResponse
+// @Router /roles [get]
+func (u *User) GetRoles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, err := u.roleService.GetAll(c)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		if len(roles) == 0 {
+			web.Success(c, http.StatusOK, []domain.Role{})
+			return
+		}
+		web.Success(c, http.StatusOK, roles)
+	}
+}
+
+// ListUserRoles godoc
+// @Summary List roles of a user
+// @Tags Users
+// @Description get the roles assigned to a user
+// @Produce json
+// @Param id path int true "user id"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /users/{id}/roles [get]
+func (u *User) GetUserRoles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		usr, errCode, err := getUserByParamID(u, c)
+		if err != nil {
+			web.Error(c, errCode, err.Error())
+			return
+		}
+
+		roles, err := u.roleService.GetByUser(c, usr.ID)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		if len(roles) == 0 {
+			web.Success(c, http.StatusOK, []domain.Role{})
+			return
+		}
+		web.Success(c, http.StatusOK, roles)
+	}
+}
+
+// AssignRole godoc
+// @Summary Assign role
+// @Tags Users
+// @Description assign a role to a user
+// @Accept json
+// @Produce json
+// @Param id path int true "user id"
+// @Param role body requestAssignRole true "Role to assign"
+// @Success 204 {object} nil
+// @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Failure 422 {object} web.errorResponse
+// @Router /users/{id}/roles [post]
+func (u *User) AssignRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		usr, errCode, err := getUserByParamID(u, c)
+		if err != nil {
+			web.Error(c, errCode, err.Error())
+			return
+		}
+
+		var req requestAssignRole
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		if err := u.roleService.Assign(c, usr.ID, req.RoleID); err != nil {
+			switch {
+			case errors.Is(err, role.ErrNotFound):
+				web.Error(c, http.StatusNotFound, err.Error())
+			case errors.Is(err, role.ErrAlreadyAssigned):
+				web.Error(c, http.StatusConflict, err.Error())
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+// RevokeRole godoc
+// @Summary Revoke role
+// @Tags Users
+// @Description remove a role from a user
+// @Param id path int true "user id"
+// @Param roleId path int true "role id"
+// @Success 204 {object} nil
+// @Failure 400 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /users/{id}/roles/{roleId} [delete]
+func (u *User) RevokeRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		usr, errCode, err := getUserByParamID(u, c)
+		if err != nil {
+			web.Error(c, errCode, err.Error())
+			return
+		}
+
+		roleID, err := strconv.Atoi(c.Param("roleId"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "role id must be integer")
+			return
+		}
+
+		if err := u.roleService.Revoke(c, usr.ID, roleID); err != nil {
+			if errors.Is(err, role.ErrNotAssigned) {
+				web.Error(c, http.StatusNotFound, err.Error())
+				return
+			}
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+func getUserByParamID(u *User, c *gin.Context) (domain.User, int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.User{}, http.StatusBadRequest, fmt.Errorf("id must be integer")
+	}
+
+	usr, err := u.userService.Get(c, id)
+	if err != nil {
+		return domain.User{}, http.StatusNotFound, fmt.Errorf("user not found")
+	}
+
+	return usr, 0, nil
+}
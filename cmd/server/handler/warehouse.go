@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -17,17 +18,9 @@ type Warehouse struct {
 type postRequestWH struct {
 	Address            string `json:"address"`
 	Telephone          string `json:"telephone"`
-	WarehouseCode      string `json:"warehouse_code"`
-	MinimumCapacity    int    `json:"minimum_capacity"`
-	MinimumTemperature int    `json:"minimum_temperature"`
-}
-
-type patchRequestWH struct {
-	Address            string `json:"address"`
-	Telephone          string `json:"telephone"`
-	WarehouseCode      string `json:"warehouse_code"`
-	MinimumCapacity    *int   `json:"minimum_capacity"`
-	MinimumTemperature *int   `json:"minimum_temperature"`
+	WarehouseCode      string `json:"warehouse_code" binding:"required"`
+	MinimumCapacity    int    `json:"minimum_capacity" binding:"min=0"`
+	MinimumTemperature int    `json:"minimum_temperature" binding:"temperature"`
 }
 
 func NewWarehouse(w warehouse.Service) *Warehouse {
@@ -54,6 +47,7 @@ func (w *Warehouse) Get() gin.HandlerFunc {
 			web.Error(c, errCode, err.Error())
 			return
 		}
+		web.SetETag(c, wh.Version)
 		web.Success(c, 200, wh)
 	}
 }
@@ -61,18 +55,31 @@ func (w *Warehouse) Get() gin.HandlerFunc {
 // GetWarehouse godoc
 // @Summary Get all Warehouses
 // @Tags Warehouses
-// @Description get all Warehouses
-// @Produce json
+// @Description get all Warehouses, streamed as CSV or NDJSON when the Accept header asks for text/csv or application/x-ndjson
+// @Produce json,text/csv,application/x-ndjson
+// @Param include_deleted query bool false "include deleted warehouses"
 // @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
 // @Failure 500 {object} web.errorResponse
 // @Router /warehouses [get]
 func (w *Warehouse) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		withDeleted, err := includeDeleted(c)
+		if err != nil {
+			web.Error(c, 400, "%s", "include_deleted must be boolean")
+			return
+		}
+		// Si el Accept pide CSV o NDJSON, envio los Warehouses a medida que se leen
+		if format := web.ExportFormat(c); format != "" {
+			web.Export(c, format, domain.Warehouse{}, func(write func(interface{}) error) error {
+				return w.warehouseService.StreamAll(c, withDeleted, func(wh domain.Warehouse) error { return write(wh) })
+			})
+			return
+		}
 		//Pido al service todos los Warehouses, si hay error devuelvo un 500
-		whs, err := w.warehouseService.GetAll(c)
+		whs, err := w.warehouseService.GetAll(c, withDeleted)
 		if err != nil {
-			web.Error(c, 500, "internal server error")
-			// fmt.Printf("ERROR GetAll(): %v\n", err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 		// Retorno una lista de WHs o una lista vacia si no hay ninguno en la BBDD.
@@ -100,15 +107,10 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req postRequestWH
 
-		// Obtengo el Request del body y si hay error lo retorno
+		// Obtengo el Request del body y si hay error, o algun campo no es
+		// valido, lo retorno
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "%s", err.Error())
-			return
-		}
-
-		// Comprobación de existencia del campo WarehouseCode
-		if req.WarehouseCode == "" {
-			web.Error(c, 422, "%s", "field warehouse_code is required")
+			web.ValidationError(c, 422, err)
 			return
 		}
 
@@ -130,8 +132,7 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 		// Guardo el WH en la BBDD
 		id, err := w.warehouseService.Save(c, wh)
 		if err != nil {
-			web.Error(c, 500, "internal server error")
-			// fmt.Printf("ERROR Create(): %v\n", err.Error())
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
@@ -144,21 +145,24 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 // UpadteWarehouses godoc
 // @Summary Update Warehouses
 // @Tags Warehouses
-// @Description update warehouses by id
-// @Accept json
+// @Description update warehouses by id applying a JSON merge patch (RFC 7396)
+// @Accept json,application/merge-patch+json
 // @Produce json
 // @Param id path string true "Warehouse id"
-// @Param warehouse body patchRequestWH true "Warehouse to update"
+// @Param warehouse body postRequestWH true "Warehouse fields to update"
 // @Success 200 {object} web.response
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 409 {object} web.errorResponse
 // @Failure 422 {object} web.errorResponse
 // @Failure 500 {object} web.errorResponse
+// @Param If-Match header string false "ETag of the version being changed"
+// @Failure 412 {object} web.errorResponse
+// @Failure 415 {object} web.errorResponse
 // @Router /warehouses/{id} [patch]
 func (w *Warehouse) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req patchRequestWH
+		var req postRequestWH
 
 		//Obtengo el wh con la funcion auxiliar o retorno un error
 		wh, errCode, err := getWHByParamID(w, c)
@@ -167,32 +171,48 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 			return
 		}
 
-		// Obtengo el Request del body y si hay error lo retorno
-		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, err.Error())
+		// Si se envio If-Match y no coincide con la version actual retorno un 412
+		if !versionMatches(c, wh.Version) {
 			return
 		}
 
-		// Si se envio un Warehouse y es diferente al actual, verifico que no exista en la BBDD
-		if req.WarehouseCode != "" && req.WarehouseCode != wh.WarehouseCode {
-			if w.warehouseService.Exists(c, req.WarehouseCode) {
-				web.Error(c, 409, "warehouse with code %v already exists", req.WarehouseCode)
-				return
-			}
-			wh.WarehouseCode = req.WarehouseCode
+		// Aplico el merge patch del body sobre el wh actual y si hay error lo retorno
+		if !mergePatch(c, wh, &req, 422) {
+			return
 		}
 
-		// Actualizo los campos que se hayan enviado
-		updateWHFields(req, &wh)
+		// El resultado debe cumplir las mismas validaciones que el Create
+		if err := web.Validate(&req); err != nil {
+			web.ValidationError(c, 422, err)
+			return
+		}
+
+		// Si cambio el codigo de Warehouse, verifico que no exista en la BBDD
+		if req.WarehouseCode != wh.WarehouseCode && w.warehouseService.Exists(c, req.WarehouseCode) {
+			web.Error(c, 409, "warehouse with code %v already exists", req.WarehouseCode)
+			return
+		}
 
-		// Envio el update al service y retorno un error 500 si existe
+		// Actualizo los campos con el resultado del merge
+		wh.Address = req.Address
+		wh.Telephone = req.Telephone
+		wh.WarehouseCode = req.WarehouseCode
+		wh.MinimumCapacity = req.MinimumCapacity
+		wh.MinimumTemperature = req.MinimumTemperature
+
+		// Envio el update al service: si otro request lo modifico retorno un 412,
+		// en caso de otro error retorno un 500
 		if err := w.warehouseService.Update(c, wh); err != nil {
-			web.Error(c, 500, "internal server error")
-			// fmt.Printf("ERROR Update(): %v\n", err.Error())
+			if versionConflict(c, err) {
+				return
+			}
+			web.ServerError(c, err, "internal server error")
 			return
 		}
 
-		// Retorno el wh actualizado
+		// Retorno el wh actualizado con su nueva version
+		wh.Version++
+		web.SetETag(c, wh.Version)
 		web.Success(c, 200, wh)
 	}
 }
@@ -204,6 +224,9 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 // @Param id path string true "Warehouse id"
 // @Success 204 {object} nil
 // @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Param If-Match header string false "ETag of the version being changed"
+// @Failure 412 {object} web.errorResponse
 // @Router /warehouses/{id} [delete]
 func (w *Warehouse) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -213,9 +236,32 @@ func (w *Warehouse) Delete() gin.HandlerFunc {
 			web.Error(c, 400, "%s", "id must be integer")
 			return
 		}
-		// Llamo al Delete del service y en caso de error retorno un 404
-		if err := w.warehouseService.Delete(c, id); err != nil {
-			web.Error(c, 404, "warehouse not found")
+		// Si se envio If-Match verifico que coincida con la version actual y
+		// borro solo en esa version
+		version := 0
+		if c.GetHeader(web.IfMatchHeader) != "" {
+			wh, errCode, err := getWHByParamID(w, c)
+			if err != nil {
+				web.Error(c, errCode, err.Error())
+				return
+			}
+			if !versionMatches(c, wh.Version) {
+				return
+			}
+			version = wh.Version
+		}
+		// Llamo al Delete del service: si hay dependientes retorno un 409,
+		// si cambio la version un 412, si no existe un 404 y en caso de otro
+		// error un 500
+		if err := w.warehouseService.Delete(c, id, version); err != nil {
+			switch {
+			case dependentsConflict(c, err):
+			case versionConflict(c, err):
+			case errors.Is(err, warehouse.ErrNotFound):
+				web.Error(c, 404, "warehouse not found")
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
 			return
 		}
 		// Si todo salió bien retorno una 204 y una respuesta vacia
@@ -223,6 +269,90 @@ func (w *Warehouse) Delete() gin.HandlerFunc {
 	}
 }
 
+// RestoreWarehouse godoc
+// @Summary Restore Warehouse by id
+// @Tags Warehouses
+// @Description restore a deleted warehouse by id
+// @Produce json
+// @Param id path string true "Warehouse id"
+// @Success 200 {object} web.response
+// @Failure 404 {object} web.errorResponse
+// @Router /warehouses/{id}/restore [post]
+func (w *Warehouse) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Convierto id en entero y en caso de error lo retorno
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, "%s", "id must be integer")
+			return
+		}
+		// Llamo al Restore del service y en caso de error retorno un 404
+		if err := w.warehouseService.Restore(c, id); err != nil {
+			switch {
+			case errors.Is(err, warehouse.ErrNotFound):
+				web.Error(c, 404, "deleted warehouse not found")
+			default:
+				web.ServerError(c, err, "internal server error")
+			}
+			return
+		}
+		// Retorno el wh restaurado
+		wh, err := w.warehouseService.Get(c, id)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, wh)
+	}
+}
+
+// UtilizationWarehouse godoc
+// @Summary Get the utilization of a Warehouse
+// @Tags Warehouses
+// @Description get how full a warehouse is: capacity used and free space per section and in total, the sections below their minimum or above their maximum capacity and the mix of product types stored, built from its sections and product batches
+// @Produce json
+// @Param id path int true "Warehouse id"
+// @Success 200 {object} web.response
+// @Failure 400 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /warehouses/{id}/utilization [get]
+func (w *Warehouse) Utilization() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		wh, errCode, err := getWHByParamID(w, c)
+		if err != nil {
+			web.Error(c, errCode, err.Error())
+			return
+		}
+
+		u, err := w.warehouseService.Utilization(c, wh.ID)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, u)
+	}
+}
+
+// UtilizationWarehouses godoc
+// @Summary Get the utilization of every Warehouse
+// @Tags Warehouses
+// @Description get how full each warehouse is, as reported by /warehouses/{id}/utilization
+// @Produce json
+// @Success 200 {object} web.response
+// @Failure 500 {object} web.errorResponse
+// @Router /warehouses/utilization [get]
+func (w *Warehouse) Utilizations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, err := w.warehouseService.Utilizations(c)
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			return
+		}
+		web.Success(c, 200, u)
+	}
+}
+
 func getWHByParamID(w *Warehouse, c *gin.Context) (domain.Warehouse, int, error) {
 	// Convierto id en entero y en caso de error lo retorno
 	wh := domain.Warehouse{}
@@ -239,21 +369,3 @@ func getWHByParamID(w *Warehouse, c *gin.Context) (domain.Warehouse, int, error)
 
 	return wh, 0, nil
 }
-
-func updateWHFields(req patchRequestWH, wh *domain.Warehouse) {
-	if req.Address != "" {
-		wh.Address = req.Address
-	}
-
-	if req.Telephone != "" {
-		wh.Telephone = req.Telephone
-	}
-
-	if req.MinimumCapacity != nil {
-		wh.MinimumCapacity = *req.MinimumCapacity
-	}
-
-	if req.MinimumTemperature != nil {
-		wh.MinimumTemperature = *req.MinimumTemperature
-	}
-}
@@ -26,6 +26,8 @@ func createWarehouseServer(warehouseService warehouse.Service) *gin.Engine {
 		whRoutes.GET("/", handler.GetAll())
 		whRoutes.POST("/", handler.Create())
 		whRoutes.GET("/:id", handler.Get())
+		whRoutes.GET("/utilization", handler.Utilizations())
+		whRoutes.GET("/:id/utilization", handler.Utilization())
 		whRoutes.PATCH("/:id", handler.Update())
 		whRoutes.DELETE("/:id", handler.Delete())
 	}
@@ -462,8 +464,8 @@ func TestUpdateServerErrorWarehouse(t *testing.T) {
 		},
 	})
 
-	warehouseUpdated := domain.Warehouse{
-		MinimumCapacity: 10,
+	warehouseUpdated := map[string]interface{}{
+		"minimum_capacity": 10,
 	}
 
 	objRes := struct {
@@ -489,7 +491,8 @@ func TestUpdateServerErrorWarehouse(t *testing.T) {
 func TestDeleteNonExistentWarehouse(t *testing.T) {
 	r := createWarehouseServer(&mocks.MockWarehouseService{
 		MockRepository: mocks.MockWarehouseRepository{
-			MockData: mocks.MockDataWarehouse,
+			MockData:    mocks.MockDataWarehouse,
+			ErrNotFound: warehouse.ErrNotFound,
 		},
 	})
 
@@ -512,6 +515,96 @@ func TestDeleteNonExistentWarehouse(t *testing.T) {
 	assert.Equal(t, "warehouse not found", objRes.Message)
 }
 
+func TestDeleteWarehouseWithDependents(t *testing.T) {
+	r := createWarehouseServer(&mocks.MockWarehouseService{
+		MockRepository: mocks.MockWarehouseRepository{
+			MockData: mocks.MockDataWarehouse,
+			MockDependents: map[int][]domain.Dependent{
+				1: {{Entity: "sections", Count: 2}, {Entity: "employees", Count: 1}},
+			},
+		},
+	})
+
+	objRes := struct {
+		Code    string             `json:"code"`
+		Message string             `json:"message"`
+		Details []domain.Dependent `json:"details"`
+	}{}
+
+	req, rr := tests.CreateRequestTest(http.MethodDelete, "/warehouses/1", nil)
+	r.ServeHTTP(rr, req)
+	//Test de código de respuesta válido
+	assert.Equal(t, 409, rr.Code)
+
+	// Test cuerpo de respuesta con los dependientes
+	err := json.Unmarshal(rr.Body.Bytes(), &objRes)
+	assert.Nil(t, err)
+	assert.Equal(t, "conflict", objRes.Code)
+	assert.Equal(t, "warehouse 1 is still referenced by 2 sections, 1 employees", objRes.Message)
+	assert.Equal(t, []domain.Dependent{{Entity: "sections", Count: 2}, {Entity: "employees", Count: 1}}, objRes.Details)
+}
+
+func TestGetWarehouseETag(t *testing.T) {
+	r := createWarehouseServer(&mocks.MockWarehouseService{
+		MockRepository: mocks.MockWarehouseRepository{
+			MockData: []domain.Warehouse{{ID: 1, WarehouseCode: "DHM", Version: 3}},
+		},
+	})
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/warehouses/1", nil)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, 200, rr.Code)
+	assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+}
+
+func TestUpdateIfMatchWarehouse(t *testing.T) {
+	cases := []struct {
+		name    string
+		ifMatch string
+		status  int
+		etag    string
+	}{
+		{"without If-Match", "", 200, `"4"`},
+		{"matching version", `"3"`, 200, `"4"`},
+		{"any version", "*", 200, `"4"`},
+		{"stale version", `"2"`, 412, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createWarehouseServer(&mocks.MockWarehouseService{
+				MockRepository: mocks.MockWarehouseRepository{
+					MockData: []domain.Warehouse{{ID: 1, WarehouseCode: "DHM", Version: 3}},
+				},
+			})
+
+			req, rr := tests.CreateRequestTest(http.MethodPatch, "/warehouses/1", map[string]interface{}{"address": "Calle 1"})
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.status, rr.Code)
+			assert.Equal(t, tc.etag, rr.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestDeleteIfMatchStaleWarehouse(t *testing.T) {
+	r := createWarehouseServer(&mocks.MockWarehouseService{
+		MockRepository: mocks.MockWarehouseRepository{
+			MockData: []domain.Warehouse{{ID: 1, WarehouseCode: "DHM", Version: 3}},
+		},
+	})
+
+	req, rr := tests.CreateRequestTest(http.MethodDelete, "/warehouses/1", nil)
+	req.Header.Set("If-Match", `"1"`)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, 412, rr.Code)
+}
+
 func TestDeleteOkWarehouse(t *testing.T) {
 	r := createWarehouseServer(&mocks.MockWarehouseService{
 		MockRepository: mocks.MockWarehouseRepository{
@@ -582,3 +675,52 @@ func TestDeleteIDNonIntWarehouse(t *testing.T) {
 	assert.Equal(t, "bad_request", objRes.Code)
 	assert.Equal(t, "id must be integer", objRes.Message)
 }
+
+func TestUtilizationWarehouse(t *testing.T) {
+	repository := mocks.MockWarehouseRepository{
+		MockData:     []domain.Warehouse{{ID: 1, WarehouseCode: "DHM"}},
+		MockSections: []domain.SectionUtilization{{WarehouseID: 1, SectionID: 3, SectionNumber: 1, MinimumCapacity: 5, MaximumCapacity: 50, Used: 25}},
+		MockMix:      []domain.ProductTypeMix{{WarehouseID: 1, ProductTypeID: 1, Description: "frozen", Quantity: 25}},
+	}
+	utilization := domain.WarehouseUtilization{
+		WarehouseID:          1,
+		WarehouseCode:        "DHM",
+		Capacity:             50,
+		Used:                 25,
+		Free:                 25,
+		Utilization:          0.5,
+		SectionsBelowMinimum: []int{},
+		SectionsAboveMaximum: []int{},
+		Sections:             []domain.SectionUtilization{{WarehouseID: 1, SectionID: 3, SectionNumber: 1, MinimumCapacity: 5, MaximumCapacity: 50, Used: 25, Free: 25, Utilization: 0.5}},
+		ProductTypes:         []domain.ProductTypeMix{{WarehouseID: 1, ProductTypeID: 1, Description: "frozen", Quantity: 25, Share: 1}},
+	}
+
+	cases := []struct {
+		name    string
+		service warehouse.Service
+		url     string
+		code    int
+		data    interface{}
+	}{
+		{name: "warehouse", service: &mocks.MockWarehouseService{MockRepository: repository}, url: "/warehouses/1/utilization", code: 200, data: utilization},
+		{name: "all", service: &mocks.MockWarehouseService{MockRepository: repository}, url: "/warehouses/utilization", code: 200, data: []domain.WarehouseUtilization{utilization}},
+		{name: "not found", service: &mocks.MockWarehouseService{MockRepository: repository}, url: "/warehouses/9/utilization", code: 404},
+		{name: "id not int", service: &mocks.MockWarehouseService{MockRepository: repository}, url: "/warehouses/a/utilization", code: 400},
+		{name: "error", service: &mocks.MockWarehouseServiceError{MockRepository: repository}, url: "/warehouses/1/utilization", code: 500},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createWarehouseServer(tc.service)
+
+			req, rr := tests.CreateRequestTest(http.MethodGet, tc.url, nil)
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.code, rr.Code)
+			if tc.data != nil {
+				want, err := json.Marshal(map[string]interface{}{"data": tc.data})
+				assert.Nil(t, err)
+				assert.JSONEq(t, string(want), rr.Body.String())
+			}
+		})
+	}
+}
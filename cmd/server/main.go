@@ -1,25 +1,98 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/config"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/routes"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 	"github.com/gin-gonic/gin"
-	_ "github.com/go-sql-driver/mysql"
 )
 
+// shutdownTimeout is how long the requests in flight are given to finish
+// once the server is asked to stop.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	// NO MODIFICAR
-	db, _ := sql.Open("mysql", "meli_sprint_user:Meli_Sprint#123@/melisprint")
-	if err := db.Ping(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
 		panic(err)
 	}
-	r := gin.Default()
 
-	router := routes.NewRouter(r, db)
-	router.MapRoutes()
+	shutdown, err := tracing.Setup(context.Background(), cfg.TracingExporter, cfg.TracingEndpoint, os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	defer shutdown(context.Background())
+
+	var db *sql.DB
+	switch cfg.Storage {
+	case config.StorageMemory:
+	case config.StorageSQLite:
+		db, err = storage.Open(storage.SQLite, cfg.SQLitePath)
+		if err != nil {
+			panic(err)
+		}
+	default:
+		// NO MODIFICAR
+		db, err = storage.Open(storage.MySQL, "meli_sprint_user:Meli_Sprint#123@/melisprint")
+		if err != nil {
+			panic(err)
+		}
+		if err := db.Ping(); err != nil {
+			panic(err)
+		}
+	}
+	if db != nil {
+		defer db.Close()
+		stmts := storage.StatementsOf(db)
+		if err := stmts.PrepareAll(context.Background(), queries.Static); err != nil {
+			panic(err)
+		}
+		defer stmts.Close()
+	}
+	r := gin.New()
 
-	if err := r.Run(); err != nil {
+	router := routes.NewRouter(r, db, cfg)
+	router.MapRoutes(ctx)
+
+	srv := &http.Server{Addr: address(), Handler: r}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err = srv.Shutdown(shutdownCtx)
+	}
+	// The background work stops with ctx, and must be done with the database
+	// before the deferred calls close it.
+	stop()
+	router.Wait()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic(err)
 	}
 }
+
+// address is where the server listens: the port in PORT, as gin's Run
+// does, or 8080.
+func address() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+const bearerPrefix = "Bearer "
+
+// Authenticate rejects requests without a valid "Authorization: Bearer"
+// token and stores the caller's claims in the context under auth.ClaimsKey.
+func Authenticate(tokens *auth.Tokens) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			c.Header("WWW-Authenticate", "Bearer")
+			web.Error(c, http.StatusUnauthorized, "missing bearer token")
+			c.Abort()
+			return
+		}
+
+		claims, err := tokens.Parse(strings.TrimPrefix(header, bearerPrefix))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			web.Error(c, http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Set(auth.ClaimsKey, claims)
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAuthServer(tokens *auth.Tokens) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/private", Authenticate(tokens), func(c *gin.Context) {
+		claims, _ := auth.FromContext(c)
+		web.Success(c, http.StatusOK, claims.Username)
+	})
+	return r
+}
+
+func TestAuthenticateValidToken(t *testing.T) {
+	tokens := auth.NewTokens("secret", time.Hour)
+	r := createAuthServer(tokens)
+	token, _, err := tokens.Issue(1, "admin", nil)
+	assert.NoError(t, err)
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/private", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":"admin"}`, rr.Body.String())
+}
+
+func TestAuthenticateMissingToken(t *testing.T) {
+	r := createAuthServer(auth.NewTokens("secret", time.Hour))
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/private", nil)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAuthenticateRejectsForeignSignature(t *testing.T) {
+	r := createAuthServer(auth.NewTokens("secret", time.Hour))
+	token, _, err := auth.NewTokens("other", time.Hour).Issue(1, "admin", nil)
+	assert.NoError(t, err)
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/private", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAuthenticateRejectsExpiredToken(t *testing.T) {
+	tokens := auth.NewTokens("secret", -time.Minute)
+	r := createAuthServer(tokens)
+	token, _, err := tokens.Issue(1, "admin", nil)
+	assert.NoError(t, err)
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/private", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
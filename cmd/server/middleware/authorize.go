@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// Permissions maps a route, written as "METHOD /full/route/template", to the
+// roles allowed to call it.
+type Permissions map[string][]string
+
+// RoleSource looks up the current roles of a user.
+type RoleSource interface {
+	Names(ctx context.Context, userID int) ([]string, error)
+}
+
+// Authorize checks the caller's roles against perms. It must run after
+// Authenticate. Callers with the superuser role may call every route; safe
+// methods not listed in perms are open to any authenticated caller, while
+// any other unlisted route is reserved to the superuser. Requests matching
+// no route are let through to be answered with a 404.
+//
+// The roles of the token are trusted for safe methods only: other methods
+// are checked against the roles roles holds now, so that revoking a role
+// takes effect before the token expires. A nil roles trusts the token.
+func Authorize(perms Permissions, superuser string, roles RoleSource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := auth.FromContext(c)
+		if !ok {
+			web.Error(c, http.StatusUnauthorized, "missing bearer token")
+			c.Abort()
+			return
+		}
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+
+		if roles != nil && !isSafeMethod(c.Request.Method) {
+			current, err := roles.Names(c, claims.UserID)
+			if err != nil {
+				web.ServerError(c, err, "internal server error")
+				c.Abort()
+				return
+			}
+			claims.Roles = current
+			c.Set(auth.ClaimsKey, claims)
+		}
+
+		if claims.HasRole(superuser) {
+			c.Next()
+			return
+		}
+
+		allowed, listed := perms[c.Request.Method+" "+c.FullPath()]
+		if !listed && isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if !listed || !claims.HasRole(allowed...) {
+			web.Error(c, http.StatusForbidden, "user %s is not allowed to %s %s", claims.Username, c.Request.Method, c.Request.URL.Path)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAuthorizeServer(roles ...string) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	setClaims := func(c *gin.Context) {
+		c.Set(auth.ClaimsKey, auth.Claims{UserID: 1, Username: "user", Roles: roles})
+	}
+	perms := Permissions{
+		"POST /buyers/": {"sales"},
+		"GET /audit":    {"admin"},
+	}
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+
+	g := r.Group("/", setClaims, Authorize(perms, "admin", nil))
+	g.POST("/buyers/", ok)
+	g.GET("/buyers/", ok)
+	g.DELETE("/warehouses/:id", ok)
+	g.GET("/audit", ok)
+
+	return r
+}
+
+func TestAuthorize(t *testing.T) {
+	cases := []struct {
+		name   string
+		roles  []string
+		method string
+		url    string
+		status int
+	}{
+		{"listed route with role", []string{"sales"}, http.MethodPost, "/buyers/", http.StatusNoContent},
+		{"listed route without role", []string{"warehouse_operator"}, http.MethodPost, "/buyers/", http.StatusForbidden},
+		{"unlisted read", nil, http.MethodGet, "/buyers/", http.StatusNoContent},
+		{"listed read without role", []string{"sales"}, http.MethodGet, "/audit", http.StatusForbidden},
+		{"unlisted write", []string{"sales"}, http.MethodDelete, "/warehouses/1", http.StatusForbidden},
+		{"superuser unlisted write", []string{"admin"}, http.MethodDelete, "/warehouses/1", http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createAuthorizeServer(tc.roles...)
+			req, rr := tests.CreateRequestTest(tc.method, tc.url, nil)
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, tc.status, rr.Code)
+		})
+	}
+}
+
+// fakeRoles holds the current roles of every user.
+type fakeRoles map[int][]string
+
+func (f fakeRoles) Names(ctx context.Context, userID int) ([]string, error) {
+	roles, ok := f[userID]
+	if !ok {
+		return nil, errors.New("roles unavailable")
+	}
+	return roles, nil
+}
+
+func TestAuthorizeRechecksRolesOnWrites(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	current := fakeRoles{1: nil}
+	r := gin.New()
+	setClaims := func(c *gin.Context) {
+		c.Set(auth.ClaimsKey, auth.Claims{UserID: 1, Username: "user", Roles: []string{"admin"}})
+	}
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	g := r.Group("/", setClaims, Authorize(Permissions{}, "admin", current))
+	g.GET("/warehouses/:id", ok)
+	g.DELETE("/warehouses/:id", ok)
+
+	req, rr := tests.CreateRequestTest(http.MethodDelete, "/warehouses/1", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code, "the admin role was revoked")
+
+	req, rr = tests.CreateRequestTest(http.MethodGet, "/warehouses/1", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code, "reads trust the token")
+
+	delete(current, 1)
+	req, rr = tests.CreateRequestTest(http.MethodDelete, "/warehouses/1", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestAuthorizeLetsUnknownRoutesNotFound(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(auth.ClaimsKey, auth.Claims{UserID: 1, Username: "user", Roles: []string{"sales"}})
+	}, Authorize(Permissions{}, "admin", nil))
+	r.POST("/buyers/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req, rr := tests.CreateRequestTest(http.MethodDelete, "/nothing/here", nil)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodySize bounds request bodies to limit bytes, or to bulkLimit on the
+// /bulk routes of the imports. Bodies declaring a longer
+// Content-Length are answered with a 413 before reaching the handler; the
+// others fail when read past the limit, which handlers binding the body
+// through pkg/web answer with a 413 too. A limit of zero means no bound.
+func MaxBodySize(limit, bulkLimit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		max := limit
+		if strings.HasSuffix(c.FullPath(), "/bulk") {
+			max = bulkLimit
+		}
+		if max <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > max {
+			web.RequestTooLarge(c, max)
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max)
+		c.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createBodyLimitServer() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(MaxBodySize(16, 64))
+	r.POST("/", func(c *gin.Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	r.POST("/bulk", func(c *gin.Context) {
+		if _, err := web.BulkRows(c, func() interface{} { return &struct{}{} }); err != nil {
+			if limit, ok := web.BodyTooLarge(err); ok {
+				web.RequestTooLarge(c, limit)
+				return
+			}
+		}
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestMaxBodySize(t *testing.T) {
+	r := createBodyLimitServer()
+	post := func(url, contentType, body string, chunked bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		if chunked {
+			req.ContentLength = -1
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	large := `{"name":"` + strings.Repeat("x", 32) + `"}`
+
+	assert.Equal(t, http.StatusNoContent, post("/", "application/json", `{"a":1}`, false).Code)
+
+	rr := post("/", "application/json", large, false)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	assert.JSONEq(t, `{"code":"request_entity_too_large","message":"request body must be at most 16 bytes"}`, rr.Body.String())
+
+	// Without a Content-Length the limit applies while binding.
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("/", "application/json", large, true).Code)
+
+	// The bulk limit goes with the route, not with the declared Content-Type.
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("/", web.NDJSONContentType, large, false).Code)
+
+	ndjson := strings.Repeat("{}\n", 10)
+	assert.Equal(t, http.StatusNoContent, post("/bulk", web.NDJSONContentType, ndjson, false).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("/bulk", web.NDJSONContentType, ndjson+ndjson+ndjson, true).Code)
+}
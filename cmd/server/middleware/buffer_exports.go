@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// BufferExports makes web.Export read every row of an export before sending
+// any, so the export releases its database connection before the download
+// starts. The router uses it on SQLite, whose only connection a streamed
+// export would otherwise hold for as long as the client takes to read it.
+func BufferExports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(web.BufferExportsKey, true)
+		c.Next()
+	}
+}
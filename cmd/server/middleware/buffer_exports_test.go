@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type exportRow struct {
+	ID int `json:"id"`
+}
+
+func TestBufferExportsReadsEveryRowFirst(t *testing.T) {
+	for _, buffered := range []bool{false, true} {
+		gin.SetMode(gin.ReleaseMode)
+		r := gin.New()
+		if buffered {
+			r.Use(BufferExports())
+		}
+		var writtenWhileReading bool
+		r.GET("/export", func(c *gin.Context) {
+			web.Export(c, web.NDJSONContentType, exportRow{}, func(write func(interface{}) error) error {
+				for id := 1; id <= 3; id++ {
+					if err := write(exportRow{ID: id}); err != nil {
+						return err
+					}
+					writtenWhileReading = writtenWhileReading || c.Writer.Written()
+				}
+				return nil
+			})
+		})
+
+		req, rr := tests.CreateRequestTest(http.MethodGet, "/export", nil)
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n", rr.Body.String())
+		assert.Equal(t, !buffered, writtenWhileReading)
+	}
+}
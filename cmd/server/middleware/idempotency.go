@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyReplayMimeType = "application/json; charset=utf-8"
+)
+
+// responseRecorder keeps a copy of everything the handler writes so it can be
+// stored next to the idempotency key.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to
+// retry: the first response is stored and replayed for later requests with
+// the same key and body, while reusing the key for a different request is
+// rejected with a 422. Each authenticated user has keys of their own, so it
+// should run after Authenticate.
+func Idempotency(s idempotency.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			web.Error(c, http.StatusBadRequest, "%s header must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if limit, ok := web.BodyTooLarge(err); ok {
+			web.RequestTooLarge(c, limit)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "cannot read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		rec := domain.IdempotencyRecord{
+			User:        user(c),
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: fingerprint(c.Request.Method, c.Request.URL.Path, body),
+		}
+
+		stored, replay, err := s.Begin(c, rec)
+		switch {
+		case errors.Is(err, idempotency.ErrKeyReused):
+			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			c.Abort()
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			web.Error(c, http.StatusConflict, err.Error())
+			c.Abort()
+			return
+		case err != nil:
+			web.Error(c, http.StatusInternalServerError, "internal server error")
+			c.Abort()
+			return
+		}
+
+		if replay {
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(stored.StatusCode, idempotencyReplayMimeType, stored.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		// A panicking handler is answered with a 500 by Recovery, so its key
+		// is freed as for any other server error.
+		defer func() {
+			if p := recover(); p != nil {
+				_ = s.Release(c, rec.User, key)
+				panic(p)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Server errors are not stored so the client can retry with the same key.
+		if recorder.Status() >= http.StatusInternalServerError {
+			_ = s.Release(c, rec.User, key)
+			return
+		}
+
+		rec.StatusCode = recorder.Status()
+		rec.ResponseBody = recorder.body.Bytes()
+		if err := s.Complete(c, rec); err != nil {
+			_ = s.Release(c, rec.User, key)
+		}
+	}
+}
+
+// user is the name of the authenticated user, or empty when there is none.
+func user(c *gin.Context) string {
+	claims, _ := auth.FromContext(c)
+	return claims.Username
+}
+
+// fingerprint identifies a request by its method, path and body.
+func fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type idempotencyBody struct {
+	Name string `json:"name"`
+}
+
+// testUserHeader names the user the requests to the test servers are
+// authenticated as.
+const testUserHeader = "X-Test-User"
+
+func createIdempotencyServer(status int) (*gin.Engine, *int) {
+	calls := 0
+	r := createIdempotencyServerWith(newIdempotencyRepository(), func(c *gin.Context) {
+		calls++
+		web.Success(c, status, calls)
+	})
+	return r, &calls
+}
+
+func newIdempotencyRepository() *mocks.MockIdempotencyRepository {
+	return &mocks.MockIdempotencyRepository{
+		MockData:    map[mocks.MockIdempotencyKey]domain.IdempotencyRecord{},
+		ErrNotFound: idempotency.ErrNotFound,
+		ErrExists:   idempotency.ErrKeyExists,
+	}
+}
+
+func createIdempotencyServerWith(repo idempotency.Repository, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		web.Error(c, http.StatusInternalServerError, "internal server error")
+	}))
+	r.Use(func(c *gin.Context) {
+		if user := c.GetHeader(testUserHeader); user != "" {
+			c.Set(auth.ClaimsKey, auth.Claims{Username: user})
+		}
+	})
+	r.Use(Idempotency(idempotency.NewService(repo, time.Hour)))
+
+	r.POST("/items", handler)
+	r.GET("/items", handler)
+
+	return r
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	r, calls := createIdempotencyServer(http.StatusCreated)
+
+	req, rr := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(rr, req)
+
+	retry, retryRR := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+	retry.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(retryRR, retry)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, http.StatusCreated, retryRR.Code)
+	assert.Equal(t, rr.Body.String(), retryRR.Body.String())
+	assert.Equal(t, "true", retryRR.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyRejectsKeyReuseWithDifferentBody(t *testing.T) {
+	r, calls := createIdempotencyServer(http.StatusCreated)
+
+	req, rr := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(rr, req)
+
+	retry, retryRR := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "b"})
+	retry.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(retryRR, retry)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, retryRR.Code)
+}
+
+func TestIdempotencyReleasesKeyOnServerError(t *testing.T) {
+	r, calls := createIdempotencyServer(http.StatusInternalServerError)
+
+	for i := 0; i < 2; i++ {
+		req, rr := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	}
+
+	assert.Equal(t, 2, *calls)
+}
+
+func TestIdempotencyIgnoresRequestsWithoutKey(t *testing.T) {
+	r, calls := createIdempotencyServer(http.StatusOK)
+
+	for i := 0; i < 2; i++ {
+		req, rr := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+		r.ServeHTTP(rr, req)
+		get, getRR := tests.CreateRequestTest(http.MethodGet, "/items", nil)
+		get.Header.Set(IdempotencyKeyHeader, "key-1")
+		r.ServeHTTP(getRR, get)
+	}
+
+	assert.Equal(t, 4, *calls)
+}
+
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	calls := 0
+	r := createIdempotencyServerWith(newIdempotencyRepository(), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("handler failed")
+		}
+		web.Success(c, http.StatusCreated, calls)
+	})
+
+	req, rr := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	retry, retryRR := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+	retry.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(retryRR, retry)
+
+	assert.Equal(t, http.StatusCreated, retryRR.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyScopesKeysToTheUser(t *testing.T) {
+	r, calls := createIdempotencyServer(http.StatusCreated)
+	post := func(user string, body idempotencyBody) *httptest.ResponseRecorder {
+		req, rr := tests.CreateRequestTest(http.MethodPost, "/items", body)
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		req.Header.Set(testUserHeader, user)
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	alice := post("alice", idempotencyBody{Name: "a"})
+	bob := post("bob", idempotencyBody{Name: "b"})
+	bobRetry := post("bob", idempotencyBody{Name: "b"})
+
+	assert.Equal(t, http.StatusCreated, alice.Code)
+	assert.Equal(t, http.StatusCreated, bob.Code)
+	assert.Empty(t, bob.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 2, *calls)
+	assert.Equal(t, "true", bobRetry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, bob.Body.String(), bobRetry.Body.String())
+}
+
+func TestIdempotencyForgetsExpiredKeys(t *testing.T) {
+	repo := newIdempotencyRepository()
+	repo.MockData[mocks.MockIdempotencyKey{Key: "key-1"}] = domain.IdempotencyRecord{
+		Key:        "key-1",
+		StatusCode: http.StatusCreated,
+		CreatedAt:  time.Now().Add(-2 * time.Hour).Format("2006-01-02 15:04:05"),
+	}
+	calls := 0
+	r := createIdempotencyServerWith(repo, func(c *gin.Context) {
+		calls++
+		web.Success(c, http.StatusCreated, calls)
+	})
+
+	req, rr := tests.CreateRequestTest(http.MethodPost, "/items", idempotencyBody{Name: "a"})
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+}
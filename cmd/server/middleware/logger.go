@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger makes log, with the request id attached, available through
+// web.Logger and logs every request once it was answered: its route,
+// status, latency and the user that made it, if any, along with the id of
+// the trace when the request is traced. Answers with a 5xx status are
+// logged as errors and those with a 4xx as warnings. It must run after
+// RequestID and Tracing.
+func Logger(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		reqLog := log.With("request_id", c.GetString(web.RequestIDKey))
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			reqLog = reqLog.With("trace_id", sc.TraceID().String())
+		}
+		c.Set(web.LoggerKey, reqLog)
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if claims, ok := auth.FromContext(c); ok {
+			attrs = append(attrs, slog.String("user", claims.Username))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		reqLog.LogAttrs(c, level, "request", attrs...)
+	}
+}
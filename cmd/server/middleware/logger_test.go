@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createLoggerServer(out *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(RequestID(), Logger(web.NewLogger(out, "info")), Recovery())
+	r.GET("/products/:id", func(c *gin.Context) {
+		c.Set(auth.ClaimsKey, auth.Claims{Username: "operator"})
+		web.ServerError(c, errors.New("connection refused"), "internal server error")
+	})
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+	return r
+}
+
+// logLines decodes the JSON lines written to out.
+func logLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	dec := json.NewDecoder(out)
+	for dec.More() {
+		var line map[string]interface{}
+		assert.NoError(t, dec.Decode(&line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestLoggerLogsRequestWithRequestID(t *testing.T) {
+	var out bytes.Buffer
+	r := createLoggerServer(&out)
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/products/7", nil)
+	req.Header.Set(web.RequestIDHeader, "abc-123")
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "abc-123", rr.Header().Get(web.RequestIDHeader))
+	assert.JSONEq(t, `{"code":"internal_server_error","message":"internal server error","request_id":"abc-123"}`, rr.Body.String())
+
+	lines := logLines(t, &out)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "request failed", lines[0]["msg"])
+	assert.Equal(t, "connection refused", lines[0]["error"])
+	assert.Equal(t, "abc-123", lines[0]["request_id"])
+
+	assert.Equal(t, "request", lines[1]["msg"])
+	assert.Equal(t, "ERROR", lines[1]["level"])
+	assert.Equal(t, "abc-123", lines[1]["request_id"])
+	assert.Equal(t, "/products/:id", lines[1]["route"])
+	assert.Equal(t, "/products/7", lines[1]["path"])
+	assert.Equal(t, float64(http.StatusInternalServerError), lines[1]["status"])
+	assert.Equal(t, "operator", lines[1]["user"])
+	assert.Contains(t, lines[1], "latency_ms")
+}
+
+func TestLoggerLevelFollowsStatus(t *testing.T) {
+	var out bytes.Buffer
+	r := createLoggerServer(&out)
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/unknown", nil)
+	r.ServeHTTP(rr, req)
+
+	lines := logLines(t, &out)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "WARN", lines[0]["level"])
+	assert.NotEmpty(t, lines[0]["request_id"])
+	assert.NotContains(t, lines[0], "user")
+}
+
+func TestRecoveryLogsPanic(t *testing.T) {
+	var out bytes.Buffer
+	r := createLoggerServer(&out)
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/panic", nil)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	lines := logLines(t, &out)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "panic", lines[0]["msg"])
+	assert.Equal(t, "boom", lines[0]["error"])
+	assert.Equal(t, float64(http.StatusInternalServerError), lines[1]["status"])
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels the requests that matched no route, so unknown paths
+// don't each add a label value.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request in m, labelled by
+// the route template it matched, such as /api/v1/products/:id, and the
+// status it was answered with.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
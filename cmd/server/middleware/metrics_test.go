@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsLabelsRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	m := metrics.New()
+	r := gin.New()
+	r.Use(Metrics(m))
+	r.GET("/products/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, url := range []string{"/products/1", "/products/2", "/unknown/1"} {
+		req, rr := tests.CreateRequestTest(http.MethodGet, url, nil)
+		r.ServeHTTP(rr, req)
+	}
+
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/metrics", nil)
+	m.Handler().ServeHTTP(rr, req)
+	body := rr.Body.String()
+	assert.Contains(t, body, `melisprint_http_requests_total{method="GET",route="/products/:id",status="204"} 2`)
+	assert.Contains(t, body, `melisprint_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `melisprint_http_request_duration_seconds_count{method="GET",route="/products/:id",status="204"} 2`)
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// RateLimiter gives each client a token bucket holding up to requests
+// tokens, refilled at requests per period. Every request takes a token, so
+// a client can make a burst of requests and then one each period/requests.
+type RateLimiter struct {
+	capacity float64
+	rate     float64 // tokens per second
+	now      func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing requests every period, or
+// nil, meaning no limit, when requests is not positive.
+func NewRateLimiter(requests int, period time.Duration) *RateLimiter {
+	if requests <= 0 || period <= 0 {
+		return nil
+	}
+	return &RateLimiter{
+		capacity: float64(requests),
+		rate:     float64(requests) / period.Seconds(),
+		now:      time.Now,
+		buckets:  map[string]*bucket{},
+	}
+}
+
+// Allow takes a token from the bucket of key. When the bucket is empty it
+// returns false and how long until the next token.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.capacity, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.capacity, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep forgets, at most once per refill time, the buckets that have filled
+// up again, as a new bucket would be the same.
+func (l *RateLimiter) sweep(now time.Time) {
+	refill := time.Duration(l.capacity / l.rate * float64(time.Second))
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimit answers with a 429 and a Retry-After header the requests of
+// clients that ran out of tokens in l. Clients are told apart by the
+// X-API-Key header when it holds one of apiKeys, then by the authenticated
+// user, so it should run after Authenticate where there is one, and last by
+// IP address. A nil l lets every request through.
+func RateLimit(l *RateLimiter, apiKeys []string) gin.HandlerFunc {
+	known := keySet(apiKeys)
+	return limit(func(c *gin.Context) (*RateLimiter, string) {
+		return l, clientKey(c, known)
+	})
+}
+
+// RateLimitIP is RateLimit telling clients apart by IP address only. It
+// needs no token, so it runs before Authenticate to bound the requests an
+// address makes with invalid or missing tokens too.
+func RateLimitIP(l *RateLimiter) gin.HandlerFunc {
+	return limit(func(c *gin.Context) (*RateLimiter, string) {
+		return l, "ip:" + c.ClientIP()
+	})
+}
+
+// RateLimitGroups is RateLimit giving each route group, the first segment
+// of the route after prefix, quotas of its own: those of the limiter groups
+// holds for it, or else those of fallback, counted apart from the other
+// groups. A nil limiter in groups lets the requests of its group through.
+func RateLimitGroups(prefix string, groups map[string]*RateLimiter, fallback *RateLimiter, apiKeys []string) gin.HandlerFunc {
+	known := keySet(apiKeys)
+	return limit(func(c *gin.Context) (*RateLimiter, string) {
+		group := routeGroup(prefix, c.FullPath())
+		l, ok := groups[group]
+		if !ok {
+			l = fallback
+		}
+		return l, group + "|" + clientKey(c, known)
+	})
+}
+
+// limit answers with a 429 the requests whose client ran out of tokens in
+// the limiter pick returns for them, along with the key of their bucket.
+func limit(pick func(c *gin.Context) (*RateLimiter, string)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		l, key := pick(c)
+		if l == nil {
+			c.Next()
+			return
+		}
+		if ok, retryAfter := l.Allow(key); !ok {
+			web.TooManyRequests(c, retryAfter)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// routeGroup returns the first segment of route after prefix, such as
+// "sellers" for /api/v1/sellers/:id with the /api/v1 prefix.
+func routeGroup(prefix, route string) string {
+	group := strings.TrimPrefix(strings.TrimPrefix(route, prefix), "/")
+	group, _, _ = strings.Cut(group, "/")
+	return group
+}
+
+func keySet(apiKeys []string) map[string]bool {
+	known := make(map[string]bool, len(apiKeys))
+	for _, key := range apiKeys {
+		known[key] = true
+	}
+	return known
+}
+
+func clientKey(c *gin.Context, apiKeys map[string]bool) string {
+	if key := c.GetHeader(APIKeyHeader); apiKeys[key] {
+		return "key:" + key
+	}
+	if claims, ok := auth.FromContext(c); ok {
+		return "user:" + claims.Username
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterRefillsTokens(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(2, time.Second)
+	l.now = func() time.Time { return now }
+
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok)
+	ok, retryAfter := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	ok, _ = l.Allow("b")
+	assert.True(t, ok, "each key has its own bucket")
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+}
+
+func TestNewRateLimiterWithoutRequestsIsNoLimit(t *testing.T) {
+	assert.Nil(t, NewRateLimiter(0, time.Minute))
+
+	r := gin.New()
+	r.GET("/", RateLimit(nil, nil), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	req, rr := tests.CreateRequestTest(http.MethodGet, "/", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set(auth.ClaimsKey, auth.Claims{Username: user})
+		}
+	}, RateLimit(NewRateLimiter(1, time.Minute), []string{"integration"}), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	call := func(headers map[string]string) *http.Response {
+		req, rr := tests.CreateRequestTest(http.MethodGet, "/", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		r.ServeHTTP(rr, req)
+		return rr.Result()
+	}
+
+	assert.Equal(t, http.StatusNoContent, call(nil).StatusCode)
+	res := call(nil)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "60", res.Header.Get(web.RetryAfterHeader))
+
+	// Unknown API keys share the quota of the IP address.
+	assert.Equal(t, http.StatusTooManyRequests, call(map[string]string{APIKeyHeader: "other"}).StatusCode)
+	assert.Equal(t, http.StatusNoContent, call(map[string]string{APIKeyHeader: "integration"}).StatusCode)
+	assert.Equal(t, http.StatusNoContent, call(map[string]string{"X-Test-User": "admin"}).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, call(map[string]string{"X-Test-User": "admin"}).StatusCode)
+}
+
+func TestRateLimitIPIgnoresUsersAndKeys(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/", RateLimitIP(NewRateLimiter(1, time.Minute)), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	call := func(name, value string) int {
+		req, rr := tests.CreateRequestTest(http.MethodGet, "/", nil)
+		req.Header.Set(name, value)
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, call(APIKeyHeader, "integration"))
+	assert.Equal(t, http.StatusTooManyRequests, call(APIKeyHeader, "other"))
+	assert.Equal(t, http.StatusTooManyRequests, call("Authorization", "Bearer invalid"))
+}
+
+func TestRateLimitGroups(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	api := r.Group("/api/v1", RateLimitGroups("/api/v1", map[string]*RateLimiter{
+		"sellers": NewRateLimiter(2, time.Minute),
+		"buyers":  nil,
+	}, NewRateLimiter(1, time.Minute), nil))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	api.GET("/sellers/:id", ok)
+	api.GET("/buyers/", ok)
+	api.GET("/products/", ok)
+	api.GET("/warehouses/", ok)
+	call := func(url string) int {
+		req, rr := tests.CreateRequestTest(http.MethodGet, url, nil)
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, call("/api/v1/sellers/1"))
+	assert.Equal(t, http.StatusNoContent, call("/api/v1/sellers/2"))
+	assert.Equal(t, http.StatusTooManyRequests, call("/api/v1/sellers/3"))
+
+	// Groups without a limiter of their own get a bucket of fallback each.
+	assert.Equal(t, http.StatusNoContent, call("/api/v1/products/"))
+	assert.Equal(t, http.StatusTooManyRequests, call("/api/v1/products/"))
+	assert.Equal(t, http.StatusNoContent, call("/api/v1/warehouses/"))
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusNoContent, call("/api/v1/buyers/"), "a nil limiter is no limit")
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery answers with a 500 when a handler panics, logging the panic and
+// its stack through web.Logger instead of gin's plain text output.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err interface{}) {
+		web.Logger(c).ErrorContext(c, "panic", "error", fmt.Sprint(err), "stack", string(debug.Stack()))
+		web.Error(c, http.StatusInternalServerError, "internal server error")
+		c.Abort()
+	})
+}
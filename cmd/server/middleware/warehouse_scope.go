@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// warehouseResolver returns the warehouses a request writes to. An empty
+// result means the request names no target and is left to the handler to
+// validate; a target that names a section which can't be found is an error.
+type warehouseResolver func(c *gin.Context, body []byte) ([]int, error)
+
+// WarehouseScope restricts the writes of users linked to an employee to the
+// warehouse the employee works in. It must run after Authenticate. Users
+// with the superuser role are not scoped, while users that are not
+// employees may not write at all.
+type WarehouseScope struct {
+	employees employee.Service
+	sections  section.Service
+	superuser string
+}
+
+func NewWarehouseScope(e employee.Service, s section.Service, superuser string) *WarehouseScope {
+	return &WarehouseScope{
+		employees: e,
+		sections:  s,
+		superuser: superuser,
+	}
+}
+
+// InboundOrder scopes requests whose body carries a warehouse_id.
+func (ws *WarehouseScope) InboundOrder() gin.HandlerFunc {
+	return ws.scope(func(c *gin.Context, body []byte) ([]int, error) {
+		var req struct {
+			WarehouseID int `json:"warehouse_id"`
+		}
+		if json.Unmarshal(body, &req) != nil || req.WarehouseID == 0 {
+			return nil, nil
+		}
+		return []int{req.WarehouseID}, nil
+	})
+}
+
+// ProductBatch scopes requests whose body places a batch in a section.
+func (ws *WarehouseScope) ProductBatch() gin.HandlerFunc {
+	return ws.scope(func(c *gin.Context, body []byte) ([]int, error) {
+		// Batch requests are decoded without json tags, so the key matches
+		// the handler's field name.
+		var req struct {
+			SectionId int
+		}
+		if json.Unmarshal(body, &req) != nil || req.SectionId == 0 {
+			return nil, nil
+		}
+		sec, err := ws.sections.Get(c, req.SectionId)
+		if err != nil {
+			return nil, err
+		}
+		return []int{sec.WarehouseID}, nil
+	})
+}
+
+// Section scopes updates of the section in the :id path parameter. Moving a
+// section to another warehouse requires both warehouses to be in scope.
+func (ws *WarehouseScope) Section() gin.HandlerFunc {
+	return ws.scope(func(c *gin.Context, body []byte) ([]int, error) {
+		var warehouses []int
+		if id, err := strconv.Atoi(c.Param("id")); err == nil {
+			sec, err := ws.sections.Get(c, id)
+			if err != nil {
+				return nil, err
+			}
+			warehouses = append(warehouses, sec.WarehouseID)
+		}
+		var req struct {
+			WarehouseID int `json:"warehouse_id"`
+		}
+		if json.Unmarshal(body, &req) == nil && req.WarehouseID != 0 {
+			warehouses = append(warehouses, req.WarehouseID)
+		}
+		return warehouses, nil
+	})
+}
+
+func (ws *WarehouseScope) scope(resolve warehouseResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := auth.FromContext(c)
+		if !ok {
+			web.Error(c, http.StatusUnauthorized, "missing bearer token")
+			c.Abort()
+			return
+		}
+		if claims.HasRole(ws.superuser) {
+			c.Next()
+			return
+		}
+
+		emp, err := ws.employees.GetByUserID(c, claims.UserID)
+		if errors.Is(err, employee.ErrNotFound) {
+			web.Error(c, http.StatusForbidden, "user %s is not linked to an employee", claims.Username)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			c.Abort()
+			return
+		}
+
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "could not read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		warehouses, err := resolve(c, body)
+		if errors.Is(err, section.ErrNotFound) {
+			web.Error(c, http.StatusNotFound, "section not found")
+			c.Abort()
+			return
+		}
+		if err != nil {
+			web.ServerError(c, err, "internal server error")
+			c.Abort()
+			return
+		}
+		for _, warehouseID := range warehouses {
+			if warehouseID != emp.WarehouseID {
+				web.Error(c, http.StatusForbidden, "employee %d may only write to warehouse %d", emp.ID, emp.WarehouseID)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createWarehouseScopeServer(userID int, roles ...string) *gin.Engine {
+	return createWarehouseScopeServerWith(nil, nil, userID, roles...)
+}
+
+// createWarehouseScopeServerWith fails the lookups of employees by user
+// with lookupErr and those of sections with sectionErr when they are not nil.
+func createWarehouseScopeServerWith(lookupErr, sectionErr error, userID int, roles ...string) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	linkedUser := 7
+	employees := &mocks.MockEmployeeService{MockRepository: mocks.MockEmployeeRepository{
+		MockData:      []domain.Employee{{ID: 1, CardNumberID: "1", WarehouseID: 1, UserID: &linkedUser}},
+		ErrNotFound:   employee.ErrNotFound,
+		ErrUserLookup: lookupErr,
+	}}
+	sections := section.NewService(&mocks.MockSectionRepository{
+		MockData:    []domain.Section{{ID: 1, WarehouseID: 1}, {ID: 2, WarehouseID: 2}},
+		ErrNotFound: sql.ErrNoRows,
+		ErrGet:      sectionErr,
+	})
+	scope := NewWarehouseScope(employees, sections, "admin")
+
+	setClaims := func(c *gin.Context) {
+		c.Set(auth.ClaimsKey, auth.Claims{UserID: userID, Username: "user", Roles: roles})
+	}
+	// echo proves the body is still readable once the scope has inspected it.
+	echo := func(c *gin.Context) {
+		body, _ := ioutil.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, "application/json", body)
+	}
+
+	g := r.Group("/", setClaims)
+	g.POST("/inboundOrders/", scope.InboundOrder(), echo)
+	g.POST("/productBatches/", scope.ProductBatch(), echo)
+	g.PATCH("/sections/:id", scope.Section(), echo)
+
+	return r
+}
+
+func TestWarehouseScope(t *testing.T) {
+	cases := []struct {
+		name   string
+		userID int
+		roles  []string
+		method string
+		url    string
+		body   map[string]interface{}
+		status int
+	}{
+		{"inbound order own warehouse", 7, nil, http.MethodPost, "/inboundOrders/", map[string]interface{}{"warehouse_id": 1}, http.StatusOK},
+		{"inbound order other warehouse", 7, nil, http.MethodPost, "/inboundOrders/", map[string]interface{}{"warehouse_id": 2}, http.StatusForbidden},
+		{"inbound order admin", 7, []string{"admin"}, http.MethodPost, "/inboundOrders/", map[string]interface{}{"warehouse_id": 2}, http.StatusOK},
+		{"inbound order not an employee", 8, nil, http.MethodPost, "/inboundOrders/", map[string]interface{}{"warehouse_id": 2}, http.StatusForbidden},
+		{"batch own section", 7, nil, http.MethodPost, "/productBatches/", map[string]interface{}{"SectionId": 1}, http.StatusOK},
+		{"batch other section", 7, nil, http.MethodPost, "/productBatches/", map[string]interface{}{"SectionId": 2}, http.StatusForbidden},
+		{"batch unknown section", 7, nil, http.MethodPost, "/productBatches/", map[string]interface{}{"SectionId": 9}, http.StatusNotFound},
+		{"section own warehouse", 7, nil, http.MethodPatch, "/sections/1", map[string]interface{}{"current_capacity": 5}, http.StatusOK},
+		{"section other warehouse", 7, nil, http.MethodPatch, "/sections/2", map[string]interface{}{"current_capacity": 5}, http.StatusForbidden},
+		{"section moved out of warehouse", 7, nil, http.MethodPatch, "/sections/1", map[string]interface{}{"warehouse_id": 2}, http.StatusForbidden},
+		{"section unknown", 7, nil, http.MethodPatch, "/sections/9", map[string]interface{}{"current_capacity": 5}, http.StatusNotFound},
+		{"section not an employee", 8, nil, http.MethodPatch, "/sections/2", map[string]interface{}{"current_capacity": 5}, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createWarehouseScopeServer(tc.userID, tc.roles...)
+			req, rr := tests.CreateRequestTest(tc.method, tc.url, tc.body)
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, tc.status, rr.Code)
+			if tc.status == http.StatusOK {
+				assert.NotEmpty(t, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestWarehouseScopeFailsClosedOnLookupErrors(t *testing.T) {
+	r := createWarehouseScopeServerWith(errors.New("database down"), nil, 7)
+
+	req, rr := tests.CreateRequestTest(http.MethodPatch, "/sections/2", map[string]interface{}{"current_capacity": 5})
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestWarehouseScopeFailsClosedOnSectionLookupErrors(t *testing.T) {
+	r := createWarehouseScopeServerWith(nil, errors.New("database down"), 7)
+
+	for _, tc := range []struct {
+		method, url string
+		body        map[string]interface{}
+	}{
+		{http.MethodPost, "/productBatches/", map[string]interface{}{"SectionId": 2}},
+		{http.MethodPatch, "/sections/2", map[string]interface{}{"current_capacity": 5}},
+	} {
+		req, rr := tests.CreateRequestTest(tc.method, tc.url, tc.body)
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code, tc.url)
+		assert.NotContains(t, rr.Body.String(), "database down")
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/role"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/user"
+)
+
+// createAdmin registers cfg.AdminUsername with cfg.AdminPassword and the
+// admin role, so a new database gets an admin without shipping a known
+// password. Nothing is done without a password or when the user exists,
+// whatever its password and roles are.
+func (r *router) createAdmin(ctx context.Context, users user.Service, roles role.Service) error {
+	if r.cfg.AdminPassword == "" {
+		return nil
+	}
+	admin, err := users.Register(ctx, r.cfg.AdminUsername, r.cfg.AdminPassword)
+	if errors.Is(err, user.ErrUsernameTaken) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	all, err := roles.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rl := range all {
+		if rl.Name == role.Admin {
+			return roles.Assign(ctx, admin.ID, rl.ID)
+		}
+	}
+	return role.ErrNotFound
+}
@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/locality"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/cache"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+)
+
+// reportsOf returns the prefixes of the cached reports computed from the
+// entity the event reports a change of.
+func reportsOf(event domain.Event) []string {
+	switch event.(type) {
+	case domain.SellerCreated, domain.SellerUpdated, domain.SellerDeleted, domain.SellerRestored:
+		return []string{locality.SellerReportsCacheKey}
+	case domain.LocalityCreated:
+		return []string{locality.SellerReportsCacheKey, locality.CarryReportsCacheKey}
+	case domain.CarryCreated:
+		return []string{locality.CarryReportsCacheKey}
+	case domain.SectionCreated, domain.SectionUpdated, domain.SectionDeleted, domain.SectionRestored,
+		domain.ProductBatchReceived:
+		return []string{section.ProductReportsCacheKey}
+	case domain.EmployeeCreated, domain.EmployeeUpdated, domain.EmployeeDeleted, domain.EmployeeRestored,
+		domain.InboundOrderReceived:
+		return []string{employee.InboundOrdersCacheKey}
+	case domain.BuyerCreated, domain.BuyerUpdated, domain.BuyerDeleted, domain.BuyerRestored,
+		domain.PurchaseOrderCreated, domain.PurchaseOrderStatusChanged:
+		return []string{buyer.PurchaseOrdersCacheKey}
+	}
+	return nil
+}
+
+type staleReportsKey struct{}
+
+// invalidatingPublisher drops from the cache the reports computed from the
+// entities of the events published. Events published in a transaction drop
+// them once it commits, so a read in between can't cache them again stale.
+type invalidatingPublisher struct {
+	outbox.Service
+	cache cache.Cache
+}
+
+func (p invalidatingPublisher) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(staleReportsKey{}).(map[string]bool); ok {
+		return p.Service.Transact(ctx, fn)
+	}
+	stale := map[string]bool{}
+	err := p.Service.Transact(context.WithValue(ctx, staleReportsKey{}, stale), fn)
+	if err != nil {
+		return err
+	}
+	p.drop(ctx, stale)
+	return nil
+}
+
+func (p invalidatingPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	if err := p.Service.Publish(ctx, events...); err != nil {
+		return err
+	}
+	stale, inTransaction := ctx.Value(staleReportsKey{}).(map[string]bool)
+	if !inTransaction {
+		stale = map[string]bool{}
+	}
+	for _, event := range events {
+		for _, prefix := range reportsOf(event) {
+			stale[prefix] = true
+		}
+	}
+	if !inTransaction {
+		p.drop(ctx, stale)
+	}
+	return nil
+}
+
+func (p invalidatingPublisher) drop(ctx context.Context, prefixes map[string]bool) {
+	for prefix := range prefixes {
+		if err := p.cache.DeletePrefix(ctx, prefix); err != nil {
+			web.Logger(ctx).ErrorContext(ctx, "dropping cached reports failed", "prefix", prefix, "error", err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// webhookTimeout bounds each request of the events webhook.
+const webhookTimeout = 10 * time.Second
+
+// Events returns the in-process subscribers to the events of the outbox.
+func (r *router) Events() *outbox.Subscribers {
+	return r.subscribers
+}
+
+// startDispatcher delivers the events of the outbox in the background, every
+// cfg.EventsPollInterval, to the subscribers and to the webhook and log file
+// the configuration sets, until ctx is done.
+func (r *router) startDispatcher(ctx context.Context) {
+	if r.cfg.EventsPollInterval <= 0 {
+		return
+	}
+	sinks := []outbox.Sink{r.subscribers}
+	if r.cfg.EventsWebhookURL != "" {
+		sinks = append(sinks, outbox.NewWebhook(r.cfg.EventsWebhookURL, webhookTimeout))
+	}
+	if r.cfg.EventsLogPath != "" {
+		sinks = append(sinks, outbox.NewLogFile(r.cfg.EventsLogPath))
+	}
+	dispatcher := outbox.NewDispatcher(r.repos.outbox, r.log, r.cfg.EventsMaxAttempts, sinks...)
+	r.background.Add(1)
+	go func() {
+		defer r.background.Done()
+		dispatcher.Run(ctx, r.cfg.EventsPollInterval)
+	}()
+}
+
+func (r *router) Wait() {
+	r.background.Wait()
+}
@@ -0,0 +1,157 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/config"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	auditmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer"
+	buyermemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/carry"
+	carrymemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/carry/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
+	employeememory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency"
+	idempotencymemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency/memory"
+	inboundorder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/inbound_order"
+	inboundordermemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/inbound_order/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/locality"
+	localitymemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/locality/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+	outboxmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product"
+	productmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product/memory"
+	product_batch "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product_batches"
+	productbatchmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product_batches/memory"
+	purchaseOrder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/purchase_orders"
+	purchaseordermemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/purchase_orders/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/role"
+	rolememory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/role/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
+	sectionmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/seller"
+	sellermemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/seller/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/user"
+	usermemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/user/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/warehouse"
+	warehousememory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/warehouse/memory"
+)
+
+// repositories holds the repository of every package, all kept in the same
+// storage.
+type repositories struct {
+	audit         audit.Repository
+	buyer         buyer.Repository
+	carry         carry.Repository
+	employee      employee.Repository
+	idempotency   idempotency.Repository
+	inboundOrder  inboundorder.Repository
+	locality      locality.Repository
+	outbox        outbox.Repository
+	product       product.Repository
+	productBatch  product_batch.Repository
+	purchaseOrder purchaseOrder.Repository
+	role          role.Repository
+	section       section.Repository
+	seller        seller.Repository
+	user          user.Repository
+	warehouse     warehouse.Repository
+}
+
+// newRepositories builds the repositories for storage: the SQL ones over db,
+// or with config.StorageMemory in-memory ones over tables loaded with the
+// seed data of db.sql.
+func newRepositories(db *sql.DB, storage string) repositories {
+	if storage == config.StorageMemory {
+		mem := memdb.New()
+		memdb.Seed(mem)
+		return memoryRepositories(mem)
+	}
+	return repositories{
+		audit:         audit.NewRepository(db),
+		buyer:         buyer.NewRepository(db),
+		carry:         carry.NewRepository(db),
+		employee:      employee.NewRepository(db),
+		idempotency:   idempotency.NewRepository(db),
+		inboundOrder:  inboundorder.NewRepository(db),
+		locality:      locality.NewRepository(db),
+		outbox:        outbox.NewRepository(db),
+		product:       product.NewRepository(db),
+		productBatch:  product_batch.NewRepository(db),
+		purchaseOrder: purchaseOrder.NewRepository(db),
+		role:          role.NewRepository(db),
+		section:       section.NewRepository(db),
+		seller:        seller.NewRepository(db),
+		user:          user.NewRepository(db),
+		warehouse:     warehouse.NewRepository(db),
+	}
+}
+
+func memoryRepositories(mem *memdb.DB) repositories {
+	return repositories{
+		audit:         auditmemory.NewRepository(mem),
+		buyer:         buyermemory.NewRepository(mem),
+		carry:         carrymemory.NewRepository(mem),
+		employee:      employeememory.NewRepository(mem),
+		idempotency:   idempotencymemory.NewRepository(mem),
+		inboundOrder:  inboundordermemory.NewRepository(mem),
+		locality:      localitymemory.NewRepository(mem),
+		outbox:        outboxmemory.NewRepository(mem),
+		product:       productmemory.NewRepository(mem),
+		productBatch:  productbatchmemory.NewRepository(mem),
+		purchaseOrder: purchaseordermemory.NewRepository(mem),
+		role:          rolememory.NewRepository(mem),
+		section:       sectionmemory.NewRepository(mem),
+		seller:        sellermemory.NewRepository(mem),
+		user:          usermemory.NewRepository(mem),
+		warehouse:     warehousememory.NewRepository(mem),
+	}
+}
+
+// measured wraps every repository so the duration of its methods is
+// recorded in m.
+func (rs repositories) measured(m *metrics.Metrics) repositories {
+	return repositories{
+		audit:         audit.NewMeasuredRepository(rs.audit, m),
+		buyer:         buyer.NewMeasuredRepository(rs.buyer, m),
+		carry:         carry.NewMeasuredRepository(rs.carry, m),
+		employee:      employee.NewMeasuredRepository(rs.employee, m),
+		idempotency:   idempotency.NewMeasuredRepository(rs.idempotency, m),
+		inboundOrder:  inboundorder.NewMeasuredRepository(rs.inboundOrder, m),
+		locality:      locality.NewMeasuredRepository(rs.locality, m),
+		outbox:        outbox.NewMeasuredRepository(rs.outbox, m),
+		product:       product.NewMeasuredRepository(rs.product, m),
+		productBatch:  product_batch.NewMeasuredRepository(rs.productBatch, m),
+		purchaseOrder: purchaseOrder.NewMeasuredRepository(rs.purchaseOrder, m),
+		role:          role.NewMeasuredRepository(rs.role, m),
+		section:       section.NewMeasuredRepository(rs.section, m),
+		seller:        seller.NewMeasuredRepository(rs.seller, m),
+		user:          user.NewMeasuredRepository(rs.user, m),
+		warehouse:     warehouse.NewMeasuredRepository(rs.warehouse, m),
+	}
+}
+
+// traced wraps every repository so each of its methods runs in a span.
+func (rs repositories) traced() repositories {
+	return repositories{
+		audit:         audit.NewTracedRepository(rs.audit),
+		buyer:         buyer.NewTracedRepository(rs.buyer),
+		carry:         carry.NewTracedRepository(rs.carry),
+		employee:      employee.NewTracedRepository(rs.employee),
+		idempotency:   idempotency.NewTracedRepository(rs.idempotency),
+		inboundOrder:  inboundorder.NewTracedRepository(rs.inboundOrder),
+		locality:      locality.NewTracedRepository(rs.locality),
+		outbox:        outbox.NewTracedRepository(rs.outbox),
+		product:       product.NewTracedRepository(rs.product),
+		productBatch:  product_batch.NewTracedRepository(rs.productBatch),
+		purchaseOrder: purchaseOrder.NewTracedRepository(rs.purchaseOrder),
+		role:          role.NewTracedRepository(rs.role),
+		section:       section.NewTracedRepository(rs.section),
+		seller:        seller.NewTracedRepository(rs.seller),
+		user:          user.NewTracedRepository(rs.user),
+		warehouse:     warehouse.NewTracedRepository(rs.warehouse),
+	}
+}
@@ -1,43 +1,169 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
+	"log/slog"
+	"os"
+	"sync"
 
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/config"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/handler"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/middleware"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/docs"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/carry"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency"
 	inboundorder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/inbound_order"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/locality"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product"
 	product_batch "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product_batches"
 	purchaseOrder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/role"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/seller"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/user"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/warehouse"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/cache"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// permissions lists the roles allowed to call each mutating route. Admins
+// may call every route and read-only routes are open to any authenticated
+// user; a mutating route missing here is reserved to admins.
+var permissions = middleware.Permissions{
+	"POST /api/v1/inboundOrders/":  {role.WarehouseOperator},
+	"POST /api/v1/productBatches/": {role.WarehouseOperator},
+
+	"POST /api/v1/buyers/":                    {role.Sales},
+	"POST /api/v1/buyers/bulk":                {role.Sales},
+	"PATCH /api/v1/buyers/:id":                {role.Sales},
+	"DELETE /api/v1/buyers/:id":               {role.Sales},
+	"POST /api/v1/buyers/:id/restore":         {role.Sales},
+	"POST /api/v1/purchaseOrders/":            {role.Sales},
+	"PATCH /api/v1/purchaseOrders/:id/status": {role.Sales},
+
+	"POST /api/v1/warehouses/":               {role.Admin},
+	"PATCH /api/v1/warehouses/:id":           {role.Admin},
+	"DELETE /api/v1/warehouses/:id":          {role.Admin},
+	"POST /api/v1/warehouses/:id/restore":    {role.Admin},
+	"POST /api/v1/sections/":                 {role.Admin},
+	"PATCH /api/v1/sections/:id":             {role.WarehouseOperator},
+	"DELETE /api/v1/sections/:id":            {role.Admin},
+	"POST /api/v1/sections/:id/restore":      {role.Admin},
+	"POST /api/v1/users":                     {role.Admin},
+	"GET /api/v1/roles":                      {role.Admin},
+	"GET /api/v1/users/:id/roles":            {role.Admin},
+	"POST /api/v1/users/:id/roles":           {role.Admin},
+	"DELETE /api/v1/users/:id/roles/:roleId": {role.Admin},
+	"GET /api/v1/audit":                      {role.Admin},
+}
+
 type Router interface {
-	MapRoutes()
+	// MapRoutes registers the routes and delivers the events of the outbox
+	// in the background until ctx is done.
+	MapRoutes(ctx context.Context)
+	// Wait blocks until the background work started by MapRoutes has
+	// stopped, once its ctx is done.
+	Wait()
+	// Events returns the subscribers the events of the changes made through
+	// the routes are delivered to in process.
+	Events() *outbox.Subscribers
 }
 
 type router struct {
-	r  *gin.Engine
-	rg *gin.RouterGroup
-	db *sql.DB
+	r       *gin.Engine
+	rg      *gin.RouterGroup
+	public  *gin.RouterGroup
+	db      *sql.DB
+	repos   repositories
+	cfg     config.Config
+	tokens  *auth.Tokens
+	scope   *middleware.WarehouseScope
+	audit   audit.Service
+	metrics *metrics.Metrics
+	log     *slog.Logger
+	reports cache.Cache
+
+	events      outbox.Service
+	subscribers *outbox.Subscribers
+	background  sync.WaitGroup
 }
 
-func NewRouter(r *gin.Engine, db *sql.DB) Router {
-	return &router{r: r, db: db}
+func NewRouter(r *gin.Engine, db *sql.DB, cfg config.Config) Router {
+	m := metrics.New()
+	if db != nil {
+		m.WatchDB(db)
+	}
+	return &router{
+		r:       r,
+		db:      db,
+		repos:   newRepositories(db, cfg.Storage).measured(m).traced(),
+		cfg:     cfg,
+		tokens:  auth.NewTokens(cfg.JWTSecret, cfg.JWTExpiration),
+		metrics: m,
+		log:     web.NewLogger(os.Stdout, cfg.LogLevel),
+		reports: cache.NewLRU(cfg.ReportCacheSize),
+
+		subscribers: outbox.NewSubscribers(),
+	}
 }
 
-func (r *router) MapRoutes() {
+// NewMemoryRouter returns a Router whose repositories keep their data in
+// mem, whatever cfg.Storage says. It lets tests load their own fixtures.
+func NewMemoryRouter(r *gin.Engine, mem *memdb.DB, cfg config.Config) Router {
+	m := metrics.New()
+	return &router{
+		r:       r,
+		repos:   memoryRepositories(mem).measured(m).traced(),
+		cfg:     cfg,
+		tokens:  auth.NewTokens(cfg.JWTSecret, cfg.JWTExpiration),
+		metrics: m,
+		log:     web.NewLogger(os.Stdout, cfg.LogLevel),
+		reports: cache.NewLRU(cfg.ReportCacheSize),
+
+		subscribers: outbox.NewSubscribers(),
+	}
+}
+
+func (r *router) MapRoutes(ctx context.Context) {
+	if err := web.RegisterValidators(); err != nil {
+		panic(err)
+	}
+	// The rate limits and the logs find clients by their IP address, which
+	// only trusted proxies may set.
+	if err := r.r.SetTrustedProxies(r.cfg.TrustedProxies); err != nil {
+		panic(err)
+	}
+	// Lets the services find the span of the request, kept in the context
+	// of the http.Request, through the *gin.Context they get.
+	r.r.ContextWithFallback = true
+	r.r.Use(
+		middleware.RequestID(),
+		middleware.Tracing(),
+		middleware.Logger(r.log),
+		middleware.Metrics(r.metrics),
+		middleware.Recovery(),
+		middleware.MaxBodySize(r.cfg.MaxBodyBytes, r.cfg.MaxBulkBodyBytes),
+	)
+	if r.cfg.Storage == config.StorageSQLite {
+		r.r.Use(middleware.BufferExports())
+	}
 	r.setGroup()
 
+	r.buildHealthRoutes()
+	r.buildMetricsRoutes()
+	r.buildAuditRoutes()
+	r.buildUserRoutes()
 	r.buildSellerRoutes()
 	r.buildProductRoutes()
 	r.buildSectionRoutes()
@@ -50,74 +176,154 @@ func (r *router) MapRoutes() {
 	r.buildSwaggerRoutes()
 	r.buildLocalitiesRoutes()
 	r.buildCarryRoutes()
+
+	r.startDispatcher(ctx)
 }
 
+// setGroup creates the /api/v1 groups. Routes in the public group are open,
+// everything else requires a valid token. Every request counts against the
+// quota of its IP address first, then against those of its group.
 func (r *router) setGroup() {
-	r.rg = r.r.Group("/api/v1")
+	idempotencyService := idempotency.NewTracedService(idempotency.NewService(r.repos.idempotency, r.cfg.IdempotencyTTL))
+	r.audit = audit.NewTracedService(audit.NewService(r.repos.audit))
+	r.events = invalidatingPublisher{
+		Service: outbox.NewTracedService(outbox.NewService(r.repos.outbox, r.db)),
+		cache:   r.reports,
+	}
+	ip := middleware.RateLimitIP(newRateLimiter(r.cfg.IPRateLimit))
+	groups := make(map[string]*middleware.RateLimiter, len(r.cfg.GroupRateLimits))
+	for group, limit := range r.cfg.GroupRateLimits {
+		groups[group] = newRateLimiter(limit)
+	}
+	r.public = r.r.Group("/api/v1",
+		ip,
+		middleware.RateLimit(newRateLimiter(r.cfg.PublicRateLimit), r.cfg.APIKeys),
+	)
+	r.rg = r.r.Group("/api/v1",
+		ip,
+		middleware.Authenticate(r.tokens),
+		middleware.RateLimitGroups("/api/v1", groups, newRateLimiter(r.cfg.APIRateLimit), r.cfg.APIKeys),
+		middleware.Authorize(permissions, role.Admin, role.NewTracedService(role.NewService(r.repos.role))),
+		middleware.Idempotency(idempotencyService),
+	)
+
+	employeeService := employee.NewTracedService(employee.NewService(r.repos.employee))
+	sectionService := section.NewTracedService(section.NewService(r.repos.section))
+	r.scope = middleware.NewWarehouseScope(employeeService, sectionService, role.Admin)
+}
+
+func newRateLimiter(limit config.RateLimit) *middleware.RateLimiter {
+	return middleware.NewRateLimiter(limit.Requests, limit.Per)
+}
+
+func (r *router) buildHealthRoutes() {
+	handler := handler.NewHealth(r.db)
+	r.r.GET("/health", handler.Get())
+}
+
+func (r *router) buildMetricsRoutes() {
+	r.r.GET("/metrics", gin.WrapH(r.metrics.Handler()))
+}
+
+func (r *router) buildAuditRoutes() {
+	handler := handler.NewAudit(r.audit)
+	r.rg.GET("/audit", handler.GetAll())
+}
+
+func (r *router) buildUserRoutes() {
+	repo := r.repos.user
+	service := user.NewTracedService(user.NewAuditedService(user.NewService(repo), r.audit))
+	roleService := role.NewTracedService(role.NewAuditedService(role.NewService(r.repos.role), r.audit))
+	handler := handler.NewUser(service, roleService, r.tokens)
+	if err := r.createAdmin(context.Background(), user.NewService(repo), role.NewService(r.repos.role)); err != nil {
+		panic(err)
+	}
+
+	r.public.POST("/login", handler.Login())
+	r.rg.POST("/users", handler.Create())
+	r.rg.GET("/roles", handler.GetRoles())
+	userRoutes := r.rg.Group("/users/:id/roles")
+	{
+		userRoutes.GET("", handler.GetUserRoles())
+		userRoutes.POST("", handler.AssignRole())
+		userRoutes.DELETE("/:roleId", handler.RevokeRole())
+	}
 }
 
 func (r *router) buildSellerRoutes() {
 	// Example
-	repo := seller.NewRepository(r.db)
-	service := seller.NewService(repo)
+	repo := r.repos.seller
+	service := seller.NewTracedService(seller.NewAuditedService(seller.NewEventedService(seller.NewService(repo), r.events), r.audit))
 	handler := handler.NewSeller(service)
 	sellerRoutes := r.rg.Group("/sellers")
 	{
 		sellerRoutes.GET("/", handler.GetAll())
 		sellerRoutes.GET("/:id", handler.Get())
+		sellerRoutes.GET("/report", handler.Reports())
+		sellerRoutes.GET("/:id/report", handler.Report())
 		sellerRoutes.POST("/", handler.Create())
+		sellerRoutes.POST("/bulk", handler.Import())
 		sellerRoutes.PATCH("/:id", handler.Update())
 		sellerRoutes.DELETE("/:id", handler.Delete())
+		sellerRoutes.POST("/:id/restore", handler.Restore())
 	}
 }
 
 func (r *router) buildProductRoutes() {
-	repo := product.NewRepository(r.db)
-	service := product.NewService(repo)
+	repo := r.repos.product
+	service := product.NewTracedService(product.NewAuditedService(product.NewEventedService(product.NewService(repo), r.events), r.audit))
 	handler := handler.NewProduct(service)
 	prdRoutes := r.rg.Group("/products")
 	{
 		prdRoutes.GET("/", handler.GetAll())
 		prdRoutes.GET("/:id", handler.Get())
 		prdRoutes.POST("/", handler.Create())
+		prdRoutes.POST("/bulk", handler.Import())
 		prdRoutes.PATCH("/:id", handler.Update())
 		prdRoutes.DELETE("/:id", handler.Delete())
+		prdRoutes.POST("/:id/restore", handler.Restore())
 	}
 }
 
 func (r *router) buildSectionRoutes() {
-	repo := section.NewRepository(r.db)
-	service := section.NewService(repo)
+	repo := r.repos.section
+	service := section.NewAuditedService(section.NewEventedService(section.NewService(repo), r.events), r.audit)
+	service = section.NewTracedService(section.NewCachedService(service, r.reports, r.cfg.ReportCacheTTL))
 	handler := handler.NewSection(service)
 	section := r.rg.Group("/sections")
 	{
 		section.GET("/", handler.GetAll())
 		section.GET("/:id", handler.Get())
 		section.POST("/", handler.Create())
-		section.PATCH("/:id", handler.Update())
+		section.PATCH("/:id", r.scope.Section(), handler.Update())
 		section.DELETE("/:id", handler.Delete())
+		section.POST("/:id/restore", handler.Restore())
 		section.GET("/reportProducts", handler.GetProductReport())
 	}
 
 }
 
 func (r *router) buildWarehouseRoutes() {
-	repo := warehouse.NewRepository(r.db)
-	service := warehouse.NewService(repo)
+	repo := r.repos.warehouse
+	service := warehouse.NewTracedService(warehouse.NewAuditedService(warehouse.NewEventedService(warehouse.NewService(repo), r.events), r.audit))
 	handler := handler.NewWarehouse(service)
 	whRoutes := r.rg.Group("/warehouses")
 	{
 		whRoutes.GET("/", handler.GetAll())
 		whRoutes.POST("/", handler.Create())
 		whRoutes.GET("/:id", handler.Get())
+		whRoutes.GET("/utilization", handler.Utilizations())
+		whRoutes.GET("/:id/utilization", handler.Utilization())
 		whRoutes.PATCH("/:id", handler.Update())
 		whRoutes.DELETE("/:id", handler.Delete())
+		whRoutes.POST("/:id/restore", handler.Restore())
 	}
 }
 
 func (r *router) buildEmployeeRoutes() {
-	repo := employee.NewRepository(r.db)
-	service := employee.NewService(repo)
+	repo := r.repos.employee
+	service := employee.NewAuditedService(employee.NewEventedService(employee.NewService(repo), r.events), r.audit)
+	service = employee.NewTracedService(employee.NewCachedService(service, r.reports, r.cfg.ReportCacheTTL))
 	handler := handler.NewEmployee(service)
 	employeeRoutes := r.rg.Group("/employees")
 	{
@@ -127,23 +333,25 @@ func (r *router) buildEmployeeRoutes() {
 		employeeRoutes.GET("/reportInboundOrders", handler.GetInboundOrders())
 		employeeRoutes.PATCH("/:id", handler.Update())
 		employeeRoutes.DELETE("/:id", handler.Delete())
+		employeeRoutes.POST("/:id/restore", handler.Restore())
 	}
 }
 
 func (r *router) buildInboundOrderRoutes() {
 
-	repo := inboundorder.NewRepository(r.db)
-	service := inboundorder.NewService(repo)
+	repo := r.repos.inboundOrder
+	service := inboundorder.NewTracedService(inboundorder.NewAuditedService(inboundorder.NewEventedService(inboundorder.NewService(repo), r.events), r.audit))
 	handler := handler.NewInboundOrder(service)
 	inboundOrdersRoutes := r.rg.Group("/inboundOrders")
 
-	inboundOrdersRoutes.POST("/", handler.Create())
+	inboundOrdersRoutes.POST("/", r.scope.InboundOrder(), handler.Create())
 }
 
 func (r *router) buildBuyerRoutes() {
 
-	repo := buyer.NewRepository(r.db)
-	service := buyer.NewService(repo)
+	repo := r.repos.buyer
+	service := buyer.NewAuditedService(buyer.NewEventedService(buyer.NewService(repo), r.events), r.audit)
+	service = buyer.NewTracedService(buyer.NewCachedService(service, r.reports, r.cfg.ReportCacheTTL))
 	handler := handler.NewBuyer(service)
 	buyersRoutes := r.rg.Group("/buyers")
 
@@ -153,25 +361,31 @@ func (r *router) buildBuyerRoutes() {
 	buyersRoutes.GET("/", handler.GetAll())
 	buyersRoutes.GET("/:id", handler.Get())
 	buyersRoutes.POST("/", handler.Create())
+	buyersRoutes.POST("/bulk", handler.Import())
 	buyersRoutes.PATCH("/:id", handler.Update())
 	buyersRoutes.DELETE("/:id", handler.Delete())
+	buyersRoutes.POST("/:id/restore", handler.Restore())
 	buyersRoutes.GET("/reportPurchaseOrders", handler.PurchaseOrders())
+	buyersRoutes.GET("/topBuyers", handler.TopBuyers())
+	buyersRoutes.GET("/:id/purchaseOrders", handler.PurchaseHistory())
 }
 
 func (r *router) buildPurchaseOrdersRoutes() {
-	repo := purchaseOrder.NewRepository(r.db)
-	service := purchaseOrder.NewService(repo)
+	repo := r.repos.purchaseOrder
+	service := purchaseOrder.NewTracedService(purchaseOrder.NewAuditedService(purchaseOrder.NewEventedService(purchaseOrder.NewService(repo), r.events), r.audit))
 	handler := handler.NewPurchaseOrder(service)
 	purchaseOrderRoutes := r.rg.Group("/purchaseOrders")
 	{
 		purchaseOrderRoutes.POST("/", handler.Create())
+		purchaseOrderRoutes.PATCH("/:id/status", handler.UpdateStatus())
 	}
 }
 
 func (r *router) buildLocalitiesRoutes() {
 
-	repo := locality.NewRepository(r.db)
-	service := locality.NewService(repo)
+	repo := r.repos.locality
+	service := locality.NewAuditedService(locality.NewEventedService(locality.NewService(repo), r.events), r.audit)
+	service = locality.NewTracedService(locality.NewCachedService(service, r.reports, r.cfg.ReportCacheTTL))
 	handler := handler.NewLocality(service)
 
 	localitiesRoutes := r.rg.Group("/localities")
@@ -184,8 +398,8 @@ func (r *router) buildLocalitiesRoutes() {
 
 func (r *router) buildCarryRoutes() {
 
-	repo := carry.NewRepository(r.db)
-	service := carry.NewService(repo)
+	repo := r.repos.carry
+	service := carry.NewTracedService(carry.NewAuditedService(carry.NewEventedService(carry.NewService(repo), r.events), r.audit))
 	handler := handler.NewCarry(service)
 	carrieRoutes := r.rg.Group("/carries")
 	{
@@ -194,12 +408,12 @@ func (r *router) buildCarryRoutes() {
 }
 
 func (r *router) buildProductBatchRoutes() {
-	repo := product_batch.NewRepository(r.db)
-	service := product_batch.NewService(repo)
+	repo := r.repos.productBatch
+	service := product_batch.NewTracedService(product_batch.NewAuditedService(product_batch.NewEventedService(product_batch.NewService(repo), r.events), r.audit))
 	handler := handler.NewProductBatch(service)
 	section := r.rg.Group("/productBatches")
 	{
-		section.POST("/", handler.Create())
+		section.POST("/", r.scope.ProductBatch(), handler.Create())
 	}
 
 }
@@ -207,5 +421,5 @@ func (r *router) buildProductBatchRoutes() {
 func (r *router) buildSwaggerRoutes() {
 	docs.SwaggerInfo.Host = "localhost:8080"
 	docs.SwaggerInfo.BasePath = "/api/v1"
-	r.rg.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.public.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
@@ -119,6 +119,12 @@ create table user_rol(
     rol_id INT
 );
 
+alter table users
+add unique (username);
+
+alter table employees
+add user_id INT NULL unique;
+
 create table rol(
     id INT NOT NULL PRIMARY KEY auto_increment,
     description VARCHAR(255),
@@ -168,6 +174,58 @@ create table order_details(
 alter table sellers
 add locality_id INT;
 
+alter table sellers add deleted_at datetime null;
+alter table products add deleted_at datetime null;
+alter table warehouses add deleted_at datetime null;
+alter table sections add deleted_at datetime null;
+alter table employees add deleted_at datetime null;
+alter table buyers add deleted_at datetime null;
+alter table products add version int not null default 1;
+alter table warehouses add version int not null default 1;
+alter table sections add version int not null default 1;
+alter table employees add version int not null default 1;
+
+create table idempotency_keys(
+    username varchar(255) not null,
+    idempotency_key varchar(255) not null,
+    method varchar(10) not null,
+    path varchar(255) not null,
+    request_hash char(64) not null,
+    status_code int not null default 0,
+    response_body blob,
+    created_at datetime(6) not null default current_timestamp(6),
+    primary key (username, idempotency_key)
+);
+
+create table audit_log(
+    id int not null primary key auto_increment,
+    entity varchar(50) not null,
+    entity_id int not null,
+    action varchar(10) not null,
+    before_data json,
+    after_data json,
+    actor varchar(255) not null,
+    request_id varchar(64) not null,
+    created_at datetime not null default current_timestamp,
+    index audit_log_entity (entity, entity_id, created_at)
+);
+
+create table outbox_events(
+    id int not null primary key auto_increment,
+    type varchar(100) not null,
+    entity varchar(50) not null,
+    entity_id int not null,
+    payload json not null,
+    request_id varchar(64) not null,
+    status varchar(10) not null,
+    attempts int not null default 0,
+    next_attempt_at datetime not null,
+    last_error text,
+    created_at datetime not null default current_timestamp,
+    delivered_at datetime,
+    index outbox_events_pending (status, next_attempt_at)
+);
+
 /* DATA */
 
 insert into buyers (id, card_number_id, first_name, last_name) values (1, '51442-543', 'Hercule', 'Gouldeby');
@@ -229,4 +287,7 @@ insert into provinces (id, province_name, id_country) values (1, 'Frederiksberg'
 insert into provinces (id, province_name, id_country) values (2, 'Shuangta', 2);
 insert into provinces (id, province_name, id_country) values (3, 'Quibdó', 3);
 insert into provinces (id, province_name, id_country) values (4, 'Nantes', 4);
-insert into provinces (id, province_name, id_country) values (5, 'Xiaosong', 5);
\ No newline at end of file
+insert into provinces (id, province_name, id_country) values (5, 'Xiaosong', 5);
+insert into rol (id, rol_name, description) values (1, 'admin', 'Manages warehouses, sections, users and roles');
+insert into rol (id, rol_name, description) values (2, 'warehouse_operator', 'Registers inbound orders and product batches');
+insert into rol (id, rol_name, description) values (3, 'sales', 'Manages buyers and purchase orders');
\ No newline at end of file
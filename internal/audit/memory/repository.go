@@ -0,0 +1,71 @@
+// Package memory implements audit.Repository over the tables of a memdb.DB,
+// with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns an audit.Repository that keeps the audit log in db.
+func NewRepository(db *memdb.DB) audit.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Save(ctx context.Context, e domain.AuditEntry) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	e.ID = r.db.NextID("audit_log")
+	e.CreatedAt = r.db.Now()
+	r.db.AuditLog = append(r.db.AuditLog, e)
+	return e.ID, nil
+}
+
+func (r *repository) Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error) {
+	entries := []domain.AuditEntry{}
+	err := r.Stream(ctx, f, func(e domain.AuditEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Stream calls fn with each entry matching f, oldest first, stopping at the
+// first error fn returns.
+func (r *repository) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+	r.db.RLock()
+	var entries []domain.AuditEntry
+	for _, e := range r.db.AuditLog {
+		if f.Entity != "" && e.Entity != f.Entity {
+			continue
+		}
+		if f.EntityID != 0 && e.EntityID != f.EntityID {
+			continue
+		}
+		if !memdb.InRange(e.CreatedAt, domain.DateRange{From: f.From, To: f.To}) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	r.db.RUnlock()
+
+	for _, e := range entries {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
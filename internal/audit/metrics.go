@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "audit".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("audit"),
+	}
+}
+
+func (m *measuredRepository) Save(ctx context.Context, e domain.AuditEntry) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, e)
+}
+
+func (m *measuredRepository) Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error) {
+	defer m.timer.Since("Find", time.Now())
+	return m.Repository.Find(ctx, f)
+}
+
+func (m *measuredRepository) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+	defer m.timer.Since("Stream", time.Now())
+	return m.Repository.Stream(ctx, f, fn)
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
+)
+
+// Repository encapsulates the storage of the audit log.
+type Repository interface {
+	Save(ctx context.Context, e domain.AuditEntry) (int, error)
+	Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error)
+	Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error
+}
+
+type repository struct {
+	db    *sql.DB
+	stmts *storage.Statements
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db:    db,
+		stmts: storage.StatementsOf(db),
+	}
+}
+
+func (r *repository) Save(ctx context.Context, e domain.AuditEntry) (int, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.AuditSaveQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, e.Entity, e.EntityID, e.Action, nullJSON(e.Before), nullJSON(e.After), e.Actor, e.RequestID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r *repository) Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error) {
+	entries := []domain.AuditEntry{}
+	err := r.Stream(ctx, f, func(e domain.AuditEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Stream calls fn with each entry matching f as it is read from the database,
+// stopping at the first error fn returns.
+func (r *repository) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+	query := queries.AuditFindQuery
+	var args []interface{}
+	if f.Entity != "" {
+		query += queries.AuditByEntity
+		args = append(args, f.Entity)
+	}
+	if f.EntityID != 0 {
+		query += queries.AuditByEntityID
+		args = append(args, f.EntityID)
+	}
+	if f.From != "" {
+		query += queries.AuditFrom
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		query += queries.AuditTo
+		args = append(args, f.To)
+	}
+	query += queries.AuditFindOrder
+
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := domain.AuditEntry{}
+		var before, after []byte
+		if err := rows.Scan(&e.ID, &e.Entity, &e.EntityID, &e.Action, &before, &after, &e.Actor, &e.RequestID, &e.CreatedAt); err != nil {
+			return err
+		}
+		e.Before, e.After = before, after
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// nullJSON stores an empty document as NULL rather than an empty string.
+func nullJSON(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+)
+
+// Actions stored in audit_log.action.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+)
+
+// anonymous is the actor recorded for changes made outside an authenticated
+// request.
+const anonymous = "anonymous"
+
+// Recorder is the part of the audit service the audited entity services
+// depend on.
+type Recorder interface {
+	Record(ctx context.Context, entity string, id int, action string, before, after interface{})
+}
+
+type Service interface {
+	Recorder
+	Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error)
+	Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{
+		repository: r,
+	}
+}
+
+// Record stores a change made by the caller in ctx. before and after are
+// stored as JSON; pass nil for the side that does not exist. The change has
+// already been applied when Record is called, so a failure to store it is
+// logged instead of being returned to the caller.
+func (s *service) Record(ctx context.Context, entity string, id int, action string, before, after interface{}) {
+	e := domain.AuditEntry{
+		Entity:    entity,
+		EntityID:  id,
+		Action:    action,
+		Actor:     anonymous,
+		RequestID: web.RequestID(ctx),
+	}
+	if claims, ok := auth.FromContext(ctx); ok {
+		e.Actor = claims.Username
+	}
+
+	var err error
+	if e.Before, err = marshal(before); err == nil {
+		e.After, err = marshal(after)
+	}
+	if err == nil {
+		_, err = s.repository.Save(ctx, e)
+	}
+	if err != nil {
+		web.Logger(ctx).ErrorContext(ctx, "recording audit entry failed",
+			"entity", entity, "entity_id", id, "action", action, "error", err)
+	}
+}
+
+func (s *service) Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error) {
+	return s.repository.Find(ctx, f)
+}
+
+// Stream calls fn with each entry matching f without loading them all.
+func (s *service) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+	return s.repository.Stream(ctx, f, fn)
+}
+
+// State returns v as the before or after of a change, or nil when err says
+// it could not be read.
+func State(v interface{}, err error) interface{} {
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+func marshal(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func requestContext() context.Context {
+	ctx := context.WithValue(context.Background(), auth.ClaimsKey, auth.Claims{UserID: 1, Username: "admin"})
+	return context.WithValue(ctx, web.RequestIDKey, "req-1")
+}
+
+func TestRecordCreate(t *testing.T) {
+	repo := &mocks.MockAuditRepository{}
+	s := NewService(repo)
+
+	s.Record(requestContext(), "seller", 3, ActionCreate, nil, domain.Seller{ID: 3, CID: 10})
+
+	assert.Len(t, repo.MockData, 1)
+	e := repo.MockData[0]
+	assert.Equal(t, "seller", e.Entity)
+	assert.Equal(t, 3, e.EntityID)
+	assert.Equal(t, ActionCreate, e.Action)
+	assert.Nil(t, e.Before)
+	assert.JSONEq(t, `{"id":3,"cid":10,"company_name":"","address":"","telephone":"","locality_id":0}`, string(e.After))
+	assert.Equal(t, "admin", e.Actor)
+	assert.Equal(t, "req-1", e.RequestID)
+}
+
+func TestRecordWithoutCaller(t *testing.T) {
+	repo := &mocks.MockAuditRepository{}
+	s := NewService(repo)
+
+	s.Record(context.Background(), "seller", 3, ActionDelete, domain.Seller{ID: 3}, nil)
+
+	assert.Len(t, repo.MockData, 1)
+	assert.Equal(t, anonymous, repo.MockData[0].Actor)
+	assert.Empty(t, repo.MockData[0].RequestID)
+	assert.Nil(t, repo.MockData[0].After)
+}
+
+func TestRecordSaveError(t *testing.T) {
+	repo := &mocks.MockAuditRepository{ErrSave: errors.New("db down")}
+	s := NewService(repo)
+
+	assert.NotPanics(t, func() {
+		s.Record(requestContext(), "seller", 3, ActionCreate, nil, domain.Seller{ID: 3})
+	})
+	assert.Empty(t, repo.MockData)
+}
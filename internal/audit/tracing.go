@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "audit.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("audit.Service"),
+	}
+}
+
+func (t *tracedService) Record(ctx context.Context, entity string, id int, action string, before, after interface{}) {
+	ctx, span := t.tracer.Start(ctx, "Record")
+	defer span.End()
+	t.Service.Record(ctx, entity, id, action, before, after)
+}
+
+func (t *tracedService) Find(ctx context.Context, f domain.AuditFilter) (_ []domain.AuditEntry, err error) {
+	ctx, span := t.tracer.Start(ctx, "Find")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Find(ctx, f)
+}
+
+func (t *tracedService) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Stream")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Stream(ctx, f, fn)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "audit.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("audit.Repository"),
+	}
+}
+
+func (t *tracedRepository) Save(ctx context.Context, e domain.AuditEntry) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, e)
+}
+
+func (t *tracedRepository) Find(ctx context.Context, f domain.AuditFilter) (_ []domain.AuditEntry, err error) {
+	ctx, span := t.tracer.Start(ctx, "Find")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Find(ctx, f)
+}
+
+func (t *tracedRepository) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Stream")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Stream(ctx, f, fn)
+}
@@ -0,0 +1,77 @@
+package buyer
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "buyer"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Save, Import, Update, Delete and Restore
+// are recorded in the audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Save(ctx context.Context, b domain.Buyer) (int, error) {
+	id, err := a.Service.Save(ctx, b)
+	if err != nil {
+		return id, err
+	}
+	b.ID = id
+	a.audit.Record(ctx, auditEntity, id, audit.ActionCreate, nil, b)
+	return id, nil
+}
+
+func (a *auditedService) Import(ctx context.Context, buyers []domain.Buyer, dryRun bool) ([]domain.BulkResult, error) {
+	results, err := a.Service.Import(ctx, buyers, dryRun)
+	if err != nil {
+		return results, err
+	}
+	for i, r := range results {
+		if r.Status == domain.BulkCreated {
+			b := buyers[i]
+			b.ID = r.ID
+			a.audit.Record(ctx, auditEntity, r.ID, audit.ActionCreate, nil, b)
+		}
+	}
+	return results, nil
+}
+
+func (a *auditedService) Update(ctx context.Context, b domain.Buyer) error {
+	before, beforeErr := a.Service.Get(ctx, b.ID)
+	if err := a.Service.Update(ctx, b); err != nil {
+		return err
+	}
+	after, afterErr := a.Service.Get(ctx, b.ID)
+	a.audit.Record(ctx, auditEntity, b.ID, audit.ActionUpdate, audit.State(before, beforeErr), audit.State(after, afterErr))
+	return nil
+}
+
+func (a *auditedService) Delete(ctx context.Context, id int) error {
+	before, beforeErr := a.Service.Get(ctx, id)
+	if err := a.Service.Delete(ctx, id); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, auditEntity, id, audit.ActionDelete, audit.State(before, beforeErr), nil)
+	return nil
+}
+
+func (a *auditedService) Restore(ctx context.Context, id int) error {
+	if err := a.Service.Restore(ctx, id); err != nil {
+		return err
+	}
+	after, err := a.Service.Get(ctx, id)
+	a.audit.Record(ctx, auditEntity, id, audit.ActionRestore, nil, audit.State(after, err))
+	return nil
+}
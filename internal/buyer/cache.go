@@ -0,0 +1,41 @@
+package buyer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/cache"
+)
+
+// PurchaseOrdersCacheKey prefixes the cache keys of the purchase orders
+// reports, to drop them when the data they are computed from changes.
+const PurchaseOrdersCacheKey = "buyer:report_purchase_orders:"
+
+// cachedService serves the purchase orders reports of the wrapped Service
+// from a cache.
+type cachedService struct {
+	Service
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedService wraps s so its purchase orders reports are kept in c for
+// ttl. They are not dropped on changes by themselves: whoever changes buyers
+// or purchase orders must delete the keys under PurchaseOrdersCacheKey.
+func NewCachedService(s Service, c cache.Cache, ttl time.Duration) Service {
+	return &cachedService{
+		Service: s,
+		cache:   c,
+		ttl:     ttl,
+	}
+}
+
+func (s *cachedService) GetPurchaseOrders(ctx context.Context, id int) (reports []domain.BuyerOrders, err error) {
+	err = cache.Fetch(ctx, s.cache, PurchaseOrdersCacheKey+strconv.Itoa(id), s.ttl, &reports, func() error {
+		reports, err = s.Service.GetPurchaseOrders(ctx, id)
+		return err
+	})
+	return reports, err
+}
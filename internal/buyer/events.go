@@ -0,0 +1,103 @@
+package buyer
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save, Import, Update, Delete and Restore
+// publish BuyerCreated, BuyerUpdated, BuyerDeleted and BuyerRestored
+// events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, b domain.Buyer) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, b)
+		if err != nil {
+			return err
+		}
+		b.ID = id
+		return e.outbox.Publish(ctx, domain.BuyerCreated{Buyer: b})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return b.ID, nil
+}
+
+// Import publishes the creation of the rows it saves. Each batch of rows is
+// saved in a savepoint, so a failed batch leaves the others and their events.
+func (e *eventedService) Import(ctx context.Context, buyers []domain.Buyer, dryRun bool) ([]domain.BulkResult, error) {
+	if dryRun {
+		return e.Service.Import(ctx, buyers, dryRun)
+	}
+	var results []domain.BulkResult
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		if results, err = e.Service.Import(ctx, buyers, dryRun); err != nil {
+			return err
+		}
+		var events []domain.Event
+		for i, r := range results {
+			if r.Status == domain.BulkCreated {
+				b := buyers[i]
+				b.ID = r.ID
+				events = append(events, domain.BuyerCreated{Buyer: b})
+			}
+		}
+		return e.outbox.Publish(ctx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (e *eventedService) Update(ctx context.Context, b domain.Buyer) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Update(ctx, b); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, b.ID)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.BuyerUpdated{Buyer: after})
+	})
+}
+
+func (e *eventedService) Delete(ctx context.Context, id int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Delete(ctx, id); err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.BuyerDeleted{ID: id})
+	})
+}
+
+func (e *eventedService) Restore(ctx context.Context, id int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Restore(ctx, id); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.BuyerRestored{Buyer: after})
+	})
+}
@@ -0,0 +1,359 @@
+// Package memory implements buyer.Repository over the tables of a memdb.DB,
+// with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/buyer"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a buyer.Repository that keeps the buyers in db.
+func NewRepository(db *memdb.DB) buyer.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the buyer with id, or -1.
+func (r *repository) index(id int) int {
+	for i, b := range r.db.Buyers {
+		if b.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error) {
+	var buyers []domain.Buyer
+	err := r.StreamAll(ctx, includeDeleted, func(b domain.Buyer) error {
+		buyers = append(buyers, b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buyers, nil
+}
+
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error {
+	r.db.RLock()
+	var buyers []domain.Buyer
+	for _, b := range r.db.Buyers {
+		if includeDeleted || b.DeletedAt == nil {
+			buyers = append(buyers, b)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, b := range buyers {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Buyers[i].DeletedAt != nil {
+		return domain.Buyer{}, sql.ErrNoRows
+	}
+	return r.db.Buyers[i], nil
+}
+
+func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, b := range r.db.Buyers {
+		if b.CardNumberID == cardNumberID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) ExistingCardNumbers(ctx context.Context, cardNumberIDs []string) (map[string]bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	wanted := map[string]bool{}
+	for _, cardNumberID := range cardNumberIDs {
+		wanted[cardNumberID] = true
+	}
+	existing := map[string]bool{}
+	for _, b := range r.db.Buyers {
+		if wanted[b.CardNumberID] {
+			existing[b.CardNumberID] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	return r.insert(b), nil
+}
+
+func (r *repository) SaveBatch(ctx context.Context, buyers []domain.Buyer) ([]int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	ids := make([]int, 0, len(buyers))
+	for _, b := range buyers {
+		ids = append(ids, r.insert(b))
+	}
+	return ids, nil
+}
+
+func (r *repository) insert(b domain.Buyer) int {
+	b.ID = r.db.NextID("buyers")
+	b.DeletedAt = nil
+	r.db.Buyers = append(r.db.Buyers, b)
+	return b.ID
+}
+
+// Update changes the names of the buyer only and, like the UPDATE it
+// replaces, doesn't fail when no buyer matches.
+func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(b.ID)
+	if i < 0 || r.db.Buyers[i].DeletedAt != nil {
+		return nil
+	}
+	r.db.Buyers[i].FirstName, r.db.Buyers[i].LastName = b.FirstName, b.LastName
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Buyers[i].DeletedAt != nil {
+		return buyer.ErrNotFound
+	}
+	if dependents := r.dependents(id); len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "buyer", ID: id, Dependents: dependents}
+	}
+	now := r.db.Now()
+	r.db.Buyers[i].DeletedAt = &now
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Buyers[i].DeletedAt == nil {
+		return buyer.ErrNotFound
+	}
+	r.db.Buyers[i].DeletedAt = nil
+	return nil
+}
+
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.dependents(id), nil
+}
+
+// dependents counts the records referencing the buyer id. The caller holds
+// the lock of r.db.
+func (r *repository) dependents(id int) []domain.Dependent {
+	orders := 0
+	for _, po := range r.db.PurchaseOrders {
+		if po.BuyerId == id {
+			orders++
+		}
+	}
+	return domain.NewDependents([]string{"purchase_orders"}, []int{orders})
+}
+
+// orders counts the purchase orders of the buyer b.
+func (r *repository) orders(b domain.Buyer) domain.BuyerOrders {
+	bo := domain.BuyerOrders{ID: b.ID, CardNumberID: b.CardNumberID, FirstName: b.FirstName, LastName: b.LastName}
+	for _, po := range r.db.PurchaseOrders {
+		if po.BuyerId == b.ID {
+			bo.PurchaseOrdersCount++
+		}
+	}
+	return bo
+}
+
+func (r *repository) GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error) {
+	if id == 0 {
+		var purchaseOrders []domain.BuyerOrders
+		err := r.StreamPurchaseOrders(ctx, func(b domain.BuyerOrders) error {
+			purchaseOrders = append(purchaseOrders, b)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return purchaseOrders, nil
+	}
+
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Buyers[i].DeletedAt != nil {
+		return nil, sql.ErrNoRows
+	}
+	return []domain.BuyerOrders{r.orders(r.db.Buyers[i])}, nil
+}
+
+func (r *repository) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error {
+	r.db.RLock()
+	var purchaseOrders []domain.BuyerOrders
+	for _, b := range r.db.Buyers {
+		if b.DeletedAt == nil {
+			purchaseOrders = append(purchaseOrders, r.orders(b))
+		}
+	}
+	r.db.RUnlock()
+
+	for _, bo := range purchaseOrders {
+		if err := fn(bo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// history returns the purchase orders of the buyer b with their items,
+// newest first, and what b has spent.
+func (r *repository) history(b domain.Buyer) domain.BuyerHistory {
+	history := domain.BuyerHistory{
+		BuyerSpend: domain.BuyerSpend{BuyerID: b.ID, CardNumberID: b.CardNumberID, FirstName: b.FirstName, LastName: b.LastName},
+		Orders:     []domain.BuyerOrder{},
+	}
+	for _, po := range r.db.PurchaseOrders {
+		if po.BuyerId != b.ID {
+			continue
+		}
+		o := domain.BuyerOrder{
+			ID:            po.ID,
+			OrderNumber:   po.OrderNumber,
+			OrderDate:     po.OrderDate,
+			TrackingCode:  po.TrackingCode,
+			OrderStatusID: po.OrderStatusId,
+			Status:        r.status(po.OrderStatusId),
+			Items:         []domain.OrderItem{},
+		}
+		for _, od := range r.db.OrderDetails {
+			if od.PurchaseOrderID != po.ID {
+				continue
+			}
+			item := domain.OrderItem{ID: od.ID, ProductRecordID: od.ProductRecordID, Quantity: od.Quantity}
+			if rc, ok := r.db.ProductRecord(od.ProductRecordID); ok {
+				item.ProductID, item.UnitPrice = rc.ProductID, rc.SalePrice
+			}
+			item.Total = float64(item.Quantity) * item.UnitPrice
+			o.Items = append(o.Items, item)
+			o.Total += item.Total
+		}
+		history.Orders = append(history.Orders, o)
+
+		history.OrdersCount++
+		history.TotalSpent += o.Total
+		if history.LastOrderDate == nil || po.OrderDate > *history.LastOrderDate {
+			date := po.OrderDate
+			history.LastOrderDate = &date
+		}
+	}
+	if history.OrdersCount > 0 {
+		history.AverageOrderValue = history.TotalSpent / float64(history.OrdersCount)
+	}
+	sort.SliceStable(history.Orders, func(i, j int) bool {
+		if history.Orders[i].OrderDate != history.Orders[j].OrderDate {
+			return history.Orders[i].OrderDate > history.Orders[j].OrderDate
+		}
+		return history.Orders[i].ID > history.Orders[j].ID
+	})
+	return history
+}
+
+func (r *repository) status(id int) string {
+	for _, os := range r.db.OrderStatuses {
+		if os.ID == id {
+			return os.Description
+		}
+	}
+	return ""
+}
+
+func (r *repository) PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Buyers[i].DeletedAt != nil {
+		return domain.BuyerHistory{}, sql.ErrNoRows
+	}
+	return r.history(r.db.Buyers[i]), nil
+}
+
+// spends returns what each buyer with purchase orders has spent, ranked by
+// total spent.
+func (r *repository) spends() []domain.BuyerSpend {
+	spends := []domain.BuyerSpend{}
+	for _, b := range r.db.Buyers {
+		if b.DeletedAt != nil {
+			continue
+		}
+		if s := r.history(b).BuyerSpend; s.OrdersCount > 0 {
+			spends = append(spends, s)
+		}
+	}
+	sort.SliceStable(spends, func(i, j int) bool {
+		if spends[i].TotalSpent != spends[j].TotalSpent {
+			return spends[i].TotalSpent > spends[j].TotalSpent
+		}
+		return spends[i].BuyerID < spends[j].BuyerID
+	})
+	return spends
+}
+
+func (r *repository) TopBuyers(ctx context.Context, limit, offset int) ([]domain.BuyerSpend, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	spends := r.spends()
+	if offset > len(spends) {
+		offset = len(spends)
+	}
+	spends = spends[offset:]
+	if limit < len(spends) {
+		spends = spends[:limit]
+	}
+	return spends, nil
+}
+
+func (r *repository) CountTopBuyers(ctx context.Context) (int, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return len(r.spends()), nil
+}
@@ -0,0 +1,105 @@
+package buyer
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "buyer".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("buyer"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (m *measuredRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error {
+	defer m.timer.Since("StreamAll", time.Now())
+	return m.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Buyer, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, cardNumberID string) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, cardNumberID)
+}
+
+func (m *measuredRepository) ExistingCardNumbers(ctx context.Context, cardNumberIDs []string) (map[string]bool, error) {
+	defer m.timer.Since("ExistingCardNumbers", time.Now())
+	return m.Repository.ExistingCardNumbers(ctx, cardNumberIDs)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, b domain.Buyer) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, b)
+}
+
+func (m *measuredRepository) SaveBatch(ctx context.Context, buyers []domain.Buyer) ([]int, error) {
+	defer m.timer.Since("SaveBatch", time.Now())
+	return m.Repository.SaveBatch(ctx, buyers)
+}
+
+func (m *measuredRepository) Update(ctx context.Context, b domain.Buyer) error {
+	defer m.timer.Since("Update", time.Now())
+	return m.Repository.Update(ctx, b)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, id int) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, id)
+}
+
+func (m *measuredRepository) Restore(ctx context.Context, id int) error {
+	defer m.timer.Since("Restore", time.Now())
+	return m.Repository.Restore(ctx, id)
+}
+
+func (m *measuredRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	defer m.timer.Since("Dependents", time.Now())
+	return m.Repository.Dependents(ctx, id)
+}
+
+func (m *measuredRepository) GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error) {
+	defer m.timer.Since("GetPurchaseOrders", time.Now())
+	return m.Repository.GetPurchaseOrders(ctx, id)
+}
+
+func (m *measuredRepository) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error {
+	defer m.timer.Since("StreamPurchaseOrders", time.Now())
+	return m.Repository.StreamPurchaseOrders(ctx, fn)
+}
+
+func (m *measuredRepository) PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error) {
+	defer m.timer.Since("PurchaseHistory", time.Now())
+	return m.Repository.PurchaseHistory(ctx, id)
+}
+
+func (m *measuredRepository) TopBuyers(ctx context.Context, limit, offset int) ([]domain.BuyerSpend, error) {
+	defer m.timer.Since("TopBuyers", time.Now())
+	return m.Repository.TopBuyers(ctx, limit, offset)
+}
+
+func (m *measuredRepository) CountTopBuyers(ctx context.Context) (int, error) {
+	defer m.timer.Since("CountTopBuyers", time.Now())
+	return m.Repository.CountTopBuyers(ctx)
+}
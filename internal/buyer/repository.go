@@ -3,55 +3,95 @@ package buyer
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"strings"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a buyer.
 type Repository interface {
-	GetAll(ctx context.Context) ([]domain.Buyer, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error
 	Get(ctx context.Context, id int) (domain.Buyer, error)
 	Exists(ctx context.Context, cardNumberID string) bool
+	ExistingCardNumbers(ctx context.Context, cardNumberIDs []string) (map[string]bool, error)
 	Save(ctx context.Context, b domain.Buyer) (int, error)
+	SaveBatch(ctx context.Context, buyers []domain.Buyer) ([]int, error)
 	Update(ctx context.Context, b domain.Buyer) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) ([]domain.Dependent, error)
 	GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error)
+	StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error
+	PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error)
+	TopBuyers(ctx context.Context, limit, offset int) ([]domain.BuyerSpend, error)
+	CountTopBuyers(ctx context.Context) (int, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
-	query := "SELECT * FROM buyers"
-	rows, err := r.db.Query(query)
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error) {
+	var buyers []domain.Buyer
+	err := r.StreamAll(ctx, includeDeleted, func(b domain.Buyer) error {
+		buyers = append(buyers, b)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var buyers []domain.Buyer
+	return buyers, nil
+}
+
+//StreamAll call fn with each buyer as it is read from the database and stop at the first error returned by fn
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error {
+	query := queries.BuyerGetAllQuery
+	if !includeDeleted {
+		query += queries.BuyerNotDeleted
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		b := domain.Buyer{}
-		_ = rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
-		buyers = append(buyers, b)
+		if err := rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
 	}
 
-	return buyers, nil
+	return rows.Err()
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
-	query := "SELECT * FROM buyers WHERE id = ?;"
-	row := r.db.QueryRow(query, id)
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerGetQuery)
+	if err != nil {
+		return domain.Buyer{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
 	b := domain.Buyer{}
-	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+	err = row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.DeletedAt)
 	if err != nil {
 		return domain.Buyer{}, err
 	}
@@ -60,20 +100,83 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
-	query := "SELECT card_number_id FROM buyers WHERE card_number_id=?;"
-	row := r.db.QueryRow(query, cardNumberID)
-	err := row.Scan(&cardNumberID)
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, cardNumberID)
+	err = row.Scan(&cardNumberID)
 	return err == nil
 }
 
+//ExistingCardNumbers returns which of cardNumberIDs are already used by a buyer, looking them up BulkBatchSize at a time
+func (r *repository) ExistingCardNumbers(ctx context.Context, cardNumberIDs []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	for from := 0; from < len(cardNumberIDs); from += domain.BulkBatchSize {
+		to := from + domain.BulkBatchSize
+		if to > len(cardNumberIDs) {
+			to = len(cardNumberIDs)
+		}
+		args := make([]interface{}, 0, to-from)
+		for _, cardNumberID := range cardNumberIDs[from:to] {
+			args = append(args, cardNumberID)
+		}
+		query := queries.BuyerExistingCardNumbersQuery + strings.Repeat(",?", len(args)-1) + ")"
+		rows, err := storage.ExecutorOf(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var cardNumberID string
+			if err := rows.Scan(&cardNumberID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			existing[cardNumberID] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+//SaveBatch inserts buyers in a single transaction, or in a savepoint
+//of the one ctx carries, and returns their ids
+func (r *repository) SaveBatch(ctx context.Context, buyers []domain.Buyer) ([]int, error) {
+	ids := make([]int, 0, len(buyers))
+	err := storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		stmt, err := r.stmts.Prepare(ctx, queries.BuyerSaveQuery)
+		if err != nil {
+			return err
+		}
+		for _, b := range buyers {
+			res, err := stmt.ExecContext(ctx, b.CardNumberID, b.FirstName, b.LastName)
+			if err != nil {
+				return err
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			ids = append(ids, int(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
-	query := "INSERT INTO buyers(card_number_id,first_name,last_name) VALUES (?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerSaveQuery)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&b.CardNumberID, &b.FirstName, &b.LastName)
+	res, err := stmt.ExecContext(ctx, &b.CardNumberID, &b.FirstName, &b.LastName)
 	if err != nil {
 		return 0, err
 	}
@@ -87,13 +190,12 @@ func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
-	query := "UPDATE buyers SET first_name=?, last_name=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerUpdateQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&b.FirstName, &b.LastName, &b.ID)
+	res, err := stmt.ExecContext(ctx, &b.FirstName, &b.LastName, &b.ID)
 	if err != nil {
 		return err
 	}
@@ -106,39 +208,46 @@ func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
 	return nil
 }
 
+// Delete soft deletes the buyer unless records still reference it, which
+// returns a *domain.DependentsError. The references are counted after the
+// delete, in its transaction, which is rolled back when there are any.
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM buyers WHERE id = ?"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return err
-	}
+	return storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		stmt, err := r.stmts.Prepare(ctx, queries.BuyerDeleteQuery)
+		if err != nil {
+			return err
+		}
 
-	res, err := stmt.Exec(id)
-	if err != nil {
-		return err
-	}
+		res, err := stmt.ExecContext(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	affect, err := res.RowsAffected()
-	if err != nil {
-		return err
-	}
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
 
-	if affect < 1 {
-		return ErrNotFound
-	}
+		if affect < 1 {
+			return ErrNotFound
+		}
 
-	return nil
+		dependents, err := r.Dependents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return &domain.DependentsError{Entity: "buyer", ID: id, Dependents: dependents}
+		}
+		return nil
+	})
 }
 
 func (r *repository) GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error) {
 	var purchaseOrders []domain.BuyerOrders
 
 	if id != 0 {
-		query := "SELECT b.id, b.card_number_id, b.first_name, b.last_name, count(po.buyer_id) FROM buyers AS b " +
-			"LEFT JOIN purchase_orders AS po " +
-			"ON b.id=po.buyer_id WHERE b.id=? GROUP BY b.id;"
-
-		stmt, err := r.db.PrepareContext(ctx, query)
+		stmt, err := r.stmts.Prepare(ctx, queries.BuyerPurchaseOrdersQuery)
 		if err != nil {
 			return nil, err
 		}
@@ -146,7 +255,6 @@ func (r *repository) GetPurchaseOrders(ctx context.Context, id int) ([]domain.Bu
 		b := domain.BuyerOrders{}
 		row := stmt.QueryRowContext(ctx, id)
 		if err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.PurchaseOrdersCount); err != nil {
-			fmt.Println(err)
 			return nil, err
 		}
 
@@ -155,22 +263,181 @@ func (r *repository) GetPurchaseOrders(ctx context.Context, id int) ([]domain.Bu
 
 	} else {
 
-		query := "SELECT b.id,b.card_number_id,b.first_name,b.last_name, count(po.buyer_id) " +
-			"FROM buyers AS b LEFT JOIN purchase_orders AS po ON po.buyer_id=b.id GROUP BY b.id;"
-		rows, err := r.db.QueryContext(ctx, query)
-
+		err := r.StreamPurchaseOrders(ctx, func(b domain.BuyerOrders) error {
+			purchaseOrders = append(purchaseOrders, b)
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
+		return purchaseOrders, nil
+	}
 
-		for rows.Next() {
-			b := domain.BuyerOrders{}
-			if err := rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.PurchaseOrdersCount); err != nil {
-				return nil, err
-			}
-			purchaseOrders = append(purchaseOrders, b)
+}
+
+//StreamPurchaseOrders call fn with each buyer and the number of its purchases as it is read from the database and stop at the first error returned by fn
+func (r *repository) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerAllPurchaseOrdersQuery)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		b := domain.BuyerOrders{}
+		if err := rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.PurchaseOrdersCount); err != nil {
+			return err
 		}
-		return purchaseOrders, nil
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func scanSpend(row interface{ Scan(...interface{}) error }) (domain.BuyerSpend, error) {
+	s := domain.BuyerSpend{}
+	if err := row.Scan(&s.BuyerID, &s.CardNumberID, &s.FirstName, &s.LastName, &s.OrdersCount, &s.TotalSpent, &s.LastOrderDate); err != nil {
+		return s, err
+	}
+	if s.OrdersCount > 0 {
+		s.AverageOrderValue = s.TotalSpent / float64(s.OrdersCount)
+	}
+	return s, nil
+}
+
+//PurchaseHistory return the purchase orders of the buyer with their details, newest first, and what the buyer has spent
+func (r *repository) PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerSpendByIDQuery)
+	if err != nil {
+		return domain.BuyerHistory{}, err
+	}
+	spend, err := scanSpend(stmt.QueryRowContext(ctx, id))
+	if err != nil {
+		return domain.BuyerHistory{}, err
+	}
+	history := domain.BuyerHistory{BuyerSpend: spend, Orders: []domain.BuyerOrder{}}
+
+	stmt, err = r.stmts.Prepare(ctx, queries.BuyerHistoryQuery)
+	if err != nil {
+		return domain.BuyerHistory{}, err
+	}
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return domain.BuyerHistory{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		o := domain.BuyerOrder{}
+		var itemID, recordID, productID, quantity sql.NullInt64
+		var price sql.NullFloat64
+		if err := rows.Scan(&o.ID, &o.OrderNumber, &o.OrderDate, &o.TrackingCode, &o.OrderStatusID, &o.Status,
+			&itemID, &recordID, &productID, &quantity, &price); err != nil {
+			return domain.BuyerHistory{}, err
+		}
+
+		//the rows of an order come together, one per detail
+		if n := len(history.Orders); n == 0 || history.Orders[n-1].ID != o.ID {
+			o.Items = []domain.OrderItem{}
+			history.Orders = append(history.Orders, o)
+		}
+		if !itemID.Valid {
+			continue
+		}
+		order := &history.Orders[len(history.Orders)-1]
+		item := domain.OrderItem{
+			ID:              int(itemID.Int64),
+			ProductRecordID: int(recordID.Int64),
+			ProductID:       int(productID.Int64),
+			Quantity:        int(quantity.Int64),
+			UnitPrice:       price.Float64,
+		}
+		item.Total = float64(item.Quantity) * item.UnitPrice
+		order.Items = append(order.Items, item)
+		order.Total += item.Total
+	}
+	if err := rows.Err(); err != nil {
+		return domain.BuyerHistory{}, err
+	}
+
+	return history, nil
+}
+
+//TopBuyers return the buyers with purchase orders ranked by what they spent, skipping the first offset
+func (r *repository) TopBuyers(ctx context.Context, limit, offset int) ([]domain.BuyerSpend, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerTopBuyersQuery)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buyers := []domain.BuyerSpend{}
+	for rows.Next() {
+		s, err := scanSpend(rows)
+		if err != nil {
+			return nil, err
+		}
+		buyers = append(buyers, s)
+	}
+
+	return buyers, rows.Err()
+}
+
+//CountTopBuyers return how many buyers have purchase orders
+func (r *repository) CountTopBuyers(ctx context.Context) (int, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerCountTopQuery)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	err = stmt.QueryRowContext(ctx).Scan(&count)
+	return count, err
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerRestoreQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Dependents counts the records that still reference the buyer.
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.BuyerDependentsQuery)
+	if err != nil {
+		return nil, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
+	counts := make([]int, 1)
+	if err := row.Scan(&counts[0]); err != nil {
+		return nil, err
 	}
 
+	return domain.NewDependents([]string{"purchase_orders"}, counts), nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
 )
 
 // Errors
@@ -13,13 +14,19 @@ var (
 )
 
 type Service interface {
-	GetAll(ctx context.Context) ([]domain.Buyer, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error
 	Get(ctx context.Context, id int) (domain.Buyer, error)
 	Exists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, b domain.Buyer) (int, error)
+	Import(ctx context.Context, buyers []domain.Buyer, dryRun bool) ([]domain.BulkResult, error)
 	Update(ctx context.Context, b domain.Buyer) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
 	GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error)
+	StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error
+	PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error)
+	TopBuyers(ctx context.Context, page, pageSize int) (domain.Page, error)
 }
 
 type service struct {
@@ -34,8 +41,13 @@ func NewService(r Repository) Service {
 }
 
 //GetAll receive the context, generate a instance of repository.GetAll and return a list of buyer to repository and error
-func (s *service) GetAll(ctx context.Context) ([]domain.Buyer, error) {
-	return s.repository.GetAll(ctx)
+func (s *service) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error) {
+	return s.repository.GetAll(ctx, includeDeleted)
+}
+
+//StreamAll receive the context and fn, generate a instance of repository.StreamAll and call fn with each buyer without loading them all
+func (s *service) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error {
+	return s.repository.StreamAll(ctx, includeDeleted, fn)
 }
 
 //Get receive the context and the buyer id from the handler, generate a instance of repository.Get and return the buyer and error
@@ -54,6 +66,29 @@ func (s *service) Save(ctx context.Context, b domain.Buyer) (int, error) {
 	return s.repository.Save(ctx, b)
 }
 
+//Import receive the context, the buyers to save and whether it is a dry run, save in batched transactions the buyers whose card_number_id is not taken and return the result of each in the same order
+func (s *service) Import(ctx context.Context, buyers []domain.Buyer, dryRun bool) ([]domain.BulkResult, error) {
+	cardNumberIDs := make([]string, len(buyers))
+	for i, b := range buyers {
+		cardNumberIDs[i] = b.CardNumberID
+	}
+	existing, err := s.repository.ExistingCardNumbers(ctx, cardNumberIDs)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BulkImport(cardNumberIDs, existing, nil, dryRun, func(rows []int) ([]int, error) {
+		batch := make([]domain.Buyer, len(rows))
+		for j, i := range rows {
+			batch[j] = buyers[i]
+		}
+		ids, err := s.repository.SaveBatch(ctx, batch)
+		if err != nil {
+			web.Logger(ctx).ErrorContext(ctx, "saving imported buyers failed", "error", err)
+		}
+		return ids, err
+	}), nil
+}
+
 //Update receive the context and the buyer to update, generate a instance of repository.Update and return error
 func (s *service) Update(ctx context.Context, b domain.Buyer) error {
 	return s.repository.Update(ctx, b)
@@ -68,3 +103,32 @@ func (s *service) Delete(ctx context.Context, id int) error {
 func (s *service) GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error) {
 	return s.repository.GetPurchaseOrders(ctx, id)
 }
+
+//StreamPurchaseOrders call fn with each buyer and the number of its purchases without loading them all
+func (s *service) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error {
+	return s.repository.StreamPurchaseOrders(ctx, fn)
+}
+
+//PurchaseHistory return the purchase orders of a buyer with their details and the totals of the buyer
+func (s *service) PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error) {
+	return s.repository.PurchaseHistory(ctx, id)
+}
+
+//TopBuyers return the page of the ranking of buyers by what they spent, counting pages from 1
+func (s *service) TopBuyers(ctx context.Context, page, pageSize int) (domain.Page, error) {
+	total, err := s.repository.CountTopBuyers(ctx)
+	if err != nil {
+		return domain.Page{}, err
+	}
+	buyers, err := s.repository.TopBuyers(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return domain.Page{}, err
+	}
+	return domain.Page{Page: page, PageSize: pageSize, Total: total, Items: buyers}, nil
+}
+
+// Restore brings back a deleted buyer. It returns ErrNotFound when the buyer
+// does not exist or is not deleted.
+func (s *service) Restore(ctx context.Context, id int) error {
+	return s.repository.Restore(ctx, id)
+}
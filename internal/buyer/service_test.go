@@ -67,7 +67,7 @@ func TestFindAllBuyer(t *testing.T) {
 	service := NewService(&mocks.MockBuyerRepository{
 		DataMock: dataBase,
 	})
-	result, err := service.GetAll(context.TODO())
+	result, err := service.GetAll(context.TODO(), false)
 
 	//assert
 	//No devuelva error
@@ -224,3 +224,17 @@ func TestExistsBuyer(t *testing.T) {
 	assert.True(t, err)
 
 }
+
+func TestTopBuyersPage(t *testing.T) {
+	spend := []domain.BuyerSpend{
+		{BuyerID: 2, TotalSpent: 300, OrdersCount: 2, AverageOrderValue: 150},
+		{BuyerID: 1, TotalSpent: 200, OrdersCount: 1, AverageOrderValue: 200},
+		{BuyerID: 3, TotalSpent: 50, OrdersCount: 1, AverageOrderValue: 50},
+	}
+	service := NewService(&mocks.MockBuyerRepository{MockSpend: spend})
+
+	page, err := service.TopBuyers(context.TODO(), 2, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Page{Page: 2, PageSize: 2, Total: 3, Items: spend[2:]}, page)
+}
@@ -0,0 +1,212 @@
+package buyer
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "buyer.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("buyer.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Buyer, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.Buyer, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Exists(ctx context.Context, cardNumberID string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Service.Exists(ctx, cardNumberID)
+}
+
+func (t *tracedService) Save(ctx context.Context, b domain.Buyer) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, b)
+}
+
+func (t *tracedService) Import(ctx context.Context, buyers []domain.Buyer, dryRun bool) (_ []domain.BulkResult, err error) {
+	ctx, span := t.tracer.Start(ctx, "Import")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Import(ctx, buyers, dryRun)
+}
+
+func (t *tracedService) Update(ctx context.Context, b domain.Buyer) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Update(ctx, b)
+}
+
+func (t *tracedService) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Delete(ctx, id)
+}
+
+func (t *tracedService) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Restore(ctx, id)
+}
+
+func (t *tracedService) GetPurchaseOrders(ctx context.Context, id int) (_ []domain.BuyerOrders, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetPurchaseOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetPurchaseOrders(ctx, id)
+}
+
+func (t *tracedService) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamPurchaseOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamPurchaseOrders(ctx, fn)
+}
+
+func (t *tracedService) PurchaseHistory(ctx context.Context, id int) (_ domain.BuyerHistory, err error) {
+	ctx, span := t.tracer.Start(ctx, "PurchaseHistory")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.PurchaseHistory(ctx, id)
+}
+
+func (t *tracedService) TopBuyers(ctx context.Context, page int, pageSize int) (_ domain.Page, err error) {
+	ctx, span := t.tracer.Start(ctx, "TopBuyers")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.TopBuyers(ctx, page, pageSize)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "buyer.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("buyer.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Buyer, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Buyer, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, cardNumberID string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, cardNumberID)
+}
+
+func (t *tracedRepository) ExistingCardNumbers(ctx context.Context, cardNumberIDs []string) (_ map[string]bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "ExistingCardNumbers")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ExistingCardNumbers(ctx, cardNumberIDs)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, b domain.Buyer) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, b)
+}
+
+func (t *tracedRepository) SaveBatch(ctx context.Context, buyers []domain.Buyer) (_ []int, err error) {
+	ctx, span := t.tracer.Start(ctx, "SaveBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SaveBatch(ctx, buyers)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, b domain.Buyer) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Update(ctx, b)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, id)
+}
+
+func (t *tracedRepository) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Restore(ctx, id)
+}
+
+func (t *tracedRepository) Dependents(ctx context.Context, id int) (_ []domain.Dependent, err error) {
+	ctx, span := t.tracer.Start(ctx, "Dependents")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Dependents(ctx, id)
+}
+
+func (t *tracedRepository) GetPurchaseOrders(ctx context.Context, id int) (_ []domain.BuyerOrders, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetPurchaseOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetPurchaseOrders(ctx, id)
+}
+
+func (t *tracedRepository) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamPurchaseOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamPurchaseOrders(ctx, fn)
+}
+
+func (t *tracedRepository) PurchaseHistory(ctx context.Context, id int) (_ domain.BuyerHistory, err error) {
+	ctx, span := t.tracer.Start(ctx, "PurchaseHistory")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.PurchaseHistory(ctx, id)
+}
+
+func (t *tracedRepository) TopBuyers(ctx context.Context, limit int, offset int) (_ []domain.BuyerSpend, err error) {
+	ctx, span := t.tracer.Start(ctx, "TopBuyers")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.TopBuyers(ctx, limit, offset)
+}
+
+func (t *tracedRepository) CountTopBuyers(ctx context.Context) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "CountTopBuyers")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.CountTopBuyers(ctx)
+}
@@ -0,0 +1,33 @@
+package carry
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "carry"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Save is recorded in the audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Save(ctx context.Context, c domain.Carry) (int, error) {
+	id, err := a.Service.Save(ctx, c)
+	if err != nil {
+		return id, err
+	}
+	c.ID = id
+	a.audit.Record(ctx, auditEntity, id, audit.ActionCreate, nil, c)
+	return id, nil
+}
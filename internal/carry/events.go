@@ -0,0 +1,38 @@
+package carry
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save publishes CarryCreated events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, c domain.Carry) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, c)
+		if err != nil {
+			return err
+		}
+		c.ID = id
+		return e.outbox.Publish(ctx, domain.CarryCreated{Carry: c})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return c.ID, nil
+}
@@ -0,0 +1,70 @@
+// Package memory implements carry.Repository over the tables of a memdb.DB,
+// with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/carry"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a carry.Repository that keeps the carries in db.
+func NewRepository(db *memdb.DB) carry.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Save(ctx context.Context, c domain.Carry) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if r.cidExists(c.CID) {
+		return 0, fmt.Errorf("carry with cid %v already exists", c.CID)
+	}
+	if !r.localityExists(c.LocalityID) {
+		return 0, fmt.Errorf("locality with id %v not exists", c.LocalityID)
+	}
+	c.ID = r.db.NextID("carries")
+	r.db.Carries = append(r.db.Carries, c)
+	return c.ID, nil
+}
+
+func (r *repository) CIDExists(ctx context.Context, cid string) (bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.cidExists(cid), nil
+}
+
+func (r *repository) cidExists(cid string) bool {
+	for _, c := range r.db.Carries {
+		if c.CID == cid {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) LocalityExists(ctx context.Context, id int) (bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.localityExists(id), nil
+}
+
+func (r *repository) localityExists(id int) bool {
+	for _, l := range r.db.Localities {
+		if l.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package carry
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped
+// Repository takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "carry".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("carry"),
+	}
+}
+
+func (m *measuredRepository) Save(ctx context.Context, w domain.Carry) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, w)
+}
+
+func (m *measuredRepository) CIDExists(ctx context.Context, cid string) (bool, error) {
+	defer m.timer.Since("CIDExists", time.Now())
+	return m.Repository.CIDExists(ctx, cid)
+}
+
+func (m *measuredRepository) LocalityExists(ctx context.Context, id int) (bool, error) {
+	defer m.timer.Since("LocalityExists", time.Now())
+	return m.Repository.LocalityExists(ctx, id)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
@@ -17,12 +18,14 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
@@ -46,11 +49,10 @@ func (r *repository) Save(ctx context.Context, c domain.Carry) (int, error) {
 	}
 
 	// Preparo el query
-	stmt, err := r.db.PrepareContext(ctx, queries.CarrySaveQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.CarrySaveQuery)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
 
 	// Ejecuto el query
 	res, err := stmt.ExecContext(ctx, &c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityID)
@@ -69,11 +71,10 @@ func (r *repository) Save(ctx context.Context, c domain.Carry) (int, error) {
 }
 
 func (r *repository) CIDExists(ctx context.Context, cid string) (bool, error) {
-	stmt, err := r.db.PrepareContext(ctx, queries.CarryCIDExistsQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.CarryCIDExistsQuery)
 	if err != nil {
 		return false, err
 	}
-	defer stmt.Close()
 
 	row := stmt.QueryRowContext(ctx, cid)
 	err = row.Scan(&cid)
@@ -84,11 +85,10 @@ func (r *repository) CIDExists(ctx context.Context, cid string) (bool, error) {
 }
 
 func (r *repository) LocalityExists(ctx context.Context, id int) (bool, error) {
-	stmt, err := r.db.PrepareContext(ctx, queries.CarryLocalityExistsQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.CarryLocalityExistsQuery)
 	if err != nil {
 		return false, err
 	}
-	defer stmt.Close()
 
 	row := stmt.QueryRowContext(ctx, id)
 	err = row.Scan(&id)
@@ -0,0 +1,74 @@
+package carry
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "carry.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("carry.Service"),
+	}
+}
+
+func (t *tracedService) Save(ctx context.Context, w domain.Carry) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, w)
+}
+
+func (t *tracedService) CIDExists(ctx context.Context, cid string) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "CIDExists")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.CIDExists(ctx, cid)
+}
+
+func (t *tracedService) LocalityExists(ctx context.Context, id int) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "LocalityExists")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.LocalityExists(ctx, id)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "carry.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("carry.Repository"),
+	}
+}
+
+func (t *tracedRepository) Save(ctx context.Context, w domain.Carry) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, w)
+}
+
+func (t *tracedRepository) CIDExists(ctx context.Context, cid string) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "CIDExists")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.CIDExists(ctx, cid)
+}
+
+func (t *tracedRepository) LocalityExists(ctx context.Context, id int) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "LocalityExists")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.LocalityExists(ctx, id)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "encoding/json"
+
+// AuditEntry records a single change to an entity. Before is empty for
+// creations and After is empty for deletions.
+type AuditEntry struct {
+	ID        int             `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"request_id"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// AuditFilter narrows an audit log query. Zero values match everything; From
+// and To are "YYYY-MM-DD hh:mm:ss" bounds, both inclusive.
+type AuditFilter struct {
+	Entity   string
+	EntityID int
+	From     string
+	To       string
+}
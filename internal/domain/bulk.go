@@ -0,0 +1,93 @@
+package domain
+
+// Statuses of a row in a bulk import.
+const (
+	BulkCreated  = "created"
+	BulkValid    = "valid"
+	BulkInvalid  = "invalid"
+	BulkConflict = "conflict"
+	BulkFailed   = "failed"
+)
+
+// BulkBatchSize is the number of rows a bulk import inserts per transaction.
+const BulkBatchSize = 500
+
+// BulkResult is the outcome of one row of a bulk import.
+type BulkResult struct {
+	Row     int         `json:"row"`
+	Status  string      `json:"status"`
+	ID      int         `json:"id,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// BulkReport is the answer to a bulk import, with the result of every row
+// and how many rows ended in each status.
+type BulkReport struct {
+	DryRun  bool           `json:"dry_run"`
+	Total   int            `json:"total"`
+	Counts  map[string]int `json:"counts"`
+	Results []BulkResult   `json:"results"`
+}
+
+// NewBulkReport counts results by status.
+func NewBulkReport(dryRun bool, results []BulkResult) BulkReport {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	return BulkReport{DryRun: dryRun, Total: len(results), Counts: counts, Results: results}
+}
+
+// BulkSaveFailed is the message of the rows of a batch that could not be
+// saved. The error of the batch is logged by the caller, not reported.
+const BulkSaveFailed = "the batch could not be saved"
+
+// BulkImport resolves the rows of a bulk import identified by their unique
+// keys. A row with a message in invalid, such as a reference to a record that
+// doesn't exist, is invalid; invalid may be nil when every row is valid. A
+// row whose key is in existing, or repeats the key of an earlier row, is a
+// conflict. In a dry run the other rows are valid; otherwise save is called
+// with them in batches of BulkBatchSize, given as indexes into keys, and
+// returns their ids in the same order. When a batch fails none of its rows
+// is stored and all of them are reported as failed.
+func BulkImport(keys []string, existing map[string]bool, invalid []string, dryRun bool, save func(rows []int) ([]int, error)) []BulkResult {
+	results := make([]BulkResult, len(keys))
+	seen := map[string]bool{}
+	var pending []int
+	for i, key := range keys {
+		switch {
+		case invalid != nil && invalid[i] != "":
+			results[i] = BulkResult{Status: BulkInvalid, Message: invalid[i]}
+			continue
+		case existing[key]:
+			results[i] = BulkResult{Status: BulkConflict, Message: "already exists: " + key}
+		case seen[key]:
+			results[i] = BulkResult{Status: BulkConflict, Message: "repeated in the import: " + key}
+		default:
+			results[i] = BulkResult{Status: BulkValid}
+			pending = append(pending, i)
+		}
+		seen[key] = true
+	}
+	if dryRun {
+		return results
+	}
+
+	for from := 0; from < len(pending); from += BulkBatchSize {
+		to := from + BulkBatchSize
+		if to > len(pending) {
+			to = len(pending)
+		}
+		batch := pending[from:to]
+		ids, err := save(batch)
+		for j, i := range batch {
+			if err != nil {
+				results[i] = BulkResult{Status: BulkFailed, Message: BulkSaveFailed}
+				continue
+			}
+			results[i] = BulkResult{Status: BulkCreated, ID: ids[j]}
+		}
+	}
+	return results
+}
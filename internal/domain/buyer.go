@@ -1,10 +1,11 @@
 package domain
 
 type Buyer struct {
-	ID           int    `json:"id"`
-	CardNumberID string `json:"card_number_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
+	ID           int     `json:"id"`
+	CardNumberID string  `json:"card_number_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	DeletedAt    *string `json:"deleted_at,omitempty"`
 }
 
 type BuyerOrders struct {
@@ -14,3 +15,46 @@ type BuyerOrders struct {
 	LastName            string `json:"last_name"`
 	PurchaseOrdersCount int    `json:"purchase_orders_count"`
 }
+
+// BuyerSpend sums up what a buyer has bought. LastOrderDate is nil for a
+// buyer without purchase orders.
+type BuyerSpend struct {
+	BuyerID           int     `json:"buyer_id"`
+	CardNumberID      string  `json:"card_number_id"`
+	FirstName         string  `json:"first_name"`
+	LastName          string  `json:"last_name"`
+	OrdersCount       int     `json:"orders_count"`
+	TotalSpent        float64 `json:"total_spent"`
+	AverageOrderValue float64 `json:"average_order_value"`
+	LastOrderDate     *string `json:"last_order_date"`
+}
+
+// BuyerHistory is the purchase history of a buyer, newest order first.
+type BuyerHistory struct {
+	BuyerSpend
+	Orders []BuyerOrder `json:"orders"`
+}
+
+// BuyerOrder is a purchase order of a buyer with its line items. Total is
+// the sum of the totals of the items.
+type BuyerOrder struct {
+	ID            int         `json:"id"`
+	OrderNumber   string      `json:"order_number"`
+	OrderDate     string      `json:"order_date"`
+	TrackingCode  string      `json:"tracking_code"`
+	OrderStatusID int         `json:"order_status_id"`
+	Status        string      `json:"status"`
+	Total         float64     `json:"total"`
+	Items         []OrderItem `json:"items"`
+}
+
+// OrderItem is a line of a purchase order, priced at the sale price of its
+// product record.
+type OrderItem struct {
+	ID              int     `json:"id"`
+	ProductRecordID int     `json:"product_record_id"`
+	ProductID       int     `json:"product_id"`
+	Quantity        int     `json:"quantity"`
+	UnitPrice       float64 `json:"unit_price"`
+	Total           float64 `json:"total"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// DateRange bounds a report in time. From and To are "YYYY-MM-DD hh:mm:ss"
+// bounds, both inclusive; an empty bound is open.
+type DateRange struct {
+	From string
+	To   string
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dependent counts the records of an entity that still reference another
+// entity.
+type Dependent struct {
+	Entity string `json:"entity"`
+	Count  int    `json:"count"`
+}
+
+// DependentsError is returned when an entity can't be deleted because other
+// records still reference it.
+type DependentsError struct {
+	Entity     string
+	ID         int
+	Dependents []Dependent
+}
+
+func (e *DependentsError) Error() string {
+	parts := make([]string, len(e.Dependents))
+	for i, d := range e.Dependents {
+		parts[i] = fmt.Sprintf("%d %s", d.Count, d.Entity)
+	}
+	return fmt.Sprintf("%s %d is still referenced by %s", e.Entity, e.ID, strings.Join(parts, ", "))
+}
+
+// NewDependents lists the entities with a non zero count, keeping the order
+// of entities.
+func NewDependents(entities []string, counts []int) []Dependent {
+	var dependents []Dependent
+	for i, entity := range entities {
+		if counts[i] > 0 {
+			dependents = append(dependents, Dependent{Entity: entity, Count: counts[i]})
+		}
+	}
+	return dependents
+}
@@ -1,11 +1,16 @@
 package domain
 
+// Employee works in a single warehouse. UserID links the employee to the
+// account it logs in with, when it has one.
 type Employee struct {
-	ID           int    `json:"id"`
-	CardNumberID string `json:"card_number_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	WarehouseID  int    `json:"warehouse_id"`
+	ID           int     `json:"id"`
+	CardNumberID string  `json:"card_number_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	WarehouseID  int     `json:"warehouse_id"`
+	UserID       *int    `json:"user_id,omitempty"`
+	Version      int     `json:"-"`
+	DeletedAt    *string `json:"deleted_at,omitempty"`
 }
 
 type EmployeeOrders struct {
@@ -0,0 +1,254 @@
+package domain
+
+import "encoding/json"
+
+// Event is a change to an entity that other services may react to. Each
+// event type carries the state of the entity after the change.
+type Event interface {
+	// EventType names the event, such as "seller.created".
+	EventType() string
+	// EventEntity returns the name and id of the entity the event is about.
+	EventEntity() (string, int)
+}
+
+// OutboxEvent is an Event stored in the outbox, in the transaction of the
+// change it reports, until it is delivered. Payload is the event as JSON.
+// Consumers may get an event more than once and tell repeats apart by ID.
+type OutboxEvent struct {
+	ID        int             `json:"id"`
+	Type      string          `json:"type"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Payload   json.RawMessage `json:"payload"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt string          `json:"created_at"`
+
+	Status        string `json:"-"`
+	Attempts      int    `json:"-"`
+	NextAttemptAt string `json:"-"`
+	LastError     string `json:"-"`
+}
+
+type SellerCreated struct {
+	Seller Seller `json:"seller"`
+}
+
+func (SellerCreated) EventType() string            { return "seller.created" }
+func (e SellerCreated) EventEntity() (string, int) { return "seller", e.Seller.ID }
+
+type SellerUpdated struct {
+	Seller Seller `json:"seller"`
+}
+
+func (SellerUpdated) EventType() string            { return "seller.updated" }
+func (e SellerUpdated) EventEntity() (string, int) { return "seller", e.Seller.ID }
+
+type SellerDeleted struct {
+	ID int `json:"id"`
+}
+
+func (SellerDeleted) EventType() string            { return "seller.deleted" }
+func (e SellerDeleted) EventEntity() (string, int) { return "seller", e.ID }
+
+type SellerRestored struct {
+	Seller Seller `json:"seller"`
+}
+
+func (SellerRestored) EventType() string            { return "seller.restored" }
+func (e SellerRestored) EventEntity() (string, int) { return "seller", e.Seller.ID }
+
+type BuyerCreated struct {
+	Buyer Buyer `json:"buyer"`
+}
+
+func (BuyerCreated) EventType() string            { return "buyer.created" }
+func (e BuyerCreated) EventEntity() (string, int) { return "buyer", e.Buyer.ID }
+
+type BuyerUpdated struct {
+	Buyer Buyer `json:"buyer"`
+}
+
+func (BuyerUpdated) EventType() string            { return "buyer.updated" }
+func (e BuyerUpdated) EventEntity() (string, int) { return "buyer", e.Buyer.ID }
+
+type BuyerDeleted struct {
+	ID int `json:"id"`
+}
+
+func (BuyerDeleted) EventType() string            { return "buyer.deleted" }
+func (e BuyerDeleted) EventEntity() (string, int) { return "buyer", e.ID }
+
+type BuyerRestored struct {
+	Buyer Buyer `json:"buyer"`
+}
+
+func (BuyerRestored) EventType() string            { return "buyer.restored" }
+func (e BuyerRestored) EventEntity() (string, int) { return "buyer", e.Buyer.ID }
+
+type ProductCreated struct {
+	Product Product `json:"product"`
+}
+
+func (ProductCreated) EventType() string            { return "product.created" }
+func (e ProductCreated) EventEntity() (string, int) { return "product", e.Product.ID }
+
+type ProductUpdated struct {
+	Product Product `json:"product"`
+}
+
+func (ProductUpdated) EventType() string            { return "product.updated" }
+func (e ProductUpdated) EventEntity() (string, int) { return "product", e.Product.ID }
+
+type ProductDeleted struct {
+	ID int `json:"id"`
+}
+
+func (ProductDeleted) EventType() string            { return "product.deleted" }
+func (e ProductDeleted) EventEntity() (string, int) { return "product", e.ID }
+
+type ProductRestored struct {
+	Product Product `json:"product"`
+}
+
+func (ProductRestored) EventType() string            { return "product.restored" }
+func (e ProductRestored) EventEntity() (string, int) { return "product", e.Product.ID }
+
+type SectionCreated struct {
+	Section Section `json:"section"`
+}
+
+func (SectionCreated) EventType() string            { return "section.created" }
+func (e SectionCreated) EventEntity() (string, int) { return "section", e.Section.ID }
+
+type SectionUpdated struct {
+	Section Section `json:"section"`
+}
+
+func (SectionUpdated) EventType() string            { return "section.updated" }
+func (e SectionUpdated) EventEntity() (string, int) { return "section", e.Section.ID }
+
+type SectionDeleted struct {
+	ID int `json:"id"`
+}
+
+func (SectionDeleted) EventType() string            { return "section.deleted" }
+func (e SectionDeleted) EventEntity() (string, int) { return "section", e.ID }
+
+type SectionRestored struct {
+	Section Section `json:"section"`
+}
+
+func (SectionRestored) EventType() string            { return "section.restored" }
+func (e SectionRestored) EventEntity() (string, int) { return "section", e.Section.ID }
+
+type WarehouseCreated struct {
+	Warehouse Warehouse `json:"warehouse"`
+}
+
+func (WarehouseCreated) EventType() string            { return "warehouse.created" }
+func (e WarehouseCreated) EventEntity() (string, int) { return "warehouse", e.Warehouse.ID }
+
+type WarehouseUpdated struct {
+	Warehouse Warehouse `json:"warehouse"`
+}
+
+func (WarehouseUpdated) EventType() string            { return "warehouse.updated" }
+func (e WarehouseUpdated) EventEntity() (string, int) { return "warehouse", e.Warehouse.ID }
+
+type WarehouseDeleted struct {
+	ID int `json:"id"`
+}
+
+func (WarehouseDeleted) EventType() string            { return "warehouse.deleted" }
+func (e WarehouseDeleted) EventEntity() (string, int) { return "warehouse", e.ID }
+
+type WarehouseRestored struct {
+	Warehouse Warehouse `json:"warehouse"`
+}
+
+func (WarehouseRestored) EventType() string            { return "warehouse.restored" }
+func (e WarehouseRestored) EventEntity() (string, int) { return "warehouse", e.Warehouse.ID }
+
+type EmployeeCreated struct {
+	Employee Employee `json:"employee"`
+}
+
+func (EmployeeCreated) EventType() string            { return "employee.created" }
+func (e EmployeeCreated) EventEntity() (string, int) { return "employee", e.Employee.ID }
+
+type EmployeeUpdated struct {
+	Employee Employee `json:"employee"`
+}
+
+func (EmployeeUpdated) EventType() string            { return "employee.updated" }
+func (e EmployeeUpdated) EventEntity() (string, int) { return "employee", e.Employee.ID }
+
+type EmployeeDeleted struct {
+	ID int `json:"id"`
+}
+
+func (EmployeeDeleted) EventType() string            { return "employee.deleted" }
+func (e EmployeeDeleted) EventEntity() (string, int) { return "employee", e.ID }
+
+type EmployeeRestored struct {
+	Employee Employee `json:"employee"`
+}
+
+func (EmployeeRestored) EventType() string            { return "employee.restored" }
+func (e EmployeeRestored) EventEntity() (string, int) { return "employee", e.Employee.ID }
+
+type LocalityCreated struct {
+	Locality Locality `json:"locality"`
+}
+
+func (LocalityCreated) EventType() string            { return "locality.created" }
+func (e LocalityCreated) EventEntity() (string, int) { return "locality", e.Locality.ID }
+
+type CarryCreated struct {
+	Carry Carry `json:"carry"`
+}
+
+func (CarryCreated) EventType() string            { return "carry.created" }
+func (e CarryCreated) EventEntity() (string, int) { return "carry", e.Carry.ID }
+
+// ProductBatchReceived reports a batch of a product stored in a section.
+type ProductBatchReceived struct {
+	ProductBatch ProductBatches `json:"product_batch"`
+}
+
+func (ProductBatchReceived) EventType() string { return "product_batch.received" }
+func (e ProductBatchReceived) EventEntity() (string, int) {
+	return "product_batch", e.ProductBatch.Id
+}
+
+// InboundOrderReceived reports a product batch taken in by a warehouse.
+type InboundOrderReceived struct {
+	InboundOrder InboundOrder `json:"inbound_order"`
+}
+
+func (InboundOrderReceived) EventType() string { return "inbound_order.received" }
+func (e InboundOrderReceived) EventEntity() (string, int) {
+	return "inbound_order", e.InboundOrder.ID
+}
+
+type PurchaseOrderCreated struct {
+	PurchaseOrder PurchaseOrders `json:"purchase_order"`
+}
+
+func (PurchaseOrderCreated) EventType() string { return "purchase_order.created" }
+func (e PurchaseOrderCreated) EventEntity() (string, int) {
+	return "purchase_order", e.PurchaseOrder.ID
+}
+
+// PurchaseOrderStatusChanged reports a purchase order moved from one order
+// status to another.
+type PurchaseOrderStatusChanged struct {
+	ID           int `json:"id"`
+	FromStatusID int `json:"from_status_id"`
+	ToStatusID   int `json:"to_status_id"`
+}
+
+func (PurchaseOrderStatusChanged) EventType() string { return "purchase_order.status_changed" }
+func (e PurchaseOrderStatusChanged) EventEntity() (string, int) {
+	return "purchase_order", e.ID
+}
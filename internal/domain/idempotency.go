@@ -0,0 +1,18 @@
+package domain
+
+// IdempotencyRecord stores the fingerprint of a request sent with an
+// Idempotency-Key header and, once it finished, the response it produced.
+// A StatusCode of zero means the original request is still being processed.
+type IdempotencyRecord struct {
+	// User is the name of the user who sent the key, empty when anonymous.
+	// Each user has keys of their own.
+	User         string
+	Key          string
+	Method       string
+	Path         string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	// CreatedAt is when the key was reserved, as "2006-01-02 15:04:05".
+	CreatedAt string
+}
@@ -0,0 +1,10 @@
+package domain
+
+// Page is one page of a list: Items holds at most PageSize entries, starting
+// at entry (Page-1)*PageSize of Total.
+type Page struct {
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+	Total    int         `json:"total"`
+	Items    interface{} `json:"items"`
+}
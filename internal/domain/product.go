@@ -14,4 +14,6 @@ type Product struct {
 	Width          float32 `json:"width"`
 	ProductTypeID  int     `json:"product_type_id"`
 	SellerID       int     `json:"seller_id"`
+	Version        int     `json:"-"`
+	DeletedAt      *string `json:"deleted_at,omitempty"`
 }
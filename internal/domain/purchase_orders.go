@@ -7,5 +7,6 @@ type PurchaseOrders struct {
 	TrackingCode    string `json:"tracking_code"`
 	BuyerId         int    `json:"buyer_id"`
 	ProductRecordId int    `json:"product_record_id"`
+	Quantity        int    `json:"quantity"`
 	OrderStatusId   int    `json:"order_status_id"`
 }
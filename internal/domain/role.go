@@ -0,0 +1,7 @@
+package domain
+
+type Role struct {
+	ID          int    `json:"id"`
+	Name        string `json:"rol_name"`
+	Description string `json:"description"`
+}
@@ -1,10 +1,24 @@
 package domain
 
 type Seller struct {
-	ID          int    `json:"id"`
-	CID         int    `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  int    `json:"locality_id"`
+	ID          int     `json:"id"`
+	CID         int     `json:"cid"`
+	CompanyName string  `json:"company_name"`
+	Address     string  `json:"address"`
+	Telephone   string  `json:"telephone"`
+	LocalityId  int     `json:"locality_id"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
+}
+
+// SellerReport sums up the catalog, stock and sales of a seller. Stock is the
+// current quantity of the batches of its products; PurchaseOrders and Revenue
+// only count orders placed within the requested DateRange.
+type SellerReport struct {
+	SellerID       int     `json:"seller_id"`
+	CID            int     `json:"cid"`
+	CompanyName    string  `json:"company_name"`
+	ProductsCount  int     `json:"products_count"`
+	Stock          int     `json:"stock"`
+	PurchaseOrders int     `json:"purchase_orders_count"`
+	Revenue        float64 `json:"revenue"`
 }
@@ -0,0 +1,9 @@
+package domain
+
+// User is an account allowed to call the API. Password holds the bcrypt hash
+// and is never serialized.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Password string `json:"-"`
+}
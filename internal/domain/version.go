@@ -0,0 +1,7 @@
+package domain
+
+import "errors"
+
+// ErrVersionConflict is returned by versioned updates when the record was
+// changed since it was read.
+var ErrVersionConflict = errors.New("the record was modified by another request")
@@ -1,10 +1,109 @@
 package domain
 
 type Warehouse struct {
-	ID                 int    `json:"id"`
-	Address            string `json:"address"`
-	Telephone          string `json:"telephone"`
-	WarehouseCode      string `json:"warehouse_code"`
-	MinimumCapacity    int    `json:"minimum_capacity"`
-	MinimumTemperature int    `json:"minimum_temperature"`
+	ID                 int     `json:"id"`
+	Address            string  `json:"address"`
+	Telephone          string  `json:"telephone"`
+	WarehouseCode      string  `json:"warehouse_code"`
+	MinimumCapacity    int     `json:"minimum_capacity"`
+	MinimumTemperature int     `json:"minimum_temperature"`
+	Version            int     `json:"-"`
+	DeletedAt          *string `json:"deleted_at,omitempty"`
+}
+
+// WarehouseUtilization reports how full a warehouse is. Capacity is the sum
+// of the maximum capacity of its sections and Used the current quantity of
+// the product batches stored in them.
+type WarehouseUtilization struct {
+	WarehouseID          int                  `json:"warehouse_id"`
+	WarehouseCode        string               `json:"warehouse_code"`
+	Capacity             int                  `json:"capacity"`
+	Used                 int                  `json:"used"`
+	Free                 int                  `json:"free"`
+	Utilization          float64              `json:"utilization"`
+	SectionsBelowMinimum []int                `json:"sections_below_minimum"`
+	SectionsAboveMaximum []int                `json:"sections_above_maximum"`
+	Sections             []SectionUtilization `json:"sections"`
+	ProductTypes         []ProductTypeMix     `json:"product_types"`
+}
+
+// SectionUtilization reports how full a section is. Utilization is the
+// fraction of the maximum capacity in use.
+type SectionUtilization struct {
+	WarehouseID     int     `json:"-"`
+	SectionID       int     `json:"section_id"`
+	SectionNumber   int     `json:"section_number"`
+	ProductTypeID   int     `json:"product_type_id"`
+	MinimumCapacity int     `json:"minimum_capacity"`
+	MaximumCapacity int     `json:"maximum_capacity"`
+	Used            int     `json:"used"`
+	Free            int     `json:"free"`
+	Utilization     float64 `json:"utilization"`
+	BelowMinimum    bool    `json:"below_minimum"`
+	AboveMaximum    bool    `json:"above_maximum"`
+}
+
+// ProductTypeMix is the quantity of a product type stored in a warehouse and
+// its share of everything stored there.
+type ProductTypeMix struct {
+	WarehouseID   int     `json:"-"`
+	ProductTypeID int     `json:"product_type_id"`
+	Description   string  `json:"description"`
+	Quantity      int     `json:"quantity"`
+	Share         float64 `json:"share"`
+}
+
+// NewWarehouseUtilization reports the utilization of w from the used
+// quantity of its sections and the quantity of each product type, filling
+// in the free space, ratios and capacity flags.
+func NewWarehouseUtilization(w Warehouse, sections []SectionUtilization, mix []ProductTypeMix) WarehouseUtilization {
+	u := WarehouseUtilization{
+		WarehouseID:          w.ID,
+		WarehouseCode:        w.WarehouseCode,
+		SectionsBelowMinimum: []int{},
+		SectionsAboveMaximum: []int{},
+		Sections:             []SectionUtilization{},
+		ProductTypes:         []ProductTypeMix{},
+	}
+	for _, s := range sections {
+		s.Free = freeSpace(s.MaximumCapacity, s.Used)
+		s.Utilization = ratio(s.Used, s.MaximumCapacity)
+		s.BelowMinimum = s.Used < s.MinimumCapacity
+		s.AboveMaximum = s.Used > s.MaximumCapacity
+		if s.BelowMinimum {
+			u.SectionsBelowMinimum = append(u.SectionsBelowMinimum, s.SectionID)
+		}
+		if s.AboveMaximum {
+			u.SectionsAboveMaximum = append(u.SectionsAboveMaximum, s.SectionID)
+		}
+		u.Capacity += s.MaximumCapacity
+		u.Used += s.Used
+		u.Sections = append(u.Sections, s)
+	}
+	u.Free = freeSpace(u.Capacity, u.Used)
+	u.Utilization = ratio(u.Used, u.Capacity)
+
+	var stored int
+	for _, m := range mix {
+		stored += m.Quantity
+	}
+	for _, m := range mix {
+		m.Share = ratio(m.Quantity, stored)
+		u.ProductTypes = append(u.ProductTypes, m)
+	}
+	return u
+}
+
+func freeSpace(capacity, used int) int {
+	if used > capacity {
+		return 0
+	}
+	return capacity - used
+}
+
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
 }
@@ -0,0 +1,42 @@
+package employee
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/cache"
+)
+
+// InboundOrdersCacheKey prefixes the cache keys of the inbound orders
+// reports, to drop them when the data they are computed from changes.
+const InboundOrdersCacheKey = "employee:report_inbound_orders:"
+
+// cachedService serves the inbound orders reports of the wrapped Service
+// from a cache.
+type cachedService struct {
+	Service
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedService wraps s so its inbound orders reports are kept in c for
+// ttl. They are not dropped on changes by themselves: whoever changes
+// employees or inbound orders must delete the keys under
+// InboundOrdersCacheKey.
+func NewCachedService(s Service, c cache.Cache, ttl time.Duration) Service {
+	return &cachedService{
+		Service: s,
+		cache:   c,
+		ttl:     ttl,
+	}
+}
+
+func (s *cachedService) GetInboundOrders(ctx context.Context, id int) (reports []domain.EmployeeOrders, err error) {
+	err = cache.Fetch(ctx, s.cache, InboundOrdersCacheKey+strconv.Itoa(id), s.ttl, &reports, func() error {
+		reports, err = s.Service.GetInboundOrders(ctx, id)
+		return err
+	})
+	return reports, err
+}
@@ -0,0 +1,74 @@
+package employee
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save, Update, Delete and Restore publish
+// EmployeeCreated, EmployeeUpdated, EmployeeDeleted and EmployeeRestored events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, em domain.Employee) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, em)
+		if err != nil {
+			return err
+		}
+		em.ID = id
+		return e.outbox.Publish(ctx, domain.EmployeeCreated{Employee: em})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return em.ID, nil
+}
+
+func (e *eventedService) Update(ctx context.Context, em domain.Employee) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Update(ctx, em); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, em.ID)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.EmployeeUpdated{Employee: after})
+	})
+}
+
+func (e *eventedService) Delete(ctx context.Context, id, version int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.EmployeeDeleted{ID: id})
+	})
+}
+
+func (e *eventedService) Restore(ctx context.Context, id int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Restore(ctx, id); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.EmployeeRestored{Employee: after})
+	})
+}
@@ -0,0 +1,223 @@
+// Package memory implements employee.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns an employee.Repository that keeps the employees in
+// db.
+func NewRepository(db *memdb.DB) employee.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the employee with id, or -1.
+func (r *repository) index(id int) int {
+	for i, e := range r.db.Employees {
+		if e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// userTaken tells whether an employee other than id is linked to userID,
+// which the unique key on user_id forbids.
+func (r *repository) userTaken(userID *int, id int) bool {
+	if userID == nil {
+		return false
+	}
+	for _, e := range r.db.Employees {
+		if e.ID != id && e.UserID != nil && *e.UserID == *userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error) {
+	var employees []domain.Employee
+	err := r.StreamAll(ctx, includeDeleted, func(e domain.Employee) error {
+		employees = append(employees, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error {
+	r.db.RLock()
+	var employees []domain.Employee
+	for _, e := range r.db.Employees {
+		if includeDeleted || e.DeletedAt == nil {
+			employees = append(employees, e)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, e := range employees {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Employees[i].DeletedAt != nil {
+		return domain.Employee{}, employee.ErrNotFound
+	}
+	return r.db.Employees[i], nil
+}
+
+func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, e := range r.db.Employees {
+		if e.CardNumberID == cardNumberID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if r.userTaken(e.UserID, 0) {
+		return 0, memdb.ErrDuplicate
+	}
+	e.ID = r.db.NextID("employees")
+	e.Version = 1
+	e.DeletedAt = nil
+	r.db.Employees = append(r.db.Employees, e)
+	return e.ID, nil
+}
+
+func (r *repository) Update(ctx context.Context, e domain.Employee) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(e.ID)
+	if i < 0 || r.db.Employees[i].Version != e.Version || r.db.Employees[i].DeletedAt != nil {
+		return domain.ErrVersionConflict
+	}
+	if r.userTaken(e.UserID, e.ID) {
+		return memdb.ErrDuplicate
+	}
+	e.Version++
+	r.db.Employees[i] = e
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Employees[i].DeletedAt != nil {
+		return employee.ErrNotFound
+	}
+	if version != 0 && r.db.Employees[i].Version != version {
+		return domain.ErrVersionConflict
+	}
+	now := r.db.Now()
+	r.db.Employees[i].DeletedAt = &now
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Employees[i].DeletedAt == nil {
+		return employee.ErrNotFound
+	}
+	r.db.Employees[i].DeletedAt = nil
+	return nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID int) (domain.Employee, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, e := range r.db.Employees {
+		if e.UserID != nil && *e.UserID == userID && e.DeletedAt == nil {
+			return e, nil
+		}
+	}
+	return domain.Employee{}, employee.ErrNotFound
+}
+
+// orders counts the inbound orders of the employee e.
+func (r *repository) orders(e domain.Employee) domain.EmployeeOrders {
+	eo := domain.EmployeeOrders{ID: e.ID, CardNumberID: e.CardNumberID, FirstName: e.FirstName, LastName: e.LastName, WarehouseID: e.WarehouseID}
+	for _, io := range r.db.InboundOrders {
+		if io.EmployeeID == e.ID {
+			eo.InboundOrdersCount++
+		}
+	}
+	return eo
+}
+
+func (r *repository) GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error) {
+	if id == 0 {
+		var inboundOrders []domain.EmployeeOrders
+		err := r.StreamInboundOrders(ctx, func(e domain.EmployeeOrders) error {
+			inboundOrders = append(inboundOrders, e)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return inboundOrders, nil
+	}
+
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Employees[i].DeletedAt != nil {
+		return nil, employee.ErrNotFound
+	}
+	return []domain.EmployeeOrders{r.orders(r.db.Employees[i])}, nil
+}
+
+func (r *repository) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error {
+	r.db.RLock()
+	var inboundOrders []domain.EmployeeOrders
+	for _, e := range r.db.Employees {
+		if e.DeletedAt == nil {
+			inboundOrders = append(inboundOrders, r.orders(e))
+		}
+	}
+	r.db.RUnlock()
+
+	for _, eo := range inboundOrders {
+		if err := fn(eo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
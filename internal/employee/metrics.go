@@ -0,0 +1,80 @@
+package employee
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "employee".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("employee"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (m *measuredRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error {
+	defer m.timer.Since("StreamAll", time.Now())
+	return m.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Employee, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, cardNumberID string) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, cardNumberID)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, e domain.Employee) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, e)
+}
+
+func (m *measuredRepository) Update(ctx context.Context, e domain.Employee) error {
+	defer m.timer.Since("Update", time.Now())
+	return m.Repository.Update(ctx, e)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, id, version int) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, id, version)
+}
+
+func (m *measuredRepository) Restore(ctx context.Context, id int) error {
+	defer m.timer.Since("Restore", time.Now())
+	return m.Repository.Restore(ctx, id)
+}
+
+func (m *measuredRepository) GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error) {
+	defer m.timer.Since("GetInboundOrders", time.Now())
+	return m.Repository.GetInboundOrders(ctx, id)
+}
+
+func (m *measuredRepository) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error {
+	defer m.timer.Since("StreamInboundOrders", time.Now())
+	return m.Repository.StreamInboundOrders(ctx, fn)
+}
+
+func (m *measuredRepository) GetByUserID(ctx context.Context, userID int) (domain.Employee, error) {
+	defer m.timer.Since("GetByUserID", time.Now())
+	return m.Repository.GetByUserID(ctx, userID)
+}
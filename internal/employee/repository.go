@@ -3,56 +3,90 @@ package employee
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"log"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a employee.
 type Repository interface {
-	GetAll(ctx context.Context) ([]domain.Employee, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error
 	Get(ctx context.Context, id int) (domain.Employee, error)
 	Exists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, e domain.Employee) (int, error)
 	Update(ctx context.Context, e domain.Employee) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
 	GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error)
+	StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error
+	GetByUserID(ctx context.Context, userID int) (domain.Employee, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	query := "SELECT * FROM employees"
-	rows, err := r.db.Query(query)
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error) {
+	var employees []domain.Employee
+	err := r.StreamAll(ctx, includeDeleted, func(e domain.Employee) error {
+		employees = append(employees, e)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var employees []domain.Employee
+	return employees, nil
+}
+
+// StreamAll calls fn with each employee as it is read from the database,
+// stopping at the first error fn returns.
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error {
+	query := queries.EmployeeGetAllQuery
+	if !includeDeleted {
+		query += queries.EmployeeNotDeleted
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		e := domain.Employee{}
-		_ = rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
-		employees = append(employees, e)
+		if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.UserID, &e.Version, &e.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
 	}
 
-	return employees, nil
+	return rows.Err()
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
-	query := "SELECT * FROM employees WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeGetQuery)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
 	e := domain.Employee{}
-	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	err = row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.UserID, &e.Version, &e.DeletedAt)
 	if err != nil {
 		return domain.Employee{}, err
 	}
@@ -61,20 +95,22 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
-	query := "SELECT card_number_id FROM employees WHERE card_number_id=?;"
-	row := r.db.QueryRow(query, cardNumberID)
-	err := row.Scan(&cardNumberID)
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, cardNumberID)
+	err = row.Scan(&cardNumberID)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
-	query := "INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeSaveQuery)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	res, err := stmt.ExecContext(ctx, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, e.UserID)
 	if err != nil {
 		return 0, err
 	}
@@ -88,33 +124,41 @@ func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, e domain.Employee) error {
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeUpdateQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&e.FirstName, &e.LastName, &e.WarehouseID, &e.ID)
+	res, err := stmt.ExecContext(ctx, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, e.UserID, &e.ID, &e.Version)
 	if err != nil {
 		return err
 	}
 
-	_, err = res.RowsAffected()
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return domain.ErrVersionConflict
+	}
+
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM employees WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+// Delete soft deletes the employee. A version other than 0 deletes it only at
+// that version, returning domain.ErrVersionConflict otherwise.
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	query, args := queries.EmployeeDeleteQuery, []interface{}{id}
+	if version != 0 {
+		query, args = queries.EmployeeDeleteVersionQuery, []interface{}{id, version}
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
 		return err
 	}
@@ -124,6 +168,9 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 		return err
 	}
 
+	if affect < 1 && version != 0 {
+		return domain.ErrVersionConflict
+	}
 	if affect < 1 {
 		return ErrNotFound
 	}
@@ -131,17 +178,29 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+func (r *repository) GetByUserID(ctx context.Context, userID int) (domain.Employee, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeGetByUserIDQuery)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	row := stmt.QueryRowContext(ctx, userID)
+	e := domain.Employee{}
+	err = row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.UserID, &e.Version, &e.DeletedAt)
+	if err == sql.ErrNoRows {
+		return domain.Employee{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Employee{}, err
+	}
+
+	return e, nil
+}
+
 func (r *repository) GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error) {
 	var inboundOrders []domain.EmployeeOrders
 
-	log.Println(id)
-
 	if id != 0 {
-		query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(io.employe_id) FROM employees AS e " +
-			"LEFT JOIN inbound_orders AS io " +
-			"ON e.id=io.employe_id WHERE e.id=? GROUP BY e.id;"
-
-		stmt, err := r.db.PrepareContext(ctx, query)
+		stmt, err := r.stmts.Prepare(ctx, queries.EmployeeInboundOrdersQuery)
 		if err != nil {
 			return nil, err
 		}
@@ -149,7 +208,9 @@ func (r *repository) GetInboundOrders(ctx context.Context, id int) ([]domain.Emp
 		e := domain.EmployeeOrders{}
 		row := stmt.QueryRowContext(ctx, id)
 		if err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.InboundOrdersCount); err != nil {
-			fmt.Println(err)
+			if err == sql.ErrNoRows {
+				return nil, ErrNotFound
+			}
 			return nil, err
 		}
 
@@ -158,21 +219,63 @@ func (r *repository) GetInboundOrders(ctx context.Context, id int) ([]domain.Emp
 
 	} else {
 
-		query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(io.employe_id) FROM employees AS e LEFT JOIN inbound_orders AS io ON io.employe_id=e.id GROUP BY e.id;"
-		rows, err := r.db.QueryContext(ctx, query)
-
+		err := r.StreamInboundOrders(ctx, func(e domain.EmployeeOrders) error {
+			inboundOrders = append(inboundOrders, e)
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
+		return inboundOrders, nil
+	}
 
-		for rows.Next() {
-			e := domain.EmployeeOrders{}
-			if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.InboundOrdersCount); err != nil {
-				return nil, err
-			}
-			inboundOrders = append(inboundOrders, e)
+}
+
+// StreamInboundOrders calls fn with the inbound orders count of each employee
+// as it is read from the database, stopping at the first error fn returns.
+func (r *repository) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeAllInboundOrdersQuery)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := domain.EmployeeOrders{}
+		if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.InboundOrdersCount); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
 		}
-		return inboundOrders, nil
 	}
 
+	return rows.Err()
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.EmployeeRestoreQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
 }
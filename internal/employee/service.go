@@ -13,13 +13,17 @@ var (
 )
 
 type Service interface {
-	GetAll(ctx context.Context) ([]domain.Employee, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error
 	Get(ctx context.Context, id int) (domain.Employee, error)
 	Exists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, e domain.Employee) (int, error)
 	Update(ctx context.Context, e domain.Employee) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
 	GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error)
+	StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error
+	GetByUserID(ctx context.Context, userID int) (domain.Employee, error)
 }
 
 type service struct {
@@ -30,8 +34,12 @@ func NewService(r Repository) Service {
 	return &service{repository: r}
 }
 
-func (s *service) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	return s.repository.GetAll(ctx)
+func (s *service) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error) {
+	return s.repository.GetAll(ctx, includeDeleted)
+}
+
+func (s *service) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error {
+	return s.repository.StreamAll(ctx, includeDeleted, fn)
 }
 
 func (s *service) Get(ctx context.Context, id int) (domain.Employee, error) {
@@ -50,10 +58,26 @@ func (s *service) Update(ctx context.Context, e domain.Employee) error {
 	return s.repository.Update(ctx, e)
 }
 
-func (s *service) Delete(ctx context.Context, id int) error {
-	return s.repository.Delete(ctx, id)
+func (s *service) Delete(ctx context.Context, id, version int) error {
+	return s.repository.Delete(ctx, id, version)
 }
 
 func (s *service) GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error) {
 	return s.repository.GetInboundOrders(ctx, id)
 }
+
+func (s *service) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error {
+	return s.repository.StreamInboundOrders(ctx, fn)
+}
+
+// GetByUserID returns the employee linked to the user, or ErrNotFound when
+// the user is not an employee.
+func (s *service) GetByUserID(ctx context.Context, userID int) (domain.Employee, error) {
+	return s.repository.GetByUserID(ctx, userID)
+}
+
+// Restore brings back a deleted employee. It returns ErrNotFound when the employee
+// does not exist or is not deleted.
+func (s *service) Restore(ctx context.Context, id int) error {
+	return s.repository.Restore(ctx, id)
+}
@@ -49,7 +49,7 @@ func TestFindAllEmployee(t *testing.T) {
 	s := createService()
 
 	ctx := context.TODO()
-	res, err := s.GetAll(ctx)
+	res, err := s.GetAll(ctx, false)
 	
 	//Sin error esperado
 	assert.Nil(t, err)
@@ -93,7 +93,7 @@ func TestUpdateExistentEmployee(t *testing.T) {
 
 	ctx := context.TODO()
 	err := s.Update(ctx, mocks.MockUpdateEmployee)
-  	data, _ := s.GetAll(ctx)
+  	data, _ := s.GetAll(ctx, false)
 
 	assert.Nil(t, err)
 	assert.Equal(t, data[1], mocks.MockUpdateEmployee)
@@ -115,7 +115,7 @@ func TestDeleteNonExistentEmployee(t *testing.T) {
 
 	ctx := context.TODO()
 	idDelete := 8
-	err := s.Delete(ctx, idDelete)
+	err := s.Delete(ctx, idDelete, 0)
 
 	assert.NotNil(t, err)
 }
@@ -126,8 +126,8 @@ func TestDeleteOkEmployee(t *testing.T) {
 
 	ctx := context.TODO()
 	idDelete := 1
-	err := s.Delete(ctx, idDelete)
-	verificationConsult, _ := s.GetAll(ctx)
+	err := s.Delete(ctx, idDelete, 0)
+	verificationConsult, _ := s.GetAll(ctx, false)
 
 	assert.Nil(t, err)
 	assert.NotEqual(t, len(mocks.MockEmployees), len(verificationConsult))
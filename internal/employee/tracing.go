@@ -0,0 +1,170 @@
+package employee
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "employee.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("employee.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Employee, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.Employee, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Exists(ctx context.Context, cardNumberID string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Service.Exists(ctx, cardNumberID)
+}
+
+func (t *tracedService) Save(ctx context.Context, e domain.Employee) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, e)
+}
+
+func (t *tracedService) Update(ctx context.Context, e domain.Employee) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Update(ctx, e)
+}
+
+func (t *tracedService) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Delete(ctx, id, version)
+}
+
+func (t *tracedService) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Restore(ctx, id)
+}
+
+func (t *tracedService) GetInboundOrders(ctx context.Context, id int) (_ []domain.EmployeeOrders, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetInboundOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetInboundOrders(ctx, id)
+}
+
+func (t *tracedService) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamInboundOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamInboundOrders(ctx, fn)
+}
+
+func (t *tracedService) GetByUserID(ctx context.Context, userID int) (_ domain.Employee, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetByUserID")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetByUserID(ctx, userID)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "employee.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("employee.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Employee, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Employee, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, cardNumberID string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, cardNumberID)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, e domain.Employee) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, e)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, e domain.Employee) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Update(ctx, e)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, id, version)
+}
+
+func (t *tracedRepository) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Restore(ctx, id)
+}
+
+func (t *tracedRepository) GetInboundOrders(ctx context.Context, id int) (_ []domain.EmployeeOrders, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetInboundOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetInboundOrders(ctx, id)
+}
+
+func (t *tracedRepository) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamInboundOrders")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamInboundOrders(ctx, fn)
+}
+
+func (t *tracedRepository) GetByUserID(ctx context.Context, userID int) (_ domain.Employee, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetByUserID")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetByUserID(ctx, userID)
+}
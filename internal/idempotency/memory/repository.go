@@ -0,0 +1,86 @@
+// Package memory implements idempotency.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+// timeLayout is the layout of the created_at column of idempotency_keys.
+const timeLayout = "2006-01-02 15:04:05"
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns an idempotency.Repository that keeps the records in
+// db.
+func NewRepository(db *memdb.DB) idempotency.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Get(ctx context.Context, user, key string) (domain.IdempotencyRecord, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	rec, ok := r.db.IdempotencyKeys[memdb.IdempotencyKey{User: user, Key: key}]
+	if !ok {
+		return domain.IdempotencyRecord{}, idempotency.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (r *repository) Reserve(ctx context.Context, rec domain.IdempotencyRecord) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	id := memdb.IdempotencyKey{User: rec.User, Key: rec.Key}
+	if _, ok := r.db.IdempotencyKeys[id]; ok {
+		return idempotency.ErrKeyExists
+	}
+	rec.StatusCode, rec.ResponseBody = 0, nil
+	rec.CreatedAt = time.Now().Format(timeLayout)
+	r.db.IdempotencyKeys[id] = rec
+	return nil
+}
+
+func (r *repository) Complete(ctx context.Context, rec domain.IdempotencyRecord) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	id := memdb.IdempotencyKey{User: rec.User, Key: rec.Key}
+	stored, ok := r.db.IdempotencyKeys[id]
+	if !ok {
+		return idempotency.ErrNotFound
+	}
+	stored.StatusCode, stored.ResponseBody = rec.StatusCode, rec.ResponseBody
+	r.db.IdempotencyKeys[id] = stored
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, user, key string) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	delete(r.db.IdempotencyKeys, memdb.IdempotencyKey{User: user, Key: key})
+	return nil
+}
+
+func (r *repository) DeleteExpired(ctx context.Context, before string) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	for id, rec := range r.db.IdempotencyKeys {
+		if rec.CreatedAt < before {
+			delete(r.db.IdempotencyKeys, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped
+// Repository takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "idempotency".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("idempotency"),
+	}
+}
+
+func (m *measuredRepository) Get(ctx context.Context, user, key string) (domain.IdempotencyRecord, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, user, key)
+}
+
+func (m *measuredRepository) Reserve(ctx context.Context, rec domain.IdempotencyRecord) error {
+	defer m.timer.Since("Reserve", time.Now())
+	return m.Repository.Reserve(ctx, rec)
+}
+
+func (m *measuredRepository) Complete(ctx context.Context, rec domain.IdempotencyRecord) error {
+	defer m.timer.Since("Complete", time.Now())
+	return m.Repository.Complete(ctx, rec)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, user, key string) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, user, key)
+}
+
+func (m *measuredRepository) DeleteExpired(ctx context.Context, before string) error {
+	defer m.timer.Since("DeleteExpired", time.Now())
+	return m.Repository.DeleteExpired(ctx, before)
+}
@@ -0,0 +1,114 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
+)
+
+// Repository encapsulates the storage of idempotency records.
+type Repository interface {
+	// Get and Delete find a record by the user who sent the key and the key.
+	Get(ctx context.Context, user, key string) (domain.IdempotencyRecord, error)
+	Reserve(ctx context.Context, rec domain.IdempotencyRecord) error
+	Complete(ctx context.Context, rec domain.IdempotencyRecord) error
+	Delete(ctx context.Context, user, key string) error
+	// DeleteExpired removes the records reserved before the given time,
+	// written as "2006-01-02 15:04:05".
+	DeleteExpired(ctx context.Context, before string) error
+}
+
+type repository struct {
+	db    *sql.DB
+	stmts *storage.Statements
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db:    db,
+		stmts: storage.StatementsOf(db),
+	}
+}
+
+func (r *repository) Get(ctx context.Context, user, key string) (domain.IdempotencyRecord, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.IdempotencyGetQuery)
+	if err != nil {
+		return domain.IdempotencyRecord{}, err
+	}
+	row := stmt.QueryRowContext(ctx, user, key)
+	rec := domain.IdempotencyRecord{}
+	err = row.Scan(&rec.User, &rec.Key, &rec.Method, &rec.Path, &rec.RequestHash, &rec.StatusCode, &rec.ResponseBody)
+	if err == sql.ErrNoRows {
+		return domain.IdempotencyRecord{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.IdempotencyRecord{}, err
+	}
+
+	return rec, nil
+}
+
+// Reserve inserts the record as in progress. It fails with ErrKeyExists when
+// another request of the same user already claimed the same key.
+func (r *repository) Reserve(ctx context.Context, rec domain.IdempotencyRecord) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.IdempotencyReserveQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, rec.User, rec.Key, rec.Method, rec.Path, rec.RequestHash)
+	if err != nil {
+		if storage.IsDuplicate(err) {
+			return ErrKeyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) Complete(ctx context.Context, rec domain.IdempotencyRecord) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.IdempotencyCompleteQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, rec.StatusCode, rec.ResponseBody, rec.User, rec.Key)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, user, key string) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.IdempotencyDeleteQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, user, key)
+	return err
+}
+
+func (r *repository) DeleteExpired(ctx context.Context, before string) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.IdempotencyExpireQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, before)
+	return err
+}
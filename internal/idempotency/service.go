@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+)
+
+const (
+	// timeLayout is the layout of the created_at column of idempotency_keys.
+	timeLayout = "2006-01-02 15:04:05"
+	// sweepInterval is how often the expired keys are deleted.
+	sweepInterval = time.Minute
+)
+
+// Errors
+var (
+	ErrNotFound   = errors.New("idempotency key not found")
+	ErrKeyExists  = errors.New("idempotency key already exists")
+	ErrKeyReused  = errors.New("idempotency key already used with a different request")
+	ErrInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+type Service interface {
+	Begin(ctx context.Context, rec domain.IdempotencyRecord) (domain.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, rec domain.IdempotencyRecord) error
+	Release(ctx context.Context, user, key string) error
+}
+
+type service struct {
+	repository Repository
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewService returns a Service keeping the keys in r for ttl, after which
+// they may be used again for any request. A zero ttl keeps them forever.
+func NewService(r Repository, ttl time.Duration) Service {
+	return &service{
+		repository: r,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// Begin claims rec.Key of rec.User for a new request. When the user sent the
+// key before it returns the stored record and true so the caller can replay
+// its response, or ErrKeyReused / ErrInProgress when it must not be replayed.
+func (s *service) Begin(ctx context.Context, rec domain.IdempotencyRecord) (domain.IdempotencyRecord, bool, error) {
+	s.sweep(ctx)
+	err := s.repository.Reserve(ctx, rec)
+	if err == nil {
+		return rec, false, nil
+	}
+	if !errors.Is(err, ErrKeyExists) {
+		return domain.IdempotencyRecord{}, false, err
+	}
+
+	stored, err := s.repository.Get(ctx, rec.User, rec.Key)
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	if stored.RequestHash != rec.RequestHash {
+		return domain.IdempotencyRecord{}, false, ErrKeyReused
+	}
+	if stored.StatusCode == 0 {
+		return domain.IdempotencyRecord{}, false, ErrInProgress
+	}
+
+	return stored, true, nil
+}
+
+// Complete stores the response produced for a reserved key.
+func (s *service) Complete(ctx context.Context, rec domain.IdempotencyRecord) error {
+	return s.repository.Complete(ctx, rec)
+}
+
+// Release frees a key the user reserved so the request can be retried.
+func (s *service) Release(ctx context.Context, user, key string) error {
+	return s.repository.Delete(ctx, user, key)
+}
+
+// sweep deletes, at most once per sweepInterval, the keys older than the
+// ttl. A failed sweep is only logged, as it is tried again later.
+func (s *service) sweep(ctx context.Context) {
+	if s.ttl <= 0 {
+		return
+	}
+	now := s.now()
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	before := now.Add(-s.ttl).Format(timeLayout)
+	if err := s.repository.DeleteExpired(ctx, before); err != nil {
+		web.Logger(ctx).WarnContext(ctx, "deleting expired idempotency keys failed", "error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "idempotency.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("idempotency.Service"),
+	}
+}
+
+func (t *tracedService) Begin(ctx context.Context, rec domain.IdempotencyRecord) (_ domain.IdempotencyRecord, _ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "Begin")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Begin(ctx, rec)
+}
+
+func (t *tracedService) Complete(ctx context.Context, rec domain.IdempotencyRecord) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Complete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Complete(ctx, rec)
+}
+
+func (t *tracedService) Release(ctx context.Context, user, key string) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Release")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Release(ctx, user, key)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "idempotency.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("idempotency.Repository"),
+	}
+}
+
+func (t *tracedRepository) Get(ctx context.Context, user, key string) (_ domain.IdempotencyRecord, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, user, key)
+}
+
+func (t *tracedRepository) Reserve(ctx context.Context, rec domain.IdempotencyRecord) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Reserve")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Reserve(ctx, rec)
+}
+
+func (t *tracedRepository) Complete(ctx context.Context, rec domain.IdempotencyRecord) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Complete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Complete(ctx, rec)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, user, key string) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, user, key)
+}
+
+func (t *tracedRepository) DeleteExpired(ctx context.Context, before string) (err error) {
+	ctx, span := t.tracer.Start(ctx, "DeleteExpired")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.DeleteExpired(ctx, before)
+}
@@ -0,0 +1,38 @@
+package inboundorder
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save publishes InboundOrderReceived events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, i domain.InboundOrder) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, i)
+		if err != nil {
+			return err
+		}
+		i.ID = id
+		return e.outbox.Publish(ctx, domain.InboundOrderReceived{InboundOrder: i})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return i.ID, nil
+}
@@ -0,0 +1,53 @@
+// Package memory implements inboundorder.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	inboundorder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/inbound_order"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns an inboundorder.Repository that keeps the inbound
+// orders in db.
+func NewRepository(db *memdb.DB) inboundorder.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Exists(ctx context.Context, employeeID int) (bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.exists(employeeID), nil
+}
+
+func (r *repository) exists(employeeID int) bool {
+	for _, e := range r.db.Employees {
+		if e.ID == employeeID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) Save(ctx context.Context, i domain.InboundOrder) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if !r.exists(i.EmployeeID) {
+		return 0, fmt.Errorf("error. The employee with the id: %v, not exists", i.EmployeeID)
+	}
+	i.ID = r.db.NextID("inbound_orders")
+	i.OrderDate = memdb.Datetime(i.OrderDate)
+	r.db.InboundOrders = append(r.db.InboundOrders, i)
+	return i.ID, nil
+}
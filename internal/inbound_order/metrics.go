@@ -0,0 +1,35 @@
+package inboundorder
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped
+// Repository takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "inbound_order".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("inbound_order"),
+	}
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, employeeID int) (bool, error) {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, employeeID)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, i domain.InboundOrder) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, i)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates a repository interface
@@ -15,23 +17,23 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 // NewRepository creates a new repository
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
 func (r *repository) Exists(ctx context.Context, employeeID int) (bool, error) {
-	query := "SELECT id FROM employees WHERE id=?;"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.InboundOrderEmployeeExistsQuery)
 	if err != nil {
 		return false, err
 	}
-	defer stmt.Close()
 
 	row := stmt.QueryRowContext(ctx, employeeID)
 	err = row.Scan(&employeeID)
@@ -46,12 +48,10 @@ func (r *repository) Save(ctx context.Context, i domain.InboundOrder) (int, erro
 		return 0, fmt.Errorf("error. The employee with the id: %v, not exists", i.EmployeeID)
 	}
 
-	query := "INSERT INTO inbound_orders(order_date, order_number, employe_id, product_batch_id, wareHouse_id) VALUES (?,?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.InboundOrderSaveQuery)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
 
 	res, err := stmt.ExecContext(ctx, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID)
 	if err != nil {
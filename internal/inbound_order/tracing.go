@@ -0,0 +1,62 @@
+package inboundorder
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "inbound_order.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("inbound_order.Service"),
+	}
+}
+
+func (t *tracedService) Save(ctx context.Context, i domain.InboundOrder) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, i)
+}
+
+func (t *tracedService) Exists(ctx context.Context, employeeID int) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Exists(ctx, employeeID)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "inbound_order.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("inbound_order.Repository"),
+	}
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, employeeID int) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Exists(ctx, employeeID)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, i domain.InboundOrder) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, i)
+}
@@ -0,0 +1,33 @@
+package locality
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "locality"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that SaveLocality is recorded in the audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) SaveLocality(ctx context.Context, l domain.Locality) (int, error) {
+	id, err := a.Service.SaveLocality(ctx, l)
+	if err != nil {
+		return id, err
+	}
+	l.ID = id
+	a.audit.Record(ctx, auditEntity, id, audit.ActionCreate, nil, l)
+	return id, nil
+}
@@ -0,0 +1,69 @@
+package locality
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/cache"
+)
+
+// Prefixes of the cache keys of the reports, to drop them when the data they
+// are computed from changes.
+const (
+	SellerReportsCacheKey = "locality:report_sellers:"
+	CarryReportsCacheKey  = "locality:report_carries:"
+)
+
+// cachedService serves the seller and carry reports of the wrapped Service
+// from a cache.
+type cachedService struct {
+	Service
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedService wraps s so its seller and carry reports are kept in c for
+// ttl. They are not dropped on changes by themselves: whoever changes
+// sellers, carries or localities must delete the keys under
+// SellerReportsCacheKey and CarryReportsCacheKey.
+func NewCachedService(s Service, c cache.Cache, ttl time.Duration) Service {
+	return &cachedService{
+		Service: s,
+		cache:   c,
+		ttl:     ttl,
+	}
+}
+
+func (s *cachedService) SellerReport(ctx context.Context, id int) (report domain.ReportSeller, err error) {
+	err = cache.Fetch(ctx, s.cache, SellerReportsCacheKey+strconv.Itoa(id), s.ttl, &report, func() error {
+		report, err = s.Service.SellerReport(ctx, id)
+		return err
+	})
+	return report, err
+}
+
+func (s *cachedService) GetAllSellerReports(ctx context.Context) (reports []domain.ReportSeller, err error) {
+	err = cache.Fetch(ctx, s.cache, SellerReportsCacheKey+"all", s.ttl, &reports, func() error {
+		reports, err = s.Service.GetAllSellerReports(ctx)
+		return err
+	})
+	return reports, err
+}
+
+func (s *cachedService) GetCarryReport(ctx context.Context, id int) (report domain.LocalityCarries, err error) {
+	err = cache.Fetch(ctx, s.cache, CarryReportsCacheKey+strconv.Itoa(id), s.ttl, &report, func() error {
+		report, err = s.Service.GetCarryReport(ctx, id)
+		return err
+	})
+	return report, err
+}
+
+func (s *cachedService) GetAllCarryReports(ctx context.Context) (reports []domain.LocalityCarries, err error) {
+	err = cache.Fetch(ctx, s.cache, CarryReportsCacheKey+"all", s.ttl, &reports, func() error {
+		reports, err = s.Service.GetAllCarryReports(ctx)
+		return err
+	})
+	return reports, err
+}
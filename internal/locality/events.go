@@ -0,0 +1,39 @@
+package locality
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that SaveLocality publishes LocalityCreated
+// events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) SaveLocality(ctx context.Context, l domain.Locality) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.SaveLocality(ctx, l)
+		if err != nil {
+			return err
+		}
+		l.ID = id
+		return e.outbox.Publish(ctx, domain.LocalityCreated{Locality: l})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return l.ID, nil
+}
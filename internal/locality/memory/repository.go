@@ -0,0 +1,160 @@
+// Package memory implements locality.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/locality"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a locality.Repository that keeps the localities in
+// db.
+func NewRepository(db *memdb.DB) locality.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the locality with id, or -1.
+func (r *repository) index(id int) int {
+	for i, l := range r.db.Localities {
+		if l.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// SaveLocality inserts l with its own id, or the next one when it is 0 as
+// the auto increment column does.
+func (r *repository) SaveLocality(ctx context.Context, l domain.Locality) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if r.index(l.ID) >= 0 {
+		return 0, fmt.Errorf("locality with id %v already exists", l.ID)
+	}
+	if l.ID == 0 {
+		l.ID = r.db.NextID("localities")
+	} else {
+		r.db.UseID("localities", l.ID)
+	}
+	r.db.Localities = append(r.db.Localities, l)
+	return l.ID, nil
+}
+
+func (r *repository) IDExist(ctx context.Context, id int) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.index(id) >= 0
+}
+
+func (r *repository) sellerReport(l domain.Locality) domain.ReportSeller {
+	rs := domain.ReportSeller{LocalityID: l.ID, LocalityName: l.LocalityName}
+	for _, s := range r.db.Sellers {
+		if s.LocalityId == l.ID && s.DeletedAt == nil {
+			rs.SellersCount++
+		}
+	}
+	return rs
+}
+
+func (r *repository) SellerReport(ctx context.Context, id int) (domain.ReportSeller, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 {
+		return domain.ReportSeller{}, errors.New("seller not found")
+	}
+	return r.sellerReport(r.db.Localities[i]), nil
+}
+
+func (r *repository) GetAllSellerReports(ctx context.Context) ([]domain.ReportSeller, error) {
+	var lcs []domain.ReportSeller
+	err := r.StreamSellerReports(ctx, func(lc domain.ReportSeller) error {
+		lcs = append(lcs, lc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lcs, nil
+}
+
+func (r *repository) StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) error {
+	r.db.RLock()
+	reports := make([]domain.ReportSeller, 0, len(r.db.Localities))
+	for _, l := range r.db.Localities {
+		reports = append(reports, r.sellerReport(l))
+	}
+	r.db.RUnlock()
+
+	for _, lc := range reports {
+		if err := fn(lc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) carryReport(l domain.Locality) domain.LocalityCarries {
+	lc := domain.LocalityCarries{LocalityID: l.ID, LocalityName: l.LocalityName}
+	for _, c := range r.db.Carries {
+		if c.LocalityID == l.ID {
+			lc.CarriesCount++
+		}
+	}
+	return lc
+}
+
+func (r *repository) GetCarryReport(ctx context.Context, id int) (domain.LocalityCarries, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 {
+		return domain.LocalityCarries{}, errors.New("locality not found")
+	}
+	return r.carryReport(r.db.Localities[i]), nil
+}
+
+func (r *repository) GetAllCarryReports(ctx context.Context) ([]domain.LocalityCarries, error) {
+	var lcs []domain.LocalityCarries
+	err := r.StreamCarryReports(ctx, func(lc domain.LocalityCarries) error {
+		lcs = append(lcs, lc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lcs, nil
+}
+
+func (r *repository) StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) error {
+	r.db.RLock()
+	reports := make([]domain.LocalityCarries, 0, len(r.db.Localities))
+	for _, l := range r.db.Localities {
+		reports = append(reports, r.carryReport(l))
+	}
+	r.db.RUnlock()
+
+	for _, lc := range reports {
+		if err := fn(lc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package locality
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "locality".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("locality"),
+	}
+}
+
+func (m *measuredRepository) SaveLocality(ctx context.Context, l domain.Locality) (int, error) {
+	defer m.timer.Since("SaveLocality", time.Now())
+	return m.Repository.SaveLocality(ctx, l)
+}
+
+func (m *measuredRepository) IDExist(ctx context.Context, id int) bool {
+	defer m.timer.Since("IDExist", time.Now())
+	return m.Repository.IDExist(ctx, id)
+}
+
+func (m *measuredRepository) SellerReport(ctx context.Context, id int) (domain.ReportSeller, error) {
+	defer m.timer.Since("SellerReport", time.Now())
+	return m.Repository.SellerReport(ctx, id)
+}
+
+func (m *measuredRepository) GetAllSellerReports(ctx context.Context) ([]domain.ReportSeller, error) {
+	defer m.timer.Since("GetAllSellerReports", time.Now())
+	return m.Repository.GetAllSellerReports(ctx)
+}
+
+func (m *measuredRepository) StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) error {
+	defer m.timer.Since("StreamSellerReports", time.Now())
+	return m.Repository.StreamSellerReports(ctx, fn)
+}
+
+func (m *measuredRepository) GetCarryReport(ctx context.Context, id int) (domain.LocalityCarries, error) {
+	defer m.timer.Since("GetCarryReport", time.Now())
+	return m.Repository.GetCarryReport(ctx, id)
+}
+
+func (m *measuredRepository) GetAllCarryReports(ctx context.Context) ([]domain.LocalityCarries, error) {
+	defer m.timer.Since("GetAllCarryReports", time.Now())
+	return m.Repository.GetAllCarryReports(ctx)
+}
+
+func (m *measuredRepository) StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) error {
+	defer m.timer.Since("StreamCarryReports", time.Now())
+	return m.Repository.StreamCarryReports(ctx, fn)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
@@ -15,17 +16,21 @@ type Repository interface {
 	IDExist(ctx context.Context, id int) bool
 	SellerReport(ctx context.Context, id int) (domain.ReportSeller, error)
 	GetAllSellerReports(ctx context.Context) ([]domain.ReportSeller, error)
+	StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) error
 	GetCarryReport(ctx context.Context, id int) (domain.LocalityCarries, error)
 	GetAllCarryReports(ctx context.Context) ([]domain.LocalityCarries, error)
+	StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) error
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
@@ -35,11 +40,10 @@ func (r *repository) SaveLocality(ctx context.Context, l domain.Locality) (int,
 		return 0, fmt.Errorf("locality with id %v already exists", l.ID)
 	}
 
-	stmt, err := r.db.PrepareContext(ctx, queries.InsertLocality)
+	stmt, err := r.stmts.Prepare(ctx, queries.InsertLocality)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
 
 	res, err := stmt.ExecContext(ctx, &l.ID, &l.LocalityName)
 	if err != nil {
@@ -51,7 +55,7 @@ func (r *repository) SaveLocality(ctx context.Context, l domain.Locality) (int,
 		return 0, err
 	}
 
-	stmt, err = r.db.Prepare(queries.InsertProvince)
+	stmt, err = r.stmts.Prepare(ctx, queries.InsertProvince)
 	if err != nil {
 		return 0, err
 	}
@@ -61,7 +65,7 @@ func (r *repository) SaveLocality(ctx context.Context, l domain.Locality) (int,
 		return 0, err
 	}
 
-	stmt, err = r.db.Prepare(queries.InsertCountry)
+	stmt, err = r.stmts.Prepare(ctx, queries.InsertCountry)
 	if err != nil {
 		return 0, err
 	}
@@ -75,18 +79,21 @@ func (r *repository) SaveLocality(ctx context.Context, l domain.Locality) (int,
 
 //Validate Id
 func (r *repository) IDExist(ctx context.Context, id int) bool {
-	row := r.db.QueryRowContext(ctx, queries.SelectIdLocality, id)
-	err := row.Scan(&id)
+	stmt, err := r.stmts.Prepare(ctx, queries.SelectIdLocality)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, id)
+	err = row.Scan(&id)
 	return err == nil
 }
 
 //Report Seller for Id
 func (r *repository) SellerReport(ctx context.Context, id int) (domain.ReportSeller, error) {
-	stmt, err := r.db.PrepareContext(ctx, queries.LocalityGetSellerReportQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.LocalityGetSellerReportQuery)
 	if err != nil {
 		return domain.ReportSeller{}, err
 	}
-	defer stmt.Close()
 
 	row := stmt.QueryRowContext(ctx, id)
 
@@ -102,35 +109,50 @@ func (r *repository) SellerReport(ctx context.Context, id int) (domain.ReportSel
 
 //Report Seller All
 func (r *repository) GetAllSellerReports(ctx context.Context) ([]domain.ReportSeller, error) {
-	stmt, err := r.db.PrepareContext(ctx, queries.LocalityGetAllSellerReportsQuery)
+	var lcs []domain.ReportSeller
+	err := r.StreamSellerReports(ctx, func(lc domain.ReportSeller) error {
+		lcs = append(lcs, lc)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx)
+	return lcs, nil
+}
+
+// Report Seller de cada locality, a medida que se lee de la base de datos
+func (r *repository) StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.LocalityGetAllSellerReportsQuery)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var lcs []domain.ReportSeller
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		lc := domain.ReportSeller{}
-		_ = rows.Scan(&lc.LocalityID, &lc.LocalityName, &lc.SellersCount)
-		lcs = append(lcs, lc)
+		if err := rows.Scan(&lc.LocalityID, &lc.LocalityName, &lc.SellersCount); err != nil {
+			return err
+		}
+		if err := fn(lc); err != nil {
+			return err
+		}
 	}
 
-	return lcs, nil
+	return rows.Err()
 }
 
 func (r *repository) GetCarryReport(ctx context.Context, id int) (domain.LocalityCarries, error) {
 	// Preparo la query
-	stmt, err := r.db.PrepareContext(ctx, queries.LocalityGetCarryReportQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.LocalityGetCarryReportQuery)
 	if err != nil {
 		return domain.LocalityCarries{}, err
 	}
-	defer stmt.Close()
 
 	// Ejecuto la query
 	row := stmt.QueryRowContext(ctx, id)
@@ -150,27 +172,44 @@ func (r *repository) GetCarryReport(ctx context.Context, id int) (domain.Localit
 }
 
 func (r *repository) GetAllCarryReports(ctx context.Context) ([]domain.LocalityCarries, error) {
-	// Preparo la query
-	stmt, err := r.db.PrepareContext(ctx, queries.LocalityGetAllCarryReportsQuery)
+	var lcs []domain.LocalityCarries
+
+	// Agrego cada fila al slice
+	err := r.StreamCarryReports(ctx, func(lc domain.LocalityCarries) error {
+		lcs = append(lcs, lc)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
+
+	return lcs, nil
+}
+
+func (r *repository) StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) error {
+	// Preparo la query
+	stmt, err := r.stmts.Prepare(ctx, queries.LocalityGetAllCarryReportsQuery)
+	if err != nil {
+		return err
+	}
 
 	// Ejecuto la query
 	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer rows.Close()
 
-	var lcs []domain.LocalityCarries
-
-	// Escaneo todas las filas y las agrego al slice
+	// Escaneo cada fila y se la paso a fn
 	for rows.Next() {
 		lc := domain.LocalityCarries{}
-		_ = rows.Scan(&lc.LocalityID, &lc.LocalityName, &lc.CarriesCount)
-		lcs = append(lcs, lc)
+		if err := rows.Scan(&lc.LocalityID, &lc.LocalityName, &lc.CarriesCount); err != nil {
+			return err
+		}
+		if err := fn(lc); err != nil {
+			return err
+		}
 	}
 
-	return lcs, nil
+	return rows.Err()
 }
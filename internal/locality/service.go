@@ -11,8 +11,10 @@ type Service interface {
 	IDExist(ctx context.Context, id int) bool
 	SellerReport(ctx context.Context, id int) (domain.ReportSeller, error)
 	GetAllSellerReports(ctx context.Context) ([]domain.ReportSeller, error)
+	StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) error
 	GetCarryReport(ctx context.Context, id int) (domain.LocalityCarries, error)
 	GetAllCarryReports(ctx context.Context) ([]domain.LocalityCarries, error)
+	StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) error
 }
 
 type service struct {
@@ -45,6 +47,11 @@ func (s *service) GetAllSellerReports(ctx context.Context) ([]domain.ReportSelle
 	return s.repository.GetAllSellerReports(ctx)
 }
 
+//Report Seller All, fila por fila
+func (s *service) StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) error {
+	return s.repository.StreamSellerReports(ctx, fn)
+}
+
 func (s *service) GetCarryReport(ctx context.Context, id int) (domain.LocalityCarries, error) {
 	return s.repository.GetCarryReport(ctx, id)
 }
@@ -52,3 +59,7 @@ func (s *service) GetCarryReport(ctx context.Context, id int) (domain.LocalityCa
 func (s *service) GetAllCarryReports(ctx context.Context) ([]domain.LocalityCarries, error) {
 	return s.repository.GetAllCarryReports(ctx)
 }
+
+func (s *service) StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) error {
+	return s.repository.StreamCarryReports(ctx, fn)
+}
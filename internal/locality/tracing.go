@@ -0,0 +1,134 @@
+package locality
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "locality.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("locality.Service"),
+	}
+}
+
+func (t *tracedService) SaveLocality(ctx context.Context, l domain.Locality) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "SaveLocality")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.SaveLocality(ctx, l)
+}
+
+func (t *tracedService) IDExist(ctx context.Context, id int) bool {
+	ctx, span := t.tracer.Start(ctx, "IDExist")
+	defer span.End()
+	return t.Service.IDExist(ctx, id)
+}
+
+func (t *tracedService) SellerReport(ctx context.Context, id int) (_ domain.ReportSeller, err error) {
+	ctx, span := t.tracer.Start(ctx, "SellerReport")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.SellerReport(ctx, id)
+}
+
+func (t *tracedService) GetAllSellerReports(ctx context.Context) (_ []domain.ReportSeller, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAllSellerReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAllSellerReports(ctx)
+}
+
+func (t *tracedService) StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamSellerReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamSellerReports(ctx, fn)
+}
+
+func (t *tracedService) GetCarryReport(ctx context.Context, id int) (_ domain.LocalityCarries, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetCarryReport")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetCarryReport(ctx, id)
+}
+
+func (t *tracedService) GetAllCarryReports(ctx context.Context) (_ []domain.LocalityCarries, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAllCarryReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAllCarryReports(ctx)
+}
+
+func (t *tracedService) StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamCarryReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamCarryReports(ctx, fn)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "locality.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("locality.Repository"),
+	}
+}
+
+func (t *tracedRepository) SaveLocality(ctx context.Context, l domain.Locality) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "SaveLocality")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SaveLocality(ctx, l)
+}
+
+func (t *tracedRepository) IDExist(ctx context.Context, id int) bool {
+	ctx, span := t.tracer.Start(ctx, "IDExist")
+	defer span.End()
+	return t.Repository.IDExist(ctx, id)
+}
+
+func (t *tracedRepository) SellerReport(ctx context.Context, id int) (_ domain.ReportSeller, err error) {
+	ctx, span := t.tracer.Start(ctx, "SellerReport")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SellerReport(ctx, id)
+}
+
+func (t *tracedRepository) GetAllSellerReports(ctx context.Context) (_ []domain.ReportSeller, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAllSellerReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAllSellerReports(ctx)
+}
+
+func (t *tracedRepository) StreamSellerReports(ctx context.Context, fn func(domain.ReportSeller) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamSellerReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamSellerReports(ctx, fn)
+}
+
+func (t *tracedRepository) GetCarryReport(ctx context.Context, id int) (_ domain.LocalityCarries, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetCarryReport")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetCarryReport(ctx, id)
+}
+
+func (t *tracedRepository) GetAllCarryReports(ctx context.Context) (_ []domain.LocalityCarries, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAllCarryReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAllCarryReports(ctx)
+}
+
+func (t *tracedRepository) StreamCarryReports(ctx context.Context, fn func(domain.LocalityCarries) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamCarryReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamCarryReports(ctx, fn)
+}
@@ -0,0 +1,165 @@
+// Package memdb keeps the tables of the service in memory, for the
+// repositories in the memory subpackage of each domain package. It lets the
+// server run without a database, for demos and end-to-end tests.
+package memdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+)
+
+// ErrDuplicate is returned when a row would break a unique key, like the SQL
+// databases do with a duplicate entry error.
+var ErrDuplicate = storage.ErrDuplicate
+
+// TimeLayout is the layout datetime columns are stored with.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// OrderDetail is a row of order_details.
+type OrderDetail struct {
+	ID              int
+	ProductRecordID int
+	PurchaseOrderID int
+	Quantity        int
+}
+
+// ProductRecord is a row of product_records.
+type ProductRecord struct {
+	ID             int
+	LastUpdateDate string
+	PurchasePrice  float64
+	SalePrice      float64
+	ProductID      int
+}
+
+// ProductType is a row of products_types.
+type ProductType struct {
+	ID          int
+	Description string
+}
+
+// OrderStatus is a row of order_status.
+type OrderStatus struct {
+	ID          int
+	Description string
+}
+
+// UserRole is a row of user_rol.
+type UserRole struct {
+	UserID int
+	RoleID int
+}
+
+// IdempotencyKey is the primary key of idempotency_keys: the user who sent
+// the key and the key.
+type IdempotencyKey struct {
+	User string
+	Key  string
+}
+
+// DB holds every table. Repositories take the lock for the whole of each
+// call, so a call sees and leaves the tables consistent the way a statement
+// does in MySQL. Rows are kept in insertion order, which is also id order.
+type DB struct {
+	sync.RWMutex
+
+	Products        []domain.Product
+	Sellers         []domain.Seller
+	Sections        []domain.Section
+	Warehouses      []domain.Warehouse
+	Employees       []domain.Employee
+	Buyers          []domain.Buyer
+	Carries         []domain.Carry
+	Localities      []domain.Locality
+	ProductBatches  []domain.ProductBatches
+	InboundOrders   []domain.InboundOrder
+	PurchaseOrders  []domain.PurchaseOrders
+	OrderDetails    []OrderDetail
+	ProductRecords  []ProductRecord
+	ProductTypes    []ProductType
+	OrderStatuses   []OrderStatus
+	Users           []domain.User
+	Roles           []domain.Role
+	UserRoles       []UserRole
+	AuditLog        []domain.AuditEntry
+	OutboxEvents    []domain.OutboxEvent
+	IdempotencyKeys map[IdempotencyKey]domain.IdempotencyRecord
+
+	ids map[string]int
+	now func() time.Time
+}
+
+// New returns an empty DB.
+func New() *DB {
+	return &DB{
+		IdempotencyKeys: map[IdempotencyKey]domain.IdempotencyRecord{},
+		ids:             map[string]int{},
+		now:             time.Now,
+	}
+}
+
+// NextID returns the next auto increment id of table.
+func (db *DB) NextID(table string) int {
+	db.ids[table]++
+	return db.ids[table]
+}
+
+// UseID records that a row of table was inserted with an explicit id, so
+// that NextID keeps returning greater ids.
+func (db *DB) UseID(table string, id int) {
+	if id > db.ids[table] {
+		db.ids[table] = id
+	}
+}
+
+// Now returns the current time formatted as a datetime column, like NOW().
+func (db *DB) Now() string {
+	return db.now().Format(TimeLayout)
+}
+
+// Datetime formats a date taken by a DATETIME column the way the column
+// returns it, so stored dates compare as strings like MySQL compares them.
+func Datetime(value string) string {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Format(TimeLayout)
+	}
+	return value
+}
+
+// InRange tells whether the datetime value falls within dates.
+func InRange(value string, dates domain.DateRange) bool {
+	return (dates.From == "" || value >= dates.From) && (dates.To == "" || value <= dates.To)
+}
+
+// ProductRecord returns the product record with id.
+func (db *DB) ProductRecord(id int) (ProductRecord, bool) {
+	for _, pr := range db.ProductRecords {
+		if pr.ID == id {
+			return pr, true
+		}
+	}
+	return ProductRecord{}, false
+}
+
+// Product returns the product with id, deleted or not.
+func (db *DB) Product(id int) (domain.Product, bool) {
+	for _, p := range db.Products {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return domain.Product{}, false
+}
+
+// PurchaseOrder returns the purchase order with id.
+func (db *DB) PurchaseOrder(id int) (domain.PurchaseOrders, bool) {
+	for _, po := range db.PurchaseOrders {
+		if po.ID == id {
+			return po, true
+		}
+	}
+	return domain.PurchaseOrders{}, false
+}
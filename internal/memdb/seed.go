@@ -0,0 +1,89 @@
+package memdb
+
+import "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+
+// Seed loads the same rows as the data section of db.sql, including the
+// roles but no user.
+func Seed(db *DB) {
+	db.Lock()
+	defer db.Unlock()
+
+	db.Buyers = append(db.Buyers,
+		domain.Buyer{ID: 1, CardNumberID: "51442-543", FirstName: "Hercule", LastName: "Gouldeby"},
+		domain.Buyer{ID: 2, CardNumberID: "0228-2077", FirstName: "Kale", LastName: "Worge"},
+		domain.Buyer{ID: 3, CardNumberID: "31722-207", FirstName: "Winfield", LastName: "Maxfield"},
+		domain.Buyer{ID: 4, CardNumberID: "52164-1106", FirstName: "Delly", LastName: "Yearns"},
+		domain.Buyer{ID: 5, CardNumberID: "65437-035", FirstName: "Alyss", LastName: "Van Brug"},
+	)
+	db.Warehouses = append(db.Warehouses,
+		domain.Warehouse{ID: 1, Address: "2985 Lunder Center", Telephone: "(161) 7030736", WarehouseCode: "0338-0703", MinimumCapacity: 1, MinimumTemperature: 1, Version: 1},
+		domain.Warehouse{ID: 2, Address: "5 Myrtle Hill", Telephone: "(601) 5899450", WarehouseCode: "11673-170", MinimumCapacity: 2, MinimumTemperature: 2, Version: 1},
+		domain.Warehouse{ID: 3, Address: "1 Morning Center", Telephone: "(615) 9659486", WarehouseCode: "63777-223", MinimumCapacity: 3, MinimumTemperature: 3, Version: 1},
+		domain.Warehouse{ID: 4, Address: "40 Clyde Gallagher Plaza", Telephone: "(862) 9958364", WarehouseCode: "67046-590", MinimumCapacity: 4, MinimumTemperature: 4, Version: 1},
+		domain.Warehouse{ID: 5, Address: "4002 Ridgeview Alley", Telephone: "(699) 5099808", WarehouseCode: "54868-5345", MinimumCapacity: 5, MinimumTemperature: 5, Version: 1},
+	)
+	db.Sellers = append(db.Sellers,
+		domain.Seller{ID: 1, CID: 1, CompanyName: "Skyba", Address: "3180 Roxbury Drive", Telephone: "(618) 2011607"},
+		domain.Seller{ID: 2, CID: 2, CompanyName: "Latz", Address: "6286 Moulton Parkway", Telephone: "(387) 6865821"},
+		domain.Seller{ID: 3, CID: 3, CompanyName: "Wikizz", Address: "9 Marquette Drive", Telephone: "(129) 4018633"},
+		domain.Seller{ID: 4, CID: 4, CompanyName: "Meedoo", Address: "91 Briar Crest Road", Telephone: "(547) 2313975"},
+		domain.Seller{ID: 5, CID: 5, CompanyName: "Skidoo", Address: "6924 Roxbury Park", Telephone: "(558) 9207455"},
+	)
+	db.Carries = append(db.Carries,
+		domain.Carry{ID: 1, CID: "1", CompanyName: "Trudoo", Address: "94 Eastwood Way", Telephone: "(981) 2938974", LocalityID: 1},
+		domain.Carry{ID: 2, CID: "2", CompanyName: "Divape", Address: "56 Red Cloud Terrace", Telephone: "(331) 4585300", LocalityID: 2},
+		domain.Carry{ID: 3, CID: "3", CompanyName: "Livepath", Address: "101 Arrowood Place", Telephone: "(890) 1282013", LocalityID: 3},
+		domain.Carry{ID: 4, CID: "4", CompanyName: "Tavu", Address: "6124 West Trail", Telephone: "(550) 7194074", LocalityID: 4},
+		domain.Carry{ID: 5, CID: "5", CompanyName: "Tekfly", Address: "10 Commercial Park", Telephone: "(445) 9818922", LocalityID: 5},
+	)
+	db.Localities = append(db.Localities,
+		domain.Locality{ID: 1, LocalityName: "Quigley, Bauch and Willms", ProvinceName: "Frederiksberg", CountryName: "Greece"},
+		domain.Locality{ID: 2, LocalityName: "Von, Schmeler and Hyatt", ProvinceName: "Shuangta", CountryName: "Greece"},
+		domain.Locality{ID: 3, LocalityName: "Johns-Abshire", ProvinceName: "Quibdó", CountryName: "Burkina Faso"},
+		domain.Locality{ID: 4, LocalityName: "Bernhard Inc", ProvinceName: "Nantes", CountryName: "China"},
+		domain.Locality{ID: 5, LocalityName: "Gutkowski, Sipes and Rowe", ProvinceName: "Xiaosong", CountryName: "Venezuela"},
+	)
+	for i := 1; i <= 5; i++ {
+		db.Sections = append(db.Sections, domain.Section{ID: i, SectionNumber: i, CurrentTemperature: i, MinimumTemperature: i, CurrentCapacity: i, MinimumCapacity: i, MaximumCapacity: i, WarehouseID: i, ProductTypeID: i, Version: 1})
+	}
+	db.Employees = append(db.Employees,
+		domain.Employee{ID: 1, CardNumberID: "1", FirstName: "Mattie", LastName: "Smallpeice", WarehouseID: 1, Version: 1},
+		domain.Employee{ID: 2, CardNumberID: "2", FirstName: "Kary", LastName: "Gavrielli", WarehouseID: 2, Version: 1},
+		domain.Employee{ID: 3, CardNumberID: "3", FirstName: "Kerwinn", LastName: "Woller", WarehouseID: 3, Version: 1},
+		domain.Employee{ID: 4, CardNumberID: "4", FirstName: "Putnem", LastName: "Pheazey", WarehouseID: 4, Version: 1},
+		domain.Employee{ID: 5, CardNumberID: "5", FirstName: "Tamas", LastName: "Piletic", WarehouseID: 5, Version: 1},
+	)
+	db.Products = append(db.Products,
+		domain.Product{ID: 1, Description: "pretium iaculis diam erat", ExpirationRate: 22, FreezingRate: 4, Height: 88, Length: 71, Netweight: 80, ProductCode: "0536-3587", RecomFreezTemp: 77, Width: 42, ProductTypeID: 1, SellerID: 1, Version: 1},
+		domain.Product{ID: 2, Description: "pede morbi porttitor lorem id ligula", ExpirationRate: 25, FreezingRate: 20, Height: 69, Length: 73, Netweight: 77, ProductCode: "67046-089", RecomFreezTemp: 85, Width: 82, ProductTypeID: 2, SellerID: 2, Version: 1},
+		domain.Product{ID: 3, Description: "pede venenatis non sodales", ExpirationRate: 37, FreezingRate: 88, Height: 56, Length: 70, Netweight: 67, ProductCode: "63323-270", RecomFreezTemp: 41, Width: 69, ProductTypeID: 3, SellerID: 3, Version: 1},
+		domain.Product{ID: 4, Description: "turpis adipiscing lorem vitae mattis", ExpirationRate: 68, FreezingRate: 25, Height: 70, Length: 51, Netweight: 89, ProductCode: "0338-0552", RecomFreezTemp: 85, Width: 60, ProductTypeID: 4, SellerID: 4, Version: 1},
+		domain.Product{ID: 5, Description: "donec ut mauris eget", ExpirationRate: 12, FreezingRate: 45, Height: 97, Length: 29, Netweight: 64, ProductCode: "41268-029", RecomFreezTemp: 95, Width: 19, ProductTypeID: 5, SellerID: 5, Version: 1},
+	)
+	db.ProductTypes = append(db.ProductTypes,
+		ProductType{ID: 1, Description: "consectetuer eget rutrum at lorem"},
+		ProductType{ID: 2, Description: "vulputate ut ultrices"},
+		ProductType{ID: 3, Description: "pellentesque eget nunc donec"},
+		ProductType{ID: 4, Description: "vel lectus in quam"},
+		ProductType{ID: 5, Description: "justo maecenas rhoncus aliquam lacus"},
+	)
+	db.OrderStatuses = append(db.OrderStatuses,
+		OrderStatus{ID: 1, Description: "mi nulla ac enim in tempor"},
+		OrderStatus{ID: 2, Description: "blandit nam nulla integer pede"},
+		OrderStatus{ID: 3, Description: "ipsum dolor sit"},
+		OrderStatus{ID: 4, Description: "ligula in lacus curabitur at ipsum"},
+		OrderStatus{ID: 5, Description: "sit amet justo morbi"},
+	)
+	db.Roles = append(db.Roles,
+		domain.Role{ID: 1, Name: "admin", Description: "Manages warehouses, sections, users and roles"},
+		domain.Role{ID: 2, Name: "warehouse_operator", Description: "Registers inbound orders and product batches"},
+		domain.Role{ID: 3, Name: "sales", Description: "Manages buyers and purchase orders"},
+	)
+
+	for table, id := range map[string]int{
+		"buyers": 5, "warehouses": 5, "sellers": 5, "carries": 5, "localities": 5, "sections": 5, "employees": 5,
+		"products": 5, "products_types": 5, "order_status": 5, "rol": 3,
+	} {
+		db.UseID(table, id)
+	}
+}
@@ -0,0 +1,115 @@
+// Package metrics collects the metrics of the server and exposes them in the
+// Prometheus text format: the count and latency of the HTTP requests, the
+// duration of each repository method and the stats of the database pool.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the name of every metric.
+const namespace = "melisprint"
+
+// Metrics holds the collectors of a server. Each server has its own
+// registry, so several can run in the same process, as they do in tests.
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	queries  *prometheus.HistogramVec
+}
+
+// New returns Metrics with the request and repository collectors, plus the
+// Go runtime and process ones, registered.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests, by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_call_duration_seconds",
+			Help:      "Time taken by repository methods, by repository and method.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"repository", "method"}),
+	}
+	m.registry.MustRegister(
+		m.requests,
+		m.latency,
+		m.queries,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler serves the metrics to Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a request to route, the template it matched, that
+// was answered with status after d.
+func (m *Metrics) ObserveRequest(method, route string, status int, d time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(method, route, code).Inc()
+	m.latency.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+// WatchDB exports the stats of the connection pool of db, from
+// sql.DB.Stats, and the counters of its prepared statements.
+func (m *Metrics) WatchDB(db *sql.DB) {
+	stmts := storage.StatementsOf(db)
+	m.registry.MustRegister(
+		collectors.NewDBStatsCollector(db, namespace),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_statement_prepares_total",
+			Help:      "Statements prepared on the database by the statement cache.",
+		}, func() float64 { return float64(stmts.Stats().Prepares) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_statement_cache_hits_total",
+			Help:      "Statements reused from the statement cache.",
+		}, func() float64 { return float64(stmts.Stats().Hits) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_statements_open",
+			Help:      "Statements held by the statement cache.",
+		}, func() float64 { return float64(stmts.Stats().Open) }),
+	)
+}
+
+// Repository returns the Timer of the repository name.
+func (m *Metrics) Repository(name string) Timer {
+	return Timer{queries: m.queries, repository: name}
+}
+
+// Timer records the duration of the methods of a repository.
+type Timer struct {
+	queries    *prometheus.HistogramVec
+	repository string
+}
+
+// Since records a call to method started at start. It is meant to be
+// deferred at the top of the method: defer t.Since("Get", time.Now()).
+func (t Timer) Since(method string, start time.Time) {
+	t.queries.WithLabelValues(t.repository, method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+const (
+	// batchSize is how many events the Dispatcher reads at a time.
+	batchSize = 100
+	// minRetryDelay is the wait after the first failed delivery of an event,
+	// doubled after each further failure up to maxRetryDelay.
+	minRetryDelay = time.Second
+	maxRetryDelay = 5 * time.Minute
+)
+
+// Dispatcher delivers the pending events of the outbox to every sink, oldest
+// first. An event any sink fails to take is tried again later, waiting
+// twice as long after each failure, and marked failed after maxAttempts.
+// A retry goes to every sink again, so sinks may get an event twice.
+type Dispatcher struct {
+	repository  Repository
+	sinks       []Sink
+	maxAttempts int
+	log         *slog.Logger
+	tracer      tracing.Tracer
+	now         func() time.Time
+}
+
+// NewDispatcher returns a Dispatcher of the events in r to sinks, logging to
+// log the events it gives up on.
+func NewDispatcher(r Repository, log *slog.Logger, maxAttempts int, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		repository:  r,
+		sinks:       sinks,
+		maxAttempts: maxAttempts,
+		log:         log,
+		tracer:      tracing.New("outbox.Dispatcher"),
+		now:         time.Now,
+	}
+}
+
+// Run dispatches the pending events every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.Dispatch(ctx); err != nil {
+			d.log.ErrorContext(ctx, "dispatching events failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch delivers the events due now and returns how many were delivered.
+// It fails only when the outbox can't be read or updated.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		events, err := d.repository.Pending(ctx, d.now().Format(timeLayout), batchSize)
+		if err != nil {
+			return delivered, err
+		}
+		for _, e := range events {
+			ok, err := d.dispatch(ctx, e)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+		if len(events) < batchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// dispatch delivers e to every sink and records the outcome, reporting
+// whether all of them took it.
+func (d *Dispatcher) dispatch(ctx context.Context, e domain.OutboxEvent) (bool, error) {
+	ctx, span := d.tracer.Start(ctx, "Deliver")
+	deliverErr := d.deliver(ctx, e)
+	tracing.End(span, deliverErr)
+	if deliverErr == nil {
+		return true, d.repository.MarkDelivered(ctx, e.ID)
+	}
+
+	e.Attempts++
+	e.LastError = deliverErr.Error()
+	if e.Attempts >= d.maxAttempts {
+		e.Status = StatusFailed
+		d.log.ErrorContext(ctx, "giving up delivering event",
+			"event_id", e.ID, "type", e.Type, "attempts", e.Attempts, "error", deliverErr)
+	} else {
+		e.NextAttemptAt = d.now().Add(retryDelay(e.Attempts)).Format(timeLayout)
+		d.log.WarnContext(ctx, "delivering event failed",
+			"event_id", e.ID, "type", e.Type, "attempts", e.Attempts, "error", deliverErr)
+	}
+	return false, d.repository.SaveAttempt(ctx, e)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, e domain.OutboxEvent) error {
+	var errs []error
+	for _, sink := range d.sinks {
+		if err := sink.Deliver(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// retryDelay is the wait before trying an event again after attempts failed
+// deliveries.
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps the events of the outbox in a slice.
+type fakeRepository struct {
+	events []domain.OutboxEvent
+}
+
+func (f *fakeRepository) Save(ctx context.Context, e domain.OutboxEvent) (int, error) {
+	e.ID = len(f.events) + 1
+	f.events = append(f.events, e)
+	return e.ID, nil
+}
+
+func (f *fakeRepository) Pending(ctx context.Context, now string, limit int) ([]domain.OutboxEvent, error) {
+	events := []domain.OutboxEvent{}
+	for _, e := range f.events {
+		if e.Status == StatusPending && e.NextAttemptAt <= now && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (f *fakeRepository) MarkDelivered(ctx context.Context, id int) error {
+	f.events[id-1].Status = StatusDelivered
+	f.events[id-1].Attempts++
+	return nil
+}
+
+func (f *fakeRepository) SaveAttempt(ctx context.Context, e domain.OutboxEvent) error {
+	f.events[e.ID-1] = e
+	return nil
+}
+
+// fakeSink fails the first failures deliveries and records the others.
+type fakeSink struct {
+	failures  int
+	delivered []domain.OutboxEvent
+}
+
+func (f *fakeSink) Deliver(ctx context.Context, e domain.OutboxEvent) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("sink down")
+	}
+	f.delivered = append(f.delivered, e)
+	return nil
+}
+
+func newTestDispatcher(maxAttempts int, sinks ...Sink) (*Dispatcher, *fakeRepository, *time.Time) {
+	repo := &fakeRepository{}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewDispatcher(repo, slog.New(slog.NewTextHandler(io.Discard, nil)), maxAttempts, sinks...)
+	d.now = func() time.Time { return now }
+	return d, repo, &now
+}
+
+func publish(t *testing.T, repo Repository, now time.Time, events ...domain.Event) {
+	s := &service{repository: repo, now: func() time.Time { return now }}
+	require.Nil(t, s.Publish(context.TODO(), events...))
+}
+
+func TestDispatchDeliversPendingEvents(t *testing.T) {
+	sink := &fakeSink{}
+	d, repo, now := newTestDispatcher(3, sink)
+	publish(t, repo, *now,
+		domain.SellerCreated{Seller: domain.Seller{ID: 7, CID: 70}},
+		domain.SellerDeleted{ID: 7},
+	)
+
+	delivered, err := d.Dispatch(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, delivered)
+	require.Len(t, sink.delivered, 2)
+	assert.Equal(t, "seller.created", sink.delivered[0].Type)
+	assert.Equal(t, "seller", sink.delivered[0].Entity)
+	assert.Equal(t, 7, sink.delivered[0].EntityID)
+	assert.JSONEq(t, `{"id":7}`, string(sink.delivered[1].Payload))
+	assert.Equal(t, StatusDelivered, repo.events[0].Status)
+
+	delivered, err = d.Dispatch(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, delivered, "delivered events are not sent again")
+}
+
+func TestDispatchRetriesWithGrowingDelay(t *testing.T) {
+	sink := &fakeSink{failures: 2}
+	d, repo, now := newTestDispatcher(5, sink)
+	publish(t, repo, *now, domain.SellerDeleted{ID: 1})
+
+	delivered, err := d.Dispatch(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, 1, repo.events[0].Attempts)
+	assert.Equal(t, "sink down", repo.events[0].LastError)
+	assert.Equal(t, "2022-01-01 00:00:01", repo.events[0].NextAttemptAt)
+
+	delivered, _ = d.Dispatch(context.TODO())
+	assert.Equal(t, 0, delivered, "the event is not due yet")
+
+	*now = now.Add(time.Second)
+	_, _ = d.Dispatch(context.TODO())
+	assert.Equal(t, 2, repo.events[0].Attempts)
+	assert.Equal(t, "2022-01-01 00:00:03", repo.events[0].NextAttemptAt)
+
+	*now = now.Add(2 * time.Second)
+	delivered, _ = d.Dispatch(context.TODO())
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, StatusDelivered, repo.events[0].Status)
+	assert.Len(t, sink.delivered, 1)
+}
+
+func TestDispatchGivesUpAfterMaxAttempts(t *testing.T) {
+	sink := &fakeSink{failures: 10}
+	d, repo, now := newTestDispatcher(2, sink)
+	publish(t, repo, *now, domain.SellerDeleted{ID: 1})
+
+	_, _ = d.Dispatch(context.TODO())
+	*now = now.Add(time.Minute)
+	_, _ = d.Dispatch(context.TODO())
+
+	assert.Equal(t, StatusFailed, repo.events[0].Status)
+	assert.Equal(t, 2, repo.events[0].Attempts)
+	*now = now.Add(time.Hour)
+	delivered, _ := d.Dispatch(context.TODO())
+	assert.Equal(t, 0, delivered)
+	assert.Empty(t, sink.delivered)
+}
+
+func TestDispatchRetriesWhenAnySinkFails(t *testing.T) {
+	ok, failing := &fakeSink{}, &fakeSink{failures: 1}
+	d, repo, now := newTestDispatcher(3, ok, failing)
+	publish(t, repo, *now, domain.SellerDeleted{ID: 1})
+
+	_, _ = d.Dispatch(context.TODO())
+	*now = now.Add(time.Second)
+	_, _ = d.Dispatch(context.TODO())
+
+	assert.Len(t, ok.delivered, 2, "the retry goes to every sink")
+	assert.Len(t, failing.delivered, 1)
+	assert.Equal(t, StatusDelivered, repo.events[0].Status)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, retryDelay(1))
+	assert.Equal(t, 4*time.Second, retryDelay(3))
+	assert.Equal(t, maxRetryDelay, retryDelay(20))
+}
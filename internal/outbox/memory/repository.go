@@ -0,0 +1,83 @@
+// Package memory implements outbox.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns an outbox.Repository that keeps the events in db.
+func NewRepository(db *memdb.DB) outbox.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Save(ctx context.Context, e domain.OutboxEvent) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	e.ID = r.db.NextID("outbox_events")
+	e.CreatedAt = r.db.Now()
+	r.db.OutboxEvents = append(r.db.OutboxEvents, e)
+	return e.ID, nil
+}
+
+func (r *repository) Pending(ctx context.Context, now string, limit int) ([]domain.OutboxEvent, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	events := []domain.OutboxEvent{}
+	for _, e := range r.db.OutboxEvents {
+		if len(events) == limit {
+			break
+		}
+		if e.Status == outbox.StatusPending && e.NextAttemptAt <= now {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (r *repository) MarkDelivered(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if e := r.find(id); e != nil {
+		e.Status = outbox.StatusDelivered
+		e.Attempts++
+		e.LastError = ""
+	}
+	return nil
+}
+
+func (r *repository) SaveAttempt(ctx context.Context, attempt domain.OutboxEvent) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if e := r.find(attempt.ID); e != nil {
+		e.Status = attempt.Status
+		e.Attempts = attempt.Attempts
+		e.NextAttemptAt = attempt.NextAttemptAt
+		e.LastError = attempt.LastError
+	}
+	return nil
+}
+
+// find returns the event with id, to be changed in place, or nil.
+func (r *repository) find(id int) *domain.OutboxEvent {
+	for i := range r.db.OutboxEvents {
+		if r.db.OutboxEvents[i].ID == id {
+			return &r.db.OutboxEvents[i]
+		}
+	}
+	return nil
+}
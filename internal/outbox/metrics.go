@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "outbox".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("outbox"),
+	}
+}
+
+func (m *measuredRepository) Save(ctx context.Context, e domain.OutboxEvent) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, e)
+}
+
+func (m *measuredRepository) Pending(ctx context.Context, now string, limit int) ([]domain.OutboxEvent, error) {
+	defer m.timer.Since("Pending", time.Now())
+	return m.Repository.Pending(ctx, now, limit)
+}
+
+func (m *measuredRepository) MarkDelivered(ctx context.Context, id int) error {
+	defer m.timer.Since("MarkDelivered", time.Now())
+	return m.Repository.MarkDelivered(ctx, id)
+}
+
+func (m *measuredRepository) SaveAttempt(ctx context.Context, e domain.OutboxEvent) error {
+	defer m.timer.Since("SaveAttempt", time.Now())
+	return m.Repository.SaveAttempt(ctx, e)
+}
@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
+)
+
+// Repository encapsulates the storage of the outbox.
+type Repository interface {
+	// Save stores a pending event, in the transaction ctx carries if any.
+	Save(ctx context.Context, e domain.OutboxEvent) (int, error)
+	// Pending returns up to limit pending events due at or before now,
+	// oldest first.
+	Pending(ctx context.Context, now string, limit int) ([]domain.OutboxEvent, error)
+	// MarkDelivered records that the event with id reached every sink.
+	MarkDelivered(ctx context.Context, id int) error
+	// SaveAttempt stores the status, attempts, next attempt and last error
+	// of e after a failed delivery.
+	SaveAttempt(ctx context.Context, e domain.OutboxEvent) error
+}
+
+type repository struct {
+	db    *sql.DB
+	stmts *storage.Statements
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db:    db,
+		stmts: storage.StatementsOf(db),
+	}
+}
+
+func (r *repository) Save(ctx context.Context, e domain.OutboxEvent) (int, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.OutboxSaveQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, e.Type, e.Entity, e.EntityID, string(e.Payload), e.RequestID, e.Status, e.NextAttemptAt)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r *repository) Pending(ctx context.Context, now string, limit int) ([]domain.OutboxEvent, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.OutboxPendingQuery)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, StatusPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.OutboxEvent{}
+	for rows.Next() {
+		e := domain.OutboxEvent{}
+		var payload []byte
+		var lastError sql.NullString
+		if err := rows.Scan(&e.ID, &e.Type, &e.Entity, &e.EntityID, &payload, &e.RequestID, &e.CreatedAt, &e.Status, &e.Attempts, &e.NextAttemptAt, &lastError); err != nil {
+			return nil, err
+		}
+		e.Payload, e.LastError = payload, lastError.String
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *repository) MarkDelivered(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.OutboxMarkDeliveredQuery)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, StatusDelivered, id)
+	return err
+}
+
+func (r *repository) SaveAttempt(ctx context.Context, e domain.OutboxEvent) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.OutboxSaveAttemptQuery)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, e.Status, e.Attempts, e.NextAttemptAt, e.LastError, e.ID)
+	return err
+}
@@ -0,0 +1,85 @@
+// Package outbox stores the events of the changes made to the entities in
+// the transaction of each change, so an event is stored if and only if its
+// change is. A Dispatcher delivers the stored events to the sinks other
+// services listen on afterwards.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+)
+
+// Statuses stored in outbox_events.status.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// timeLayout is the layout of the datetime columns of outbox_events.
+const timeLayout = "2006-01-02 15:04:05"
+
+// Publisher is the part of the outbox the services publishing events
+// depend on.
+type Publisher interface {
+	// Transact runs fn in a transaction, so that the changes fn makes and
+	// the events it publishes are stored together or not at all.
+	Transact(ctx context.Context, fn func(ctx context.Context) error) error
+	// Publish stores events in the outbox, in the transaction ctx carries.
+	Publish(ctx context.Context, events ...domain.Event) error
+}
+
+type Service interface {
+	Publisher
+}
+
+type service struct {
+	repository Repository
+	db         *sql.DB
+	now        func() time.Time
+}
+
+// NewService returns a Service storing events in r, in transactions of db.
+// A nil db, as the in-memory storage has, stores them without transactions.
+func NewService(r Repository, db *sql.DB) Service {
+	return &service{
+		repository: r,
+		db:         db,
+		now:        time.Now,
+	}
+}
+
+func (s *service) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	return storage.Transact(ctx, s.db, fn)
+}
+
+// Publish stores each event as pending, due now. Unlike an audit entry, an
+// event that can't be stored fails the change it reports.
+func (s *service) Publish(ctx context.Context, events ...domain.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		entity, id := event.EventEntity()
+		_, err = s.repository.Save(ctx, domain.OutboxEvent{
+			Type:          event.EventType(),
+			Entity:        entity,
+			EntityID:      id,
+			Payload:       payload,
+			RequestID:     web.RequestID(ctx),
+			Status:        StatusPending,
+			NextAttemptAt: s.now().Format(timeLayout),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+// Headers of the requests of a Webhook.
+const (
+	EventIDHeader   = "X-Event-ID"
+	EventTypeHeader = "X-Event-Type"
+)
+
+// Sink takes the events the Dispatcher delivers. An error makes the
+// Dispatcher try the event again later.
+type Sink interface {
+	Deliver(ctx context.Context, e domain.OutboxEvent) error
+}
+
+// Webhook posts each event as JSON to a URL. Any answer but a 2xx fails
+// the delivery.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns a Webhook posting to url, giving up on a request after
+// timeout.
+func NewWebhook(url string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (w *Webhook) Deliver(ctx context.Context, e domain.OutboxEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, strconv.Itoa(e.ID))
+	req.Header.Set(EventTypeHeader, e.Type)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// LogFile appends each event as a line of JSON to a file, created when it
+// doesn't exist. The file is opened for each event, so it may be rotated
+// while the server runs.
+type LogFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogFile returns a LogFile writing to the file at path.
+func NewLogFile(path string) *LogFile {
+	return &LogFile{
+		path: path,
+	}
+}
+
+func (l *LogFile) Deliver(ctx context.Context, e domain.OutboxEvent) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// AllEvents subscribes a Handler to every event type.
+const AllEvents = "*"
+
+// Handler reacts in process to an event.
+type Handler func(ctx context.Context, e domain.OutboxEvent) error
+
+// Subscribers delivers each event to the Handlers subscribed to its type.
+// When one of them fails, the event is delivered to all of them again.
+type Subscribers struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewSubscribers returns Subscribers without any Handler.
+func NewSubscribers() *Subscribers {
+	return &Subscribers{
+		handlers: map[string][]Handler{},
+	}
+}
+
+// Subscribe calls h with every event of eventType, such as
+// "seller.created", or of any type with AllEvents.
+func (s *Subscribers) Subscribe(eventType string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[eventType] = append(s.handlers[eventType], h)
+}
+
+func (s *Subscribers) Deliver(ctx context.Context, e domain.OutboxEvent) error {
+	s.mu.RLock()
+	handlers := append(append([]Handler{}, s.handlers[e.Type]...), s.handlers[AllEvents]...)
+	s.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = domain.OutboxEvent{
+	ID:       3,
+	Type:     "seller.deleted",
+	Entity:   "seller",
+	EntityID: 7,
+	Payload:  json.RawMessage(`{"id":7}`),
+}
+
+func TestWebhookPostsEvents(t *testing.T) {
+	var got domain.OutboxEvent
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, time.Second).Deliver(context.TODO(), testEvent)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "3", header.Get(EventIDHeader))
+	assert.Equal(t, "seller.deleted", header.Get(EventTypeHeader))
+	assert.Equal(t, testEvent.EntityID, got.EntityID)
+	assert.JSONEq(t, `{"id":7}`, string(got.Payload))
+}
+
+func TestWebhookFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, time.Second).Deliver(context.TODO(), testEvent)
+
+	assert.EqualError(t, err, "webhook answered 503 Service Unavailable")
+}
+
+func TestLogFileAppendsLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	sink := NewLogFile(path)
+
+	require.Nil(t, sink.Deliver(context.TODO(), testEvent))
+	require.Nil(t, sink.Deliver(context.TODO(), testEvent))
+
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	var got domain.OutboxEvent
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &got))
+	assert.Equal(t, testEvent.Type, got.Type)
+}
+
+func TestSubscribersGetTheirEventTypes(t *testing.T) {
+	s := NewSubscribers()
+	var sellers, all int
+	s.Subscribe("seller.deleted", func(ctx context.Context, e domain.OutboxEvent) error {
+		sellers++
+		return nil
+	})
+	s.Subscribe("buyer.deleted", func(ctx context.Context, e domain.OutboxEvent) error {
+		return errors.New("not for this event")
+	})
+	s.Subscribe(AllEvents, func(ctx context.Context, e domain.OutboxEvent) error {
+		all++
+		return nil
+	})
+
+	assert.Nil(t, s.Deliver(context.TODO(), testEvent))
+	assert.Equal(t, 1, sellers)
+	assert.Equal(t, 1, all)
+
+	s.Subscribe(AllEvents, func(ctx context.Context, e domain.OutboxEvent) error {
+		return errors.New("subscriber failed")
+	})
+	assert.EqualError(t, s.Deliver(context.TODO(), testEvent), "subscriber failed")
+}
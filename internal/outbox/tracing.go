@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "outbox.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("outbox.Service"),
+	}
+}
+
+func (t *tracedService) Transact(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Transact")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Transact(ctx, fn)
+}
+
+func (t *tracedService) Publish(ctx context.Context, events ...domain.Event) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Publish")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Publish(ctx, events...)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "outbox.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("outbox.Repository"),
+	}
+}
+
+func (t *tracedRepository) Save(ctx context.Context, e domain.OutboxEvent) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, e)
+}
+
+func (t *tracedRepository) Pending(ctx context.Context, now string, limit int) (_ []domain.OutboxEvent, err error) {
+	ctx, span := t.tracer.Start(ctx, "Pending")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Pending(ctx, now, limit)
+}
+
+func (t *tracedRepository) MarkDelivered(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "MarkDelivered")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.MarkDelivered(ctx, id)
+}
+
+func (t *tracedRepository) SaveAttempt(ctx context.Context, e domain.OutboxEvent) (err error) {
+	ctx, span := t.tracer.Start(ctx, "SaveAttempt")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SaveAttempt(ctx, e)
+}
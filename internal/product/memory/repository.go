@@ -0,0 +1,228 @@
+// Package memory implements product.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a product.Repository that keeps the products in db.
+func NewRepository(db *memdb.DB) product.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the product with id, or -1.
+func (r *repository) index(id int) int {
+	for i, p := range r.db.Products {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.StreamAll(ctx, includeDeleted, func(p domain.Product) error {
+		products = append(products, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error {
+	r.db.RLock()
+	var products []domain.Product
+	for _, p := range r.db.Products {
+		if includeDeleted || p.DeletedAt == nil {
+			products = append(products, p)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, p := range products {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Products[i].DeletedAt != nil {
+		return domain.Product{}, sql.ErrNoRows
+	}
+	return r.db.Products[i], nil
+}
+
+func (r *repository) Exists(ctx context.Context, productCode string) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, p := range r.db.Products {
+		if p.ProductCode == productCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) ExistingCodes(ctx context.Context, codes []string) (map[string]bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	wanted := map[string]bool{}
+	for _, code := range codes {
+		wanted[code] = true
+	}
+	existing := map[string]bool{}
+	for _, p := range r.db.Products {
+		if wanted[p.ProductCode] {
+			existing[p.ProductCode] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *repository) ExistingSellers(ctx context.Context, ids []int) (map[int]bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	existing := map[int]bool{}
+	for _, s := range r.db.Sellers {
+		if s.DeletedAt == nil {
+			existing[s.ID] = true
+		}
+	}
+	return wanted(existing, ids), nil
+}
+
+func (r *repository) ExistingProductTypes(ctx context.Context, ids []int) (map[int]bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	existing := map[int]bool{}
+	for _, t := range r.db.ProductTypes {
+		existing[t.ID] = true
+	}
+	return wanted(existing, ids), nil
+}
+
+// wanted returns which of ids are in existing.
+func wanted(existing map[int]bool, ids []int) map[int]bool {
+	found := map[int]bool{}
+	for _, id := range ids {
+		if existing[id] {
+			found[id] = true
+		}
+	}
+	return found
+}
+
+func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	return r.insert(p), nil
+}
+
+func (r *repository) SaveBatch(ctx context.Context, products []domain.Product) ([]int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	ids := make([]int, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, r.insert(p))
+	}
+	return ids, nil
+}
+
+func (r *repository) insert(p domain.Product) int {
+	p.ID = r.db.NextID("products")
+	p.Version = 1
+	p.DeletedAt = nil
+	r.db.Products = append(r.db.Products, p)
+	return p.ID
+}
+
+func (r *repository) Update(ctx context.Context, p domain.Product) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(p.ID)
+	if i < 0 || r.db.Products[i].Version != p.Version || r.db.Products[i].DeletedAt != nil {
+		return domain.ErrVersionConflict
+	}
+	p.Version++
+	r.db.Products[i] = p
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Products[i].DeletedAt != nil {
+		return product.ErrNotFound
+	}
+	if version != 0 && r.db.Products[i].Version != version {
+		return domain.ErrVersionConflict
+	}
+	if dependents := r.dependents(id); len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "product", ID: id, Dependents: dependents}
+	}
+	now := r.db.Now()
+	r.db.Products[i].DeletedAt = &now
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Products[i].DeletedAt == nil {
+		return product.ErrNotFound
+	}
+	r.db.Products[i].DeletedAt = nil
+	return nil
+}
+
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.dependents(id), nil
+}
+
+// dependents counts the records referencing the product id. The caller holds
+// the lock of r.db.
+func (r *repository) dependents(id int) []domain.Dependent {
+	batches := 0
+	for _, b := range r.db.ProductBatches {
+		if b.ProductId == id {
+			batches++
+		}
+	}
+	return domain.NewDependents([]string{"product_batches"}, []int{batches})
+}
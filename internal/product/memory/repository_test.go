@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSeededRepository() product.Repository {
+	db := memdb.New()
+	memdb.Seed(db)
+	return NewRepository(db)
+}
+
+func TestSaveProductMemory(t *testing.T) {
+	repo := newSeededRepository()
+
+	id, err := repo.Save(context.TODO(), domain.Product{ProductCode: "NEW-1", SellerID: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 6, id)
+	p, err := repo.Get(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, p.Version)
+	assert.True(t, repo.Exists(context.TODO(), "NEW-1"))
+}
+
+func TestUpdateProductMemoryVersionConflict(t *testing.T) {
+	repo := newSeededRepository()
+	p, _ := repo.Get(context.TODO(), 1)
+
+	assert.Nil(t, repo.Update(context.TODO(), p))
+	err := repo.Update(context.TODO(), p)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	updated, _ := repo.Get(context.TODO(), 1)
+	assert.Equal(t, 2, updated.Version)
+}
+
+func TestDeleteProductMemoryVersionConflict(t *testing.T) {
+	repo := newSeededRepository()
+	p, _ := repo.Get(context.TODO(), 1)
+	assert.Nil(t, repo.Update(context.TODO(), p))
+
+	assert.ErrorIs(t, repo.Delete(context.TODO(), 1, p.Version), domain.ErrVersionConflict)
+	_, err := repo.Get(context.TODO(), 1)
+	assert.Nil(t, err, "the product is still there")
+	assert.Nil(t, repo.Delete(context.TODO(), 1, p.Version+1))
+}
+
+func TestDeleteAndRestoreProductMemory(t *testing.T) {
+	repo := newSeededRepository()
+
+	assert.Nil(t, repo.Delete(context.TODO(), 1, 0))
+	_, err := repo.Get(context.TODO(), 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.ErrorIs(t, repo.Delete(context.TODO(), 1, 0), product.ErrNotFound)
+	all, _ := repo.GetAll(context.TODO(), false)
+	assert.Len(t, all, 4)
+	all, _ = repo.GetAll(context.TODO(), true)
+	assert.Len(t, all, 5)
+	assert.NotNil(t, all[0].DeletedAt)
+
+	assert.Nil(t, repo.Restore(context.TODO(), 1))
+	assert.ErrorIs(t, repo.Restore(context.TODO(), 1), product.ErrNotFound)
+	_, err = repo.Get(context.TODO(), 1)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,90 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "product".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("product"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (m *measuredRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error {
+	defer m.timer.Since("StreamAll", time.Now())
+	return m.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Product, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, productCode string) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, productCode)
+}
+
+func (m *measuredRepository) ExistingCodes(ctx context.Context, codes []string) (map[string]bool, error) {
+	defer m.timer.Since("ExistingCodes", time.Now())
+	return m.Repository.ExistingCodes(ctx, codes)
+}
+
+func (m *measuredRepository) ExistingSellers(ctx context.Context, ids []int) (map[int]bool, error) {
+	defer m.timer.Since("ExistingSellers", time.Now())
+	return m.Repository.ExistingSellers(ctx, ids)
+}
+
+func (m *measuredRepository) ExistingProductTypes(ctx context.Context, ids []int) (map[int]bool, error) {
+	defer m.timer.Since("ExistingProductTypes", time.Now())
+	return m.Repository.ExistingProductTypes(ctx, ids)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, p domain.Product) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, p)
+}
+
+func (m *measuredRepository) SaveBatch(ctx context.Context, products []domain.Product) ([]int, error) {
+	defer m.timer.Since("SaveBatch", time.Now())
+	return m.Repository.SaveBatch(ctx, products)
+}
+
+func (m *measuredRepository) Update(ctx context.Context, p domain.Product) error {
+	defer m.timer.Since("Update", time.Now())
+	return m.Repository.Update(ctx, p)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, id, version int) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, id, version)
+}
+
+func (m *measuredRepository) Restore(ctx context.Context, id int) error {
+	defer m.timer.Since("Restore", time.Now())
+	return m.Repository.Restore(ctx, id)
+}
+
+func (m *measuredRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	defer m.timer.Since("Dependents", time.Now())
+	return m.Repository.Dependents(ctx, id)
+}
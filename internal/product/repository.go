@@ -3,53 +3,93 @@ package product
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a Product.
 type Repository interface {
-	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error
 	Get(ctx context.Context, id int) (domain.Product, error)
 	Exists(ctx context.Context, productCode string) bool
+	ExistingCodes(ctx context.Context, codes []string) (map[string]bool, error)
+	ExistingSellers(ctx context.Context, ids []int) (map[int]bool, error)
+	ExistingProductTypes(ctx context.Context, ids []int) (map[int]bool, error)
 	Save(ctx context.Context, p domain.Product) (int, error)
+	SaveBatch(ctx context.Context, products []domain.Product) ([]int, error)
 	Update(ctx context.Context, p domain.Product) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) ([]domain.Dependent, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
-	query := "SELECT * FROM products;"
-	rows, err := r.db.Query(query)
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.StreamAll(ctx, includeDeleted, func(p domain.Product) error {
+		products = append(products, p)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var products []domain.Product
+	return products, nil
+}
+
+// StreamAll calls fn with each product as it is read from the database,
+// stopping at the first error fn returns.
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error {
+	query := queries.ProductGetAllQuery
+	if !includeDeleted {
+		query += queries.ProductNotDeleted
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		p := domain.Product{}
-		_ = rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
-		products = append(products, p)
+		if err := rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, &p.Version, &p.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
 	}
 
-	return products, nil
+	return rows.Err()
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
-	query := "SELECT * FROM products WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductGetQuery)
+	if err != nil {
+		return domain.Product{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
 	p := domain.Product{}
-	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+	err = row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, &p.Version, &p.DeletedAt)
 	if err != nil {
 		return domain.Product{}, err
 	}
@@ -58,20 +98,129 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, productCode string) bool {
-	query := "SELECT product_code FROM products WHERE product_code=?;"
-	row := r.db.QueryRow(query, productCode)
-	err := row.Scan(&productCode)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, productCode)
+	err = row.Scan(&productCode)
 	return err == nil
 }
 
+// ExistingCodes returns which of codes are already used by a product, looking
+// them up BulkBatchSize at a time.
+func (r *repository) ExistingCodes(ctx context.Context, codes []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	for from := 0; from < len(codes); from += domain.BulkBatchSize {
+		to := from + domain.BulkBatchSize
+		if to > len(codes) {
+			to = len(codes)
+		}
+		args := make([]interface{}, 0, to-from)
+		for _, code := range codes[from:to] {
+			args = append(args, code)
+		}
+		query := queries.ProductExistingCodesQuery + strings.Repeat(",?", len(args)-1) + ")"
+		rows, err := storage.ExecutorOf(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var code string
+			if err := rows.Scan(&code); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			existing[code] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// ExistingSellers returns which of ids are sellers that exist and are not
+// deleted, looking them up BulkBatchSize at a time.
+func (r *repository) ExistingSellers(ctx context.Context, ids []int) (map[int]bool, error) {
+	return r.existingIDs(ctx, queries.ProductExistingSellersQuery, ids)
+}
+
+// ExistingProductTypes returns which of ids are product types that exist,
+// looking them up BulkBatchSize at a time.
+func (r *repository) ExistingProductTypes(ctx context.Context, ids []int) (map[int]bool, error) {
+	return r.existingIDs(ctx, queries.ProductExistingProductTypesQuery, ids)
+}
+
+// existingIDs returns which of ids query, open after its first placeholder,
+// selects.
+func (r *repository) existingIDs(ctx context.Context, query string, ids []int) (map[int]bool, error) {
+	existing := map[int]bool{}
+	for from := 0; from < len(ids); from += domain.BulkBatchSize {
+		to := from + domain.BulkBatchSize
+		if to > len(ids) {
+			to = len(ids)
+		}
+		args := make([]interface{}, 0, to-from)
+		for _, id := range ids[from:to] {
+			args = append(args, id)
+		}
+		rows, err := storage.ExecutorOf(ctx, r.db).QueryContext(ctx, query+strings.Repeat(",?", len(args)-1)+")", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			existing[id] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// SaveBatch inserts products in a single transaction, or in a savepoint
+// of the one ctx carries, and returns their ids.
+func (r *repository) SaveBatch(ctx context.Context, products []domain.Product) ([]int, error) {
+	ids := make([]int, 0, len(products))
+	err := storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		stmt, err := r.stmts.Prepare(ctx, queries.ProductSaveQuery)
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			res, err := stmt.ExecContext(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+			if err != nil {
+				return err
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			ids = append(ids, int(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
-	query := "INSERT INTO products(description,expiration_rate,freezing_rate,height,lenght,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductSaveQuery)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+	res, err := stmt.ExecContext(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
 	if err != nil {
 		return 0, err
 	}
@@ -85,33 +234,79 @@ func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, p domain.Product) error {
-	query := "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, lenght=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, id_product_type=?, id_seller=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductUpdateQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID)
+	res, err := stmt.ExecContext(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID, p.Version)
 	if err != nil {
 		return err
 	}
 
-	_, err = res.RowsAffected()
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return domain.ErrVersionConflict
+	}
+
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM products WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+// Delete soft deletes the product unless records still reference it, which
+// returns a *domain.DependentsError. The references are counted after the
+// delete, in its transaction, which is rolled back when there are any. A
+// version other than 0 deletes the product only at that version, returning
+// domain.ErrVersionConflict otherwise.
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	return storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		query, args := queries.ProductDeleteQuery, []interface{}{id}
+		if version != 0 {
+			query, args = queries.ProductDeleteVersionQuery, []interface{}{id, version}
+		}
+		stmt, err := r.stmts.Prepare(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		res, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affect < 1 && version != 0 {
+			return domain.ErrVersionConflict
+		}
+		if affect < 1 {
+			return ErrNotFound
+		}
+
+		dependents, err := r.Dependents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return &domain.DependentsError{Entity: "product", ID: id, Dependents: dependents}
+		}
+		return nil
+	})
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductRestoreQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -127,3 +322,18 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// Dependents counts the records that still reference the product.
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductDependentsQuery)
+	if err != nil {
+		return nil, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
+	counts := make([]int, 1)
+	if err := row.Scan(&counts[0]); err != nil {
+		return nil, err
+	}
+
+	return domain.NewDependents([]string{"product_batches"}, counts), nil
+}
@@ -3,8 +3,10 @@ package product
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
 )
 
 // Errors
@@ -13,12 +15,15 @@ var (
 )
 
 type Service interface {
-	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error
 	Exists(ctx context.Context, productCode string) bool
 	Get(ctx context.Context, id int) (domain.Product, error)
 	Save(ctx context.Context, p domain.Product) (int, error)
+	Import(ctx context.Context, products []domain.Product, dryRun bool) ([]domain.BulkResult, error)
 	Update(ctx context.Context, p domain.Product) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
 }
 
 type service struct {
@@ -32,8 +37,13 @@ func NewService(r Repository) Service {
 }
 
 //	Get all 'products'
-func (s *service) GetAll(ctx context.Context) ([]domain.Product, error) {
-	return s.repository.GetAll(ctx)
+func (s *service) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error) {
+	return s.repository.GetAll(ctx, includeDeleted)
+}
+
+//	Call fn with each 'product' without loading them all
+func (s *service) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error {
+	return s.repository.StreamAll(ctx, includeDeleted, fn)
 }
 
 //	Get a 'product' with id
@@ -51,12 +61,65 @@ func (s *service) Save(ctx context.Context, p domain.Product) (int, error) {
 	return s.repository.Save(ctx, p)
 }
 
+// Import saves products in batched transactions, skipping those whose
+// product code is taken or whose seller or product type doesn't exist, and
+// reports the result of each in the same order. In a dry run nothing is
+// saved.
+func (s *service) Import(ctx context.Context, products []domain.Product, dryRun bool) ([]domain.BulkResult, error) {
+	codes := make([]string, len(products))
+	sellerIDs := make([]int, len(products))
+	typeIDs := make([]int, len(products))
+	for i, p := range products {
+		codes[i] = p.ProductCode
+		sellerIDs[i] = p.SellerID
+		typeIDs[i] = p.ProductTypeID
+	}
+	existing, err := s.repository.ExistingCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	sellers, err := s.repository.ExistingSellers(ctx, sellerIDs)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.repository.ExistingProductTypes(ctx, typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	invalid := make([]string, len(products))
+	for i, p := range products {
+		switch {
+		case !sellers[p.SellerID]:
+			invalid[i] = fmt.Sprintf("seller %d does not exist", p.SellerID)
+		case !types[p.ProductTypeID]:
+			invalid[i] = fmt.Sprintf("product type %d does not exist", p.ProductTypeID)
+		}
+	}
+	return domain.BulkImport(codes, existing, invalid, dryRun, func(rows []int) ([]int, error) {
+		batch := make([]domain.Product, len(rows))
+		for j, i := range rows {
+			batch[j] = products[i]
+		}
+		ids, err := s.repository.SaveBatch(ctx, batch)
+		if err != nil {
+			web.Logger(ctx).ErrorContext(ctx, "saving imported products failed", "error", err)
+		}
+		return ids, err
+	}), nil
+}
+
 //	Update a 'product'
 func (s *service) Update(ctx context.Context, p domain.Product) error {
 	return s.repository.Update(ctx, p)
 }
 
 //	Delete a 'product'
-func (s *service) Delete(ctx context.Context, id int) error {
-	return s.repository.Delete(ctx, id)
+func (s *service) Delete(ctx context.Context, id, version int) error {
+	return s.repository.Delete(ctx, id, version)
+}
+
+// Restore brings back a deleted product. It returns ErrNotFound when the product
+// does not exist or is not deleted.
+func (s *service) Restore(ctx context.Context, id int) error {
+	return s.repository.Restore(ctx, id)
 }
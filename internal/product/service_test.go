@@ -49,7 +49,7 @@ func TestFindAll(t *testing.T) {
 	service := NewService(repository)
 	// Test Execution
 	ctx := context.TODO()
-	_, err := service.GetAll(ctx)
+	_, err := service.GetAll(ctx, false)
 	// Validation
 	assert.Nil(t, err)
 }
@@ -101,7 +101,7 @@ func TestUpdateExistent(t *testing.T) {
 	// Test Execution
 	ctx := context.TODO()
 	err := service.Update(ctx, mocks.MockUpdateProduct)
-	db, _ := service.GetAll(ctx)
+	db, _ := service.GetAll(ctx, false)
 	// Validation
 	assert.Nil(t, err)
 	assert.NotEqual(t, db[1], mocks.MockUpdateProduct)
@@ -130,7 +130,7 @@ func TestDeleteNonExistent(t *testing.T) {
 	// Test Execution
 	idSelected := 5
 	ctx := context.TODO()
-	err := service.Delete(ctx, idSelected)
+	err := service.Delete(ctx, idSelected, 0)
 	// Validation
 	assert.NotNil(t, err)
 }
@@ -145,8 +145,8 @@ func TestDelete(t *testing.T) {
 	// Test Execution
 	idSelected := 1
 	ctx := context.TODO()
-	err := service.Delete(ctx, idSelected)
-	dataAfterDeleted, _ := repository.GetAll(ctx)
+	err := service.Delete(ctx, idSelected, 0)
+	dataAfterDeleted, _ := repository.GetAll(ctx, false)
 
 	// Validation
 	assert.Nil(t, err)
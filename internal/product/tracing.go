@@ -0,0 +1,170 @@
+package product
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "product.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("product.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Product, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedService) Exists(ctx context.Context, productCode string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Service.Exists(ctx, productCode)
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.Product, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Save(ctx context.Context, p domain.Product) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, p)
+}
+
+func (t *tracedService) Import(ctx context.Context, products []domain.Product, dryRun bool) (_ []domain.BulkResult, err error) {
+	ctx, span := t.tracer.Start(ctx, "Import")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Import(ctx, products, dryRun)
+}
+
+func (t *tracedService) Update(ctx context.Context, p domain.Product) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Update(ctx, p)
+}
+
+func (t *tracedService) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Delete(ctx, id, version)
+}
+
+func (t *tracedService) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Restore(ctx, id)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "product.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("product.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Product, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Product, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, productCode string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, productCode)
+}
+
+func (t *tracedRepository) ExistingCodes(ctx context.Context, codes []string) (_ map[string]bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "ExistingCodes")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ExistingCodes(ctx, codes)
+}
+
+func (t *tracedRepository) ExistingSellers(ctx context.Context, ids []int) (_ map[int]bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "ExistingSellers")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ExistingSellers(ctx, ids)
+}
+
+func (t *tracedRepository) ExistingProductTypes(ctx context.Context, ids []int) (_ map[int]bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "ExistingProductTypes")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ExistingProductTypes(ctx, ids)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, p domain.Product) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, p)
+}
+
+func (t *tracedRepository) SaveBatch(ctx context.Context, products []domain.Product) (_ []int, err error) {
+	ctx, span := t.tracer.Start(ctx, "SaveBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SaveBatch(ctx, products)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, p domain.Product) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Update(ctx, p)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, id, version)
+}
+
+func (t *tracedRepository) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Restore(ctx, id)
+}
+
+func (t *tracedRepository) Dependents(ctx context.Context, id int) (_ []domain.Dependent, err error) {
+	ctx, span := t.tracer.Start(ctx, "Dependents")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Dependents(ctx, id)
+}
@@ -0,0 +1,33 @@
+package product_batch
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "product_batch"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Save is recorded in the audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Save(ctx context.Context, pb domain.ProductBatches) (int, error) {
+	id, err := a.Service.Save(ctx, pb)
+	if err != nil {
+		return id, err
+	}
+	pb.Id = id
+	a.audit.Record(ctx, auditEntity, id, audit.ActionCreate, nil, pb)
+	return id, nil
+}
@@ -0,0 +1,38 @@
+package product_batch
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save publishes ProductBatchReceived events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, pb domain.ProductBatches) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, pb)
+		if err != nil {
+			return err
+		}
+		pb.Id = id
+		return e.outbox.Publish(ctx, domain.ProductBatchReceived{ProductBatch: pb})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pb.Id, nil
+}
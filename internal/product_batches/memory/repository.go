@@ -0,0 +1,51 @@
+// Package memory implements product_batch.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	product_batch "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product_batches"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a product_batch.Repository that keeps the product
+// batches in db.
+func NewRepository(db *memdb.DB) product_batch.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) sectionExists(id int) bool {
+	for _, s := range r.db.Sections {
+		if s.ID == id {
+			return s.DeletedAt == nil
+		}
+	}
+	return false
+}
+
+func (r *repository) Save(ctx context.Context, pd domain.ProductBatches) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if p, ok := r.db.Product(pd.ProductId); !ok || p.DeletedAt != nil {
+		return 0, fmt.Errorf("product with id: %d doesnt exists", pd.ProductId)
+	}
+	if !r.sectionExists(pd.SectionId) {
+		return 0, fmt.Errorf("section with id: %d doesnt exists", pd.SectionId)
+	}
+	pd.Id = r.db.NextID("product_batches")
+	pd.DueDate = memdb.Datetime(pd.DueDate)
+	pd.ManufacturingDate = memdb.Datetime(pd.ManufacturingDate)
+	pd.ManufacturingHour = memdb.Datetime(pd.ManufacturingHour)
+	r.db.ProductBatches = append(r.db.ProductBatches, pd)
+	return pd.Id, nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a section.
@@ -14,26 +16,34 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
 func (r *repository) sectionExists(ctx context.Context, section_id int) bool {
-	query := "SELECT id FROM sections WHERE id=?;"
-	row := r.db.QueryRow(query, section_id)
-	err := row.Scan(&section_id)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductBatchSectionExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, section_id)
+	err = row.Scan(&section_id)
 	return err == nil
 }
 
 func (r *repository) productExists(ctx context.Context, product_id int) bool {
-	query := "SELECT id FROM products WHERE id=?;"
-	row := r.db.QueryRow(query, product_id)
-	err := row.Scan(&product_id)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductBatchProductExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, product_id)
+	err = row.Scan(&product_id)
 	return err == nil
 }
 
@@ -44,26 +54,12 @@ func (r *repository) Save(ctx context.Context, pd domain.ProductBatches) (int, e
 	if !r.sectionExists(ctx, pd.SectionId) {
 		return 0, fmt.Errorf("section with id: %d doesnt exists", pd.SectionId)
 	}
-	query := "INSERT INTO product_batches " +
-		"(" +
-		"batch_number, " +
-		"current_quantity, " +
-		"current_temperature, " +
-		"due_date, " +
-		"initial_quantity, " +
-		"manufacturing_date, " +
-		"manufacturing_hour, " +
-		"minimum_temperature, " +
-		"product_id, " +
-		"section_id" +
-		")" +
-		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.ProductBatchSaveQuery)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(
+	res, err := stmt.ExecContext(ctx,
 		&pd.BatchNumber,
 		&pd.CurrentQuantity,
 		&pd.CurrentTemperature,
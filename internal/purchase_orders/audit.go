@@ -0,0 +1,49 @@
+package purchaseOrder
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "purchase_order"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Save and UpdateStatus are recorded in the
+// audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Save(ctx context.Context, p domain.PurchaseOrders) (int, error) {
+	id, err := a.Service.Save(ctx, p)
+	if err != nil {
+		return id, err
+	}
+	p.ID = id
+	a.audit.Record(ctx, auditEntity, id, audit.ActionCreate, nil, p)
+	return id, nil
+}
+
+// UpdateStatus records the order status before and after a change.
+func (a *auditedService) UpdateStatus(ctx context.Context, id, statusID int) (int, error) {
+	previous, err := a.Service.UpdateStatus(ctx, id, statusID)
+	if err != nil || previous == statusID {
+		return previous, err
+	}
+	a.audit.Record(ctx, auditEntity, id, audit.ActionUpdate, statusOf(previous), statusOf(statusID))
+	return previous, nil
+}
+
+// statusOf is the state of a purchase order an order status change records.
+func statusOf(statusID int) map[string]int {
+	return map[string]int{"order_status_id": statusID}
+}
@@ -0,0 +1,56 @@
+package purchaseOrder
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save and UpdateStatus publish
+// PurchaseOrderCreated and PurchaseOrderStatusChanged events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, po domain.PurchaseOrders) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, po)
+		if err != nil {
+			return err
+		}
+		po.ID = id
+		return e.outbox.Publish(ctx, domain.PurchaseOrderCreated{PurchaseOrder: po})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return po.ID, nil
+}
+
+// UpdateStatus publishes nothing when the purchase order already had the
+// status.
+func (e *eventedService) UpdateStatus(ctx context.Context, id, statusID int) (int, error) {
+	var previous int
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		if previous, err = e.Service.UpdateStatus(ctx, id, statusID); err != nil || previous == statusID {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.PurchaseOrderStatusChanged{ID: id, FromStatusID: previous, ToStatusID: statusID})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return previous, nil
+}
@@ -0,0 +1,94 @@
+// Package memory implements purchaseOrder.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	purchaseOrder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/purchase_orders"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a purchaseOrder.Repository that keeps the purchase
+// orders and their details in db.
+func NewRepository(db *memdb.DB) purchaseOrder.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Exists(ctx context.Context, orderNumber string) (bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, po := range r.db.PurchaseOrders {
+		if po.OrderNumber == orderNumber {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Save inserts the purchase order and the order detail of its product
+// record.
+func (r *repository) Save(ctx context.Context, po domain.PurchaseOrders) (int, error) {
+	if po.OrderNumber == "" {
+		return 0, fmt.Errorf("error: order_number empty")
+	}
+
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	po.ID = r.db.NextID("purchase_orders")
+	po.OrderDate = memdb.Datetime(po.OrderDate)
+	r.db.PurchaseOrders = append(r.db.PurchaseOrders, po)
+	r.db.OrderDetails = append(r.db.OrderDetails, memdb.OrderDetail{
+		ID:              r.db.NextID("order_details"),
+		ProductRecordID: po.ProductRecordId,
+		PurchaseOrderID: po.ID,
+		Quantity:        po.Quantity,
+	})
+	return po.ID, nil
+}
+
+func (r *repository) Status(ctx context.Context, id int) (int, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	po, ok := r.db.PurchaseOrder(id)
+	if !ok {
+		return 0, purchaseOrder.ErrNotFound
+	}
+	return po.OrderStatusId, nil
+}
+
+func (r *repository) StatusExists(ctx context.Context, statusID int) (bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, os := range r.db.OrderStatuses {
+		if os.ID == statusID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *repository) UpdateStatus(ctx context.Context, id, statusID int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	for i := range r.db.PurchaseOrders {
+		if r.db.PurchaseOrders[i].ID == id {
+			r.db.PurchaseOrders[i].OrderStatusId = statusID
+			return nil
+		}
+	}
+	return purchaseOrder.ErrNotFound
+}
@@ -0,0 +1,50 @@
+package purchaseOrder
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped
+// Repository takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "purchase_orders".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("purchase_orders"),
+	}
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, orderNumber string) (bool, error) {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, orderNumber)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, b domain.PurchaseOrders) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, b)
+}
+
+func (m *measuredRepository) Status(ctx context.Context, id int) (int, error) {
+	defer m.timer.Since("Status", time.Now())
+	return m.Repository.Status(ctx, id)
+}
+
+func (m *measuredRepository) StatusExists(ctx context.Context, statusID int) (bool, error) {
+	defer m.timer.Since("StatusExists", time.Now())
+	return m.Repository.StatusExists(ctx, statusID)
+}
+
+func (m *measuredRepository) UpdateStatus(ctx context.Context, id, statusID int) error {
+	defer m.timer.Since("UpdateStatus", time.Now())
+	return m.Repository.UpdateStatus(ctx, id, statusID)
+}
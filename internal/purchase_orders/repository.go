@@ -3,36 +3,42 @@ package purchaseOrder
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 type Repository interface {
 	Exists(ctx context.Context, orderNumber string) (bool, error)
 	Save(ctx context.Context, b domain.PurchaseOrders) (int, error)
+	// Status returns the order status of the purchase order, or ErrNotFound.
+	Status(ctx context.Context, id int) (int, error)
+	StatusExists(ctx context.Context, statusID int) (bool, error)
+	UpdateStatus(ctx context.Context, id, statusID int) error
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
 func (r *repository) Exists(ctx context.Context, orderNumber string) (bool, error) {
 	query := queries.PurchaseOrderSelectOrderNumber
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, query)
 	if err != nil {
 		return false, err
 	}
 
-	defer stmt.Close()
-
 	row := stmt.QueryRowContext(ctx, orderNumber)
 	err = row.Scan(&orderNumber)
 	if err == sql.ErrNoRows {
@@ -49,11 +55,10 @@ func (r *repository) Save(ctx context.Context, po domain.PurchaseOrders) (int, e
 	}
 
 	query := queries.PurchaseOrderInsertIntoPO
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, query)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
 
 	res, err := stmt.ExecContext(ctx, &po.OrderNumber, &po.OrderDate, &po.TrackingCode, &po.BuyerId, &po.OrderStatusId)
 	if err != nil {
@@ -66,15 +71,62 @@ func (r *repository) Save(ctx context.Context, po domain.PurchaseOrders) (int, e
 	}
 
 	query = queries.PurchaseOrderInsertIntoOD
-	stmt, err = r.db.Prepare(query)
+	stmt, err = r.stmts.Prepare(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err = stmt.ExecContext(ctx, &po.ProductRecordId)
+	_, err = stmt.ExecContext(ctx, &po.ProductRecordId, id, &po.Quantity)
 	if err != nil {
 		return 0, err
 	}
 
 	return int(id), nil
 }
+
+func (r *repository) Status(ctx context.Context, id int) (int, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.PurchaseOrderStatusQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	var statusID int
+	err = stmt.QueryRowContext(ctx, id).Scan(&statusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return statusID, err
+}
+
+func (r *repository) StatusExists(ctx context.Context, statusID int) (bool, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.PurchaseOrderStatusExistsQuery)
+	if err != nil {
+		return false, err
+	}
+
+	err = stmt.QueryRowContext(ctx, statusID).Scan(&statusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *repository) UpdateStatus(ctx context.Context, id, statusID int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.PurchaseOrderUpdateStatusQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, statusID, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
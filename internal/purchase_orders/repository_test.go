@@ -34,7 +34,7 @@ func TestCreateOk(t *testing.T) {
 		ExpectPrepare(regexp.QuoteMeta(queries.PurchaseOrderInsertIntoOD))
 	mock.
 		ExpectExec(regexp.QuoteMeta(queries.PurchaseOrderInsertIntoOD)).
-		WithArgs(purchaseOrder.ProductRecordId).
+		WithArgs(purchaseOrder.ProductRecordId, 1, purchaseOrder.Quantity).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewRepository(db)
@@ -70,7 +70,7 @@ func TestCreateConflict(t *testing.T) {
 		ExpectPrepare(regexp.QuoteMeta(queries.PurchaseOrderInsertIntoOD))
 	mock.
 		ExpectExec(regexp.QuoteMeta(queries.PurchaseOrderInsertIntoOD)).
-		WithArgs(purchaseOrder.ProductRecordId).
+		WithArgs(purchaseOrder.ProductRecordId, 1, purchaseOrder.Quantity).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewRepository(db)
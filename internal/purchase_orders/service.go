@@ -2,13 +2,21 @@ package purchaseOrder
 
 import (
 	"context"
+	"errors"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
 )
 
+// Errors
+var (
+	ErrNotFound       = errors.New("purchase order not found")
+	ErrStatusNotFound = errors.New("order status not found")
+)
+
 type Service interface {
 	Exists(ctx context.Context, orderNumber string) (bool, error)
 	Save(ctx context.Context, b domain.PurchaseOrders) (int, error)
+	UpdateStatus(ctx context.Context, id, statusID int) (int, error)
 }
 
 type service struct {
@@ -31,3 +39,25 @@ func (s *service) Exists(ctx context.Context, orderNumber string) (bool, error)
 func (s *service) Save(ctx context.Context, po domain.PurchaseOrders) (int, error) {
 	return s.repository.Save(ctx, po)
 }
+
+// UpdateStatus moves the purchase order to the order status statusID and
+// returns the status it had. It returns ErrNotFound for an unknown purchase
+// order and ErrStatusNotFound for an unknown status.
+func (s *service) UpdateStatus(ctx context.Context, id, statusID int) (int, error) {
+	exists, err := s.repository.StatusExists(ctx, statusID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrStatusNotFound
+	}
+	previous, err := s.repository.Status(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	// MySQL counts no affected row when nothing changes.
+	if previous == statusID {
+		return previous, nil
+	}
+	return previous, s.repository.UpdateStatus(ctx, id, statusID)
+}
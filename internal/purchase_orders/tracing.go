@@ -0,0 +1,86 @@
+package purchaseOrder
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "purchase_orders.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("purchase_orders.Service"),
+	}
+}
+
+func (t *tracedService) Exists(ctx context.Context, orderNumber string) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Exists(ctx, orderNumber)
+}
+
+func (t *tracedService) Save(ctx context.Context, b domain.PurchaseOrders) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, b)
+}
+
+func (t *tracedService) UpdateStatus(ctx context.Context, id, statusID int) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.UpdateStatus(ctx, id, statusID)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "purchase_orders.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("purchase_orders.Repository"),
+	}
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, orderNumber string) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Exists(ctx, orderNumber)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, b domain.PurchaseOrders) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, b)
+}
+
+func (t *tracedRepository) Status(ctx context.Context, id int) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Status")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Status(ctx, id)
+}
+
+func (t *tracedRepository) StatusExists(ctx context.Context, statusID int) (_ bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "StatusExists")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StatusExists(ctx, statusID)
+}
+
+func (t *tracedRepository) UpdateStatus(ctx context.Context, id, statusID int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.UpdateStatus(ctx, id, statusID)
+}
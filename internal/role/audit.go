@@ -0,0 +1,46 @@
+package role
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+)
+
+// auditEntity is the entity name role assignments are recorded under. The
+// entity id is the user the role was assigned to or revoked from.
+const auditEntity = "user_role"
+
+type assignment struct {
+	UserID int `json:"user_id"`
+	RoleID int `json:"rol_id"`
+}
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Assign and Revoke are recorded in the
+// audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Assign(ctx context.Context, userID, roleID int) error {
+	if err := a.Service.Assign(ctx, userID, roleID); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, auditEntity, userID, audit.ActionCreate, nil, assignment{userID, roleID})
+	return nil
+}
+
+func (a *auditedService) Revoke(ctx context.Context, userID, roleID int) error {
+	if err := a.Service.Revoke(ctx, userID, roleID); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, auditEntity, userID, audit.ActionDelete, assignment{userID, roleID}, nil)
+	return nil
+}
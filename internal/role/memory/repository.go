@@ -0,0 +1,97 @@
+// Package memory implements role.Repository over the tables of a memdb.DB,
+// with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/role"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a role.Repository that keeps the roles and their
+// assignments in db.
+func NewRepository(db *memdb.DB) role.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Role, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return append([]domain.Role(nil), r.db.Roles...), nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Role, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, rl := range r.db.Roles {
+		if rl.ID == id {
+			return rl, nil
+		}
+	}
+	return domain.Role{}, role.ErrNotFound
+}
+
+func (r *repository) GetByUser(ctx context.Context, userID int) ([]domain.Role, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	var roles []domain.Role
+	for _, ur := range r.db.UserRoles {
+		if ur.UserID != userID {
+			continue
+		}
+		for _, rl := range r.db.Roles {
+			if rl.ID == ur.RoleID {
+				roles = append(roles, rl)
+			}
+		}
+	}
+	return roles, nil
+}
+
+func (r *repository) IsAssigned(ctx context.Context, userID, roleID int) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, ur := range r.db.UserRoles {
+		if ur.UserID == userID && ur.RoleID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) Assign(ctx context.Context, userID, roleID int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	r.db.UserRoles = append(r.db.UserRoles, memdb.UserRole{UserID: userID, RoleID: roleID})
+	return nil
+}
+
+func (r *repository) Revoke(ctx context.Context, userID, roleID int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	kept := r.db.UserRoles[:0]
+	for _, ur := range r.db.UserRoles {
+		if ur.UserID != userID || ur.RoleID != roleID {
+			kept = append(kept, ur)
+		}
+	}
+	if len(kept) == len(r.db.UserRoles) {
+		return role.ErrNotAssigned
+	}
+	r.db.UserRoles = kept
+	return nil
+}
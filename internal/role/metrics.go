@@ -0,0 +1,55 @@
+package role
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped
+// Repository takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "role".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("role"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context) ([]domain.Role, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Role, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) GetByUser(ctx context.Context, userID int) ([]domain.Role, error) {
+	defer m.timer.Since("GetByUser", time.Now())
+	return m.Repository.GetByUser(ctx, userID)
+}
+
+func (m *measuredRepository) IsAssigned(ctx context.Context, userID, roleID int) bool {
+	defer m.timer.Since("IsAssigned", time.Now())
+	return m.Repository.IsAssigned(ctx, userID, roleID)
+}
+
+func (m *measuredRepository) Assign(ctx context.Context, userID, roleID int) error {
+	defer m.timer.Since("Assign", time.Now())
+	return m.Repository.Assign(ctx, userID, roleID)
+}
+
+func (m *measuredRepository) Revoke(ctx context.Context, userID, roleID int) error {
+	defer m.timer.Since("Revoke", time.Now())
+	return m.Repository.Revoke(ctx, userID, roleID)
+}
@@ -0,0 +1,125 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
+)
+
+// Repository encapsulates the storage of roles and their assignment to users.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Role, error)
+	Get(ctx context.Context, id int) (domain.Role, error)
+	GetByUser(ctx context.Context, userID int) ([]domain.Role, error)
+	IsAssigned(ctx context.Context, userID, roleID int) bool
+	Assign(ctx context.Context, userID, roleID int) error
+	Revoke(ctx context.Context, userID, roleID int) error
+}
+
+type repository struct {
+	db    *sql.DB
+	stmts *storage.Statements
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db:    db,
+		stmts: storage.StatementsOf(db),
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Role, error) {
+	return r.query(ctx, queries.RoleGetAllQuery)
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Role, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.RoleGetQuery)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
+	rl := domain.Role{}
+	err = row.Scan(&rl.ID, &rl.Name, &rl.Description)
+	if err == sql.ErrNoRows {
+		return domain.Role{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Role{}, err
+	}
+
+	return rl, nil
+}
+
+func (r *repository) GetByUser(ctx context.Context, userID int) ([]domain.Role, error) {
+	return r.query(ctx, queries.RoleGetByUserQuery, userID)
+}
+
+func (r *repository) IsAssigned(ctx context.Context, userID, roleID int) bool {
+	stmt, err := r.stmts.Prepare(ctx, queries.RoleAssignedQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, userID, roleID)
+	err = row.Scan(&roleID)
+	return err == nil
+}
+
+func (r *repository) Assign(ctx context.Context, userID, roleID int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.RoleAssignQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, userID, roleID)
+	return err
+}
+
+func (r *repository) Revoke(ctx context.Context, userID, roleID int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.RoleRevokeQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, userID, roleID)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotAssigned
+	}
+
+	return nil
+}
+
+func (r *repository) query(ctx context.Context, query string, args ...interface{}) ([]domain.Role, error) {
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+
+	for rows.Next() {
+		rl := domain.Role{}
+		if err := rows.Scan(&rl.ID, &rl.Name, &rl.Description); err != nil {
+			return nil, err
+		}
+		roles = append(roles, rl)
+	}
+
+	return roles, rows.Err()
+}
@@ -0,0 +1,79 @@
+package role
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+// Role names stored in rol.rol_name.
+const (
+	Admin             = "admin"
+	WarehouseOperator = "warehouse_operator"
+	Sales             = "sales"
+)
+
+// Errors
+var (
+	ErrNotFound        = errors.New("role not found")
+	ErrAlreadyAssigned = errors.New("role already assigned to the user")
+	ErrNotAssigned     = errors.New("role not assigned to the user")
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.Role, error)
+	GetByUser(ctx context.Context, userID int) ([]domain.Role, error)
+	Names(ctx context.Context, userID int) ([]string, error)
+	Assign(ctx context.Context, userID, roleID int) error
+	Revoke(ctx context.Context, userID, roleID int) error
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{
+		repository: r,
+	}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.Role, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *service) GetByUser(ctx context.Context, userID int) ([]domain.Role, error) {
+	return s.repository.GetByUser(ctx, userID)
+}
+
+// Names returns the names of the roles assigned to the user, as carried in
+// its access token.
+func (s *service) Names(ctx context.Context, userID int) ([]string, error) {
+	roles, err := s.repository.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, rl := range roles {
+		names = append(names, rl.Name)
+	}
+	return names, nil
+}
+
+// Assign gives the role to the user. The role must exist and not be
+// assigned already.
+func (s *service) Assign(ctx context.Context, userID, roleID int) error {
+	if _, err := s.repository.Get(ctx, roleID); err != nil {
+		return err
+	}
+	if s.repository.IsAssigned(ctx, userID, roleID) {
+		return ErrAlreadyAssigned
+	}
+	return s.repository.Assign(ctx, userID, roleID)
+}
+
+func (s *service) Revoke(ctx context.Context, userID, roleID int) error {
+	return s.repository.Revoke(ctx, userID, roleID)
+}
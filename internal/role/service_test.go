@@ -0,0 +1,57 @@
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockRoleRepository() *mocks.MockRoleRepository {
+	return &mocks.MockRoleRepository{
+		MockRoles: []domain.Role{
+			{ID: 1, Name: Admin},
+			{ID: 2, Name: WarehouseOperator},
+			{ID: 3, Name: Sales},
+		},
+		MockAssignments: map[int][]int{1: {1}},
+		ErrNotFound:     ErrNotFound,
+		ErrNotAssigned:  ErrNotAssigned,
+	}
+}
+
+func TestAssignRoleOk(t *testing.T) {
+	s := NewService(newMockRoleRepository())
+
+	err := s.Assign(context.TODO(), 2, 3)
+	names, _ := s.Names(context.TODO(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{Sales}, names)
+}
+
+func TestAssignRoleAlreadyAssigned(t *testing.T) {
+	s := NewService(newMockRoleRepository())
+
+	err := s.Assign(context.TODO(), 1, 1)
+
+	assert.ErrorIs(t, err, ErrAlreadyAssigned)
+}
+
+func TestAssignNonExistentRole(t *testing.T) {
+	s := NewService(newMockRoleRepository())
+
+	err := s.Assign(context.TODO(), 1, 9)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRevokeNotAssignedRole(t *testing.T) {
+	s := NewService(newMockRoleRepository())
+
+	err := s.Revoke(context.TODO(), 1, 3)
+
+	assert.ErrorIs(t, err, ErrNotAssigned)
+}
@@ -0,0 +1,104 @@
+package role
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "role.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("role.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context) (_ []domain.Role, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx)
+}
+
+func (t *tracedService) GetByUser(ctx context.Context, userID int) (_ []domain.Role, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetByUser")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetByUser(ctx, userID)
+}
+
+func (t *tracedService) Names(ctx context.Context, userID int) (_ []string, err error) {
+	ctx, span := t.tracer.Start(ctx, "Names")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Names(ctx, userID)
+}
+
+func (t *tracedService) Assign(ctx context.Context, userID int, roleID int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Assign")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Assign(ctx, userID, roleID)
+}
+
+func (t *tracedService) Revoke(ctx context.Context, userID int, roleID int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Revoke")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Revoke(ctx, userID, roleID)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "role.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("role.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context) (_ []domain.Role, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Role, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) GetByUser(ctx context.Context, userID int) (_ []domain.Role, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetByUser")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetByUser(ctx, userID)
+}
+
+func (t *tracedRepository) IsAssigned(ctx context.Context, userID int, roleID int) bool {
+	ctx, span := t.tracer.Start(ctx, "IsAssigned")
+	defer span.End()
+	return t.Repository.IsAssigned(ctx, userID, roleID)
+}
+
+func (t *tracedRepository) Assign(ctx context.Context, userID int, roleID int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Assign")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Assign(ctx, userID, roleID)
+}
+
+func (t *tracedRepository) Revoke(ctx context.Context, userID int, roleID int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Revoke")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Revoke(ctx, userID, roleID)
+}
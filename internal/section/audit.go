@@ -0,0 +1,62 @@
+package section
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "section"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Save, Update, Delete and Restore are
+// recorded in the audit log.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Save(ctx context.Context, s domain.Section) (int, error) {
+	id, err := a.Service.Save(ctx, s)
+	if err != nil {
+		return id, err
+	}
+	s.ID = id
+	a.audit.Record(ctx, auditEntity, id, audit.ActionCreate, nil, s)
+	return id, nil
+}
+
+func (a *auditedService) Update(ctx context.Context, s domain.Section) (domain.Section, error) {
+	before, beforeErr := a.Service.Get(ctx, s.ID)
+	updated, err := a.Service.Update(ctx, s)
+	if err != nil {
+		return updated, err
+	}
+	a.audit.Record(ctx, auditEntity, s.ID, audit.ActionUpdate, audit.State(before, beforeErr), updated)
+	return updated, nil
+}
+
+func (a *auditedService) Delete(ctx context.Context, id, version int) error {
+	before, beforeErr := a.Service.Get(ctx, id)
+	if err := a.Service.Delete(ctx, id, version); err != nil {
+		return err
+	}
+	a.audit.Record(ctx, auditEntity, id, audit.ActionDelete, audit.State(before, beforeErr), nil)
+	return nil
+}
+
+func (a *auditedService) Restore(ctx context.Context, id int) error {
+	if err := a.Service.Restore(ctx, id); err != nil {
+		return err
+	}
+	after, err := a.Service.Get(ctx, id)
+	a.audit.Record(ctx, auditEntity, id, audit.ActionRestore, nil, audit.State(after, err))
+	return nil
+}
@@ -0,0 +1,49 @@
+package section
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/cache"
+)
+
+// ProductReportsCacheKey prefixes the cache keys of the product reports, to
+// drop them when the data they are computed from changes.
+const ProductReportsCacheKey = "section:report_products:"
+
+// cachedService serves the product reports of the wrapped Service from a
+// cache.
+type cachedService struct {
+	Service
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedService wraps s so its product reports are kept in c for ttl.
+// They are not dropped on changes by themselves: whoever changes sections or
+// product batches must delete the keys under ProductReportsCacheKey.
+func NewCachedService(s Service, c cache.Cache, ttl time.Duration) Service {
+	return &cachedService{
+		Service: s,
+		cache:   c,
+		ttl:     ttl,
+	}
+}
+
+func (s *cachedService) ReportProductsGetAll(ctx context.Context) (reports []domain.ProductReport, err error) {
+	err = cache.Fetch(ctx, s.cache, ProductReportsCacheKey+"all", s.ttl, &reports, func() error {
+		reports, err = s.Service.ReportProductsGetAll(ctx)
+		return err
+	})
+	return reports, err
+}
+
+func (s *cachedService) ReportProductsGet(ctx context.Context, id int) (report domain.ProductReport, err error) {
+	err = cache.Fetch(ctx, s.cache, ProductReportsCacheKey+strconv.Itoa(id), s.ttl, &report, func() error {
+		report, err = s.Service.ReportProductsGet(ctx, id)
+		return err
+	})
+	return report, err
+}
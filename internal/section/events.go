@@ -0,0 +1,80 @@
+package section
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save, Update, Delete and Restore publish
+// SectionCreated, SectionUpdated, SectionDeleted and SectionRestored events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, s domain.Section) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, s)
+		if err != nil {
+			return err
+		}
+		s.ID = id
+		return e.outbox.Publish(ctx, domain.SectionCreated{Section: s})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return s.ID, nil
+}
+
+func (e *eventedService) Update(ctx context.Context, s domain.Section) (domain.Section, error) {
+	var updated domain.Section
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = e.Service.Update(ctx, s); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, s.ID)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.SectionUpdated{Section: after})
+	})
+	if err != nil {
+		return domain.Section{}, err
+	}
+	return updated, nil
+}
+
+func (e *eventedService) Delete(ctx context.Context, id, version int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.SectionDeleted{ID: id})
+	})
+}
+
+func (e *eventedService) Restore(ctx context.Context, id int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Restore(ctx, id); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.SectionRestored{Section: after})
+	})
+}
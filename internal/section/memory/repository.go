@@ -0,0 +1,229 @@
+// Package memory implements section.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/section"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a section.Repository that keeps the sections in db.
+func NewRepository(db *memdb.DB) section.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the section with id, or -1.
+func (r *repository) index(id int) int {
+	for i, s := range r.db.Sections {
+		if s.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
+	var sections []domain.Section
+	err := r.StreamAll(ctx, includeDeleted, func(s domain.Section) error {
+		sections = append(sections, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	r.db.RLock()
+	var sections []domain.Section
+	for _, s := range r.db.Sections {
+		if includeDeleted || s.DeletedAt == nil {
+			sections = append(sections, s)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, s := range sections {
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sections[i].DeletedAt != nil {
+		return domain.Section{}, sql.ErrNoRows
+	}
+	return r.db.Sections[i], nil
+}
+
+func (r *repository) Exists(ctx context.Context, sectionNumber int) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, s := range r.db.Sections {
+		if s.SectionNumber == sectionNumber {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	s.ID = r.db.NextID("sections")
+	s.Version = 1
+	s.DeletedAt = nil
+	r.db.Sections = append(r.db.Sections, s)
+	return s.ID, nil
+}
+
+func (r *repository) Update(ctx context.Context, s domain.Section) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(s.ID)
+	if i < 0 || r.db.Sections[i].Version != s.Version || r.db.Sections[i].DeletedAt != nil {
+		return domain.ErrVersionConflict
+	}
+	s.Version++
+	r.db.Sections[i] = s
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sections[i].DeletedAt != nil {
+		return section.ErrNotFound
+	}
+	if version != 0 && r.db.Sections[i].Version != version {
+		return domain.ErrVersionConflict
+	}
+	if dependents := r.dependents(id); len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "section", ID: id, Dependents: dependents}
+	}
+	now := r.db.Now()
+	r.db.Sections[i].DeletedAt = &now
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sections[i].DeletedAt == nil {
+		return section.ErrNotFound
+	}
+	r.db.Sections[i].DeletedAt = nil
+	return nil
+}
+
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.dependents(id), nil
+}
+
+// dependents counts the records referencing the section id. The caller holds
+// the lock of r.db.
+func (r *repository) dependents(id int) []domain.Dependent {
+	batches := 0
+	for _, b := range r.db.ProductBatches {
+		if b.SectionId == id {
+			batches++
+		}
+	}
+	return domain.NewDependents([]string{"product_batches"}, []int{batches})
+}
+
+// report sums the quantity of the batches of the section s, and tells
+// whether it has any; sections without batches are left out of the reports
+// like the inner join of the query leaves them out.
+func (r *repository) report(s domain.Section) (domain.ProductReport, bool) {
+	report := domain.ProductReport{SectionId: s.ID, SectionNumber: s.SectionNumber}
+	found := false
+	for _, b := range r.db.ProductBatches {
+		if b.SectionId != s.ID {
+			continue
+		}
+		if _, ok := r.db.Product(b.ProductId); !ok {
+			continue
+		}
+		report.ProductCount += b.CurrentQuantity
+		found = true
+	}
+	return report, found
+}
+
+func (r *repository) ReportProductsAll(ctx context.Context) ([]domain.ProductReport, error) {
+	var reports []domain.ProductReport
+	err := r.StreamReportProducts(ctx, func(report domain.ProductReport) error {
+		reports = append(reports, report)
+		return nil
+	})
+	if err != nil {
+		return []domain.ProductReport{}, err
+	}
+
+	return reports, nil
+}
+
+func (r *repository) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	r.db.RLock()
+	var reports []domain.ProductReport
+	for _, s := range r.db.Sections {
+		if s.DeletedAt != nil {
+			continue
+		}
+		if report, ok := r.report(s); ok {
+			reports = append(reports, report)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, report := range reports {
+		if err := fn(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sections[i].DeletedAt != nil {
+		return domain.ProductReport{}, sql.ErrNoRows
+	}
+	report, ok := r.report(r.db.Sections[i])
+	if !ok {
+		return domain.ProductReport{}, sql.ErrNoRows
+	}
+	return report, nil
+}
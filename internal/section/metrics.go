@@ -0,0 +1,85 @@
+package section
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "section".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("section"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (m *measuredRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	defer m.timer.Since("StreamAll", time.Now())
+	return m.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Section, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, cid int) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, cid)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, s domain.Section) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, s)
+}
+
+func (m *measuredRepository) Update(ctx context.Context, s domain.Section) error {
+	defer m.timer.Since("Update", time.Now())
+	return m.Repository.Update(ctx, s)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, id, version int) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, id, version)
+}
+
+func (m *measuredRepository) Restore(ctx context.Context, id int) error {
+	defer m.timer.Since("Restore", time.Now())
+	return m.Repository.Restore(ctx, id)
+}
+
+func (m *measuredRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	defer m.timer.Since("Dependents", time.Now())
+	return m.Repository.Dependents(ctx, id)
+}
+
+func (m *measuredRepository) ReportProductsAll(ctx context.Context) ([]domain.ProductReport, error) {
+	defer m.timer.Since("ReportProductsAll", time.Now())
+	return m.Repository.ReportProductsAll(ctx)
+}
+
+func (m *measuredRepository) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	defer m.timer.Since("StreamReportProducts", time.Now())
+	return m.Repository.StreamReportProducts(ctx, fn)
+}
+
+func (m *measuredRepository) ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error) {
+	defer m.timer.Since("ReportProductsGet", time.Now())
+	return m.Repository.ReportProductsGet(ctx, id)
+}
@@ -5,53 +5,89 @@ import (
 	"database/sql"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a section.
 type Repository interface {
-	GetAll(ctx context.Context) ([]domain.Section, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error
 	Get(ctx context.Context, id int) (domain.Section, error)
 	Exists(ctx context.Context, cid int) bool
 	Save(ctx context.Context, s domain.Section) (int, error)
 	Update(ctx context.Context, s domain.Section) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) ([]domain.Dependent, error)
 	ReportProductsAll(ctx context.Context) ([]domain.ProductReport, error)
+	StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error
 	ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
-	query := "SELECT * FROM sections;"
-	rows, err := r.db.Query(query)
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
+	var sections []domain.Section
+	err := r.StreamAll(ctx, includeDeleted, func(s domain.Section) error {
+		sections = append(sections, s)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var sections []domain.Section
+	return sections, nil
+}
+
+// StreamAll llama a fn con cada seccion a medida que se lee de la base de
+// datos, y corta en el primer error que devuelva fn
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	query := queries.SectionGetAllQuery
+	if !includeDeleted {
+		query += queries.SectionNotDeleted
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		s := domain.Section{}
-		_ = rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
-		sections = append(sections, s)
+		if err := rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.Version, &s.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
 	}
 
-	return sections, nil
+	return rows.Err()
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
-	query := "SELECT * FROM sections WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionGetQuery)
+	if err != nil {
+		return domain.Section{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
 	s := domain.Section{}
-	err := row.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	err = row.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.Version, &s.DeletedAt)
 	if err != nil {
 		return domain.Section{}, err
 	}
@@ -60,20 +96,22 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, sectionNumber int) bool {
-	query := "SELECT section_number FROM sections WHERE section_number=?;"
-	row := r.db.QueryRow(query, sectionNumber)
-	err := row.Scan(&sectionNumber)
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, sectionNumber)
+	err = row.Scan(&sectionNumber)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
-	query := "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionSaveQuery)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	res, err := stmt.ExecContext(ctx, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
 	if err != nil {
 		return 0, err
 	}
@@ -87,80 +125,119 @@ func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Section) error {
-	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=? WHERE id=?;"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionUpdateQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID)
+	res, err := stmt.ExecContext(ctx, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID, &s.Version)
 	if err != nil {
 		return err
 	}
 
-	_, err = res.RowsAffected()
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return domain.ErrVersionConflict
+	}
+
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM sections WHERE id=?;"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return err
-	}
+// Delete soft deletes the section unless records still reference it, which
+// returns a *domain.DependentsError. The references are counted after the
+// delete, in its transaction, which is rolled back when there are any. A
+// version other than 0 deletes the section only at that version, returning
+// domain.ErrVersionConflict otherwise.
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	return storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		query, args := queries.SectionDeleteQuery, []interface{}{id}
+		if version != 0 {
+			query, args = queries.SectionDeleteVersionQuery, []interface{}{id, version}
+		}
+		stmt, err := r.stmts.Prepare(ctx, query)
+		if err != nil {
+			return err
+		}
 
-	res, err := stmt.Exec(id)
-	if err != nil {
-		return err
-	}
+		res, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return err
+		}
 
-	affect, err := res.RowsAffected()
-	if err != nil {
-		return err
-	}
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
 
-	if affect < 1 {
-		return ErrNotFound
-	}
+		if affect < 1 && version != 0 {
+			return domain.ErrVersionConflict
+		}
+		if affect < 1 {
+			return ErrNotFound
+		}
 
-	return nil
+		dependents, err := r.Dependents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return &domain.DependentsError{Entity: "section", ID: id, Dependents: dependents}
+		}
+		return nil
+	})
 }
 
 func (r *repository) ReportProductsAll(ctx context.Context) ([]domain.ProductReport, error) {
-	query := "SELECT s.id, s.section_number, SUM(pb.current_quantity)  as product_count FROM sections s JOIN product_batches pb ON pb.section_id = s.id JOIN products p  ON pb.product_id = p.id GROUP BY s.id"
-
-	rows, err := r.db.Query(query)
+	var reports []domain.ProductReport
+	err := r.StreamReportProducts(ctx, func(report domain.ProductReport) error {
+		reports = append(reports, report)
+		return nil
+	})
 	if err != nil {
 		return []domain.ProductReport{}, err
 	}
 
-	var reports []domain.ProductReport
+	return reports, nil
+}
+
+// StreamReportProducts llama a fn con el reporte de productos de cada seccion
+// a medida que se lee de la base de datos
+func (r *repository) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionReportAllQuery)
+	if err != nil {
+		return err
+	}
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		report := domain.ProductReport{}
-		err = rows.Scan(&report.SectionId, &report.SectionNumber, &report.ProductCount)
-		if err != nil {
-			return []domain.ProductReport{}, err
+		if err := rows.Scan(&report.SectionId, &report.SectionNumber, &report.ProductCount); err != nil {
+			return err
+		}
+		if err := fn(report); err != nil {
+			return err
 		}
-		reports = append(reports, report)
 	}
 
-	return reports, nil
+	return rows.Err()
 }
 
 func (r *repository) ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error) {
-	query := "SELECT s.id, s.section_number, SUM(pb.current_quantity)  as product_count FROM sections s JOIN product_batches pb ON pb.section_id = s.id JOIN products p  ON pb.product_id = p.id WHERE s.id = ? GROUP BY s.id"
-
-	stmt, err := r.db.PrepareContext(ctx, query)
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionReportGetQuery)
 	if err != nil {
 		return domain.ProductReport{}, err
 	}
 
-	row := stmt.QueryRow(id)
+	row := stmt.QueryRowContext(ctx, id)
 
 	var report domain.ProductReport
 	err = row.Scan(&report.SectionId, &report.SectionNumber, &report.ProductCount)
@@ -169,3 +246,41 @@ func (r *repository) ReportProductsGet(ctx context.Context, id int) (domain.Prod
 	}
 	return report, nil
 }
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionRestoreQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Dependents counts the records that still reference the section.
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.SectionDependentsQuery)
+	if err != nil {
+		return nil, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
+	counts := make([]int, 1)
+	if err := row.Scan(&counts[0]); err != nil {
+		return nil, err
+	}
+
+	return domain.NewDependents([]string{"product_batches"}, counts), nil
+}
@@ -55,7 +55,7 @@ func TestCreateErrorService(t *testing.T) {
 func TestFindAllService(t *testing.T) {
 	s := createMockRepository()
 	ctx := context.TODO()
-	_, err := s.GetAll(ctx)
+	_, err := s.GetAll(ctx, false)
 	assert.NoError(t, err)
 }
 
@@ -103,7 +103,7 @@ func TestDeleteNonExistantService(t *testing.T) {
 	expectedErrStr := fmt.Sprintf(expectedErrFormat, deletedId)
 	s := createMockRepository()
 	ctx := context.TODO()
-	err := s.Delete(ctx, deletedId)
+	err := s.Delete(ctx, deletedId, 0)
 	assert.EqualError(t, err, expectedErrStr)
 }
 
@@ -111,7 +111,7 @@ func TestDeleteService(t *testing.T) {
 	deletedId := 1
 	s := createMockRepository()
 	ctx := context.TODO()
-	err := s.Delete(ctx, deletedId)
+	err := s.Delete(ctx, deletedId, 0)
 	deletedSection, errBusqueda := s.Get(ctx, deletedId)
 	assert.NoError(t, err)
 	assert.Error(t, errBusqueda)
@@ -2,6 +2,7 @@ package section
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
@@ -13,12 +14,15 @@ var (
 )
 
 type Service interface {
-	GetAll(ctx context.Context) ([]domain.Section, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error
 	Get(ctx context.Context, id int) (domain.Section, error)
 	Save(ctx context.Context, s domain.Section) (int, error)
 	Update(ctx context.Context, s domain.Section) (domain.Section, error)
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
 	ReportProductsGetAll(ctx context.Context) ([]domain.ProductReport, error)
+	StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error
 	ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error)
 }
 
@@ -33,14 +37,23 @@ func NewService(r Repository) Service {
 }
 
 // Trae todas las secciones registradas por el repositorio usando el metodo GetAll del repositorio
-func (ser *service) GetAll(ctx context.Context) ([]domain.Section, error) {
-	return ser.repository.GetAll(ctx)
+func (ser *service) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
+	return ser.repository.GetAll(ctx, includeDeleted)
 
 }
 
+// Llama a fn con cada seccion registrada sin cargarlas todas en memoria
+func (ser *service) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	return ser.repository.StreamAll(ctx, includeDeleted, fn)
+}
+
 // Busca una seccion especifica por el id dado usando el metodo Get del repositorio
 func (ser *service) Get(ctx context.Context, id int) (domain.Section, error) {
-	return ser.repository.Get(ctx, id)
+	s, err := ser.repository.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Section{}, ErrNotFound
+	}
+	return s, err
 }
 
 // Crea una nueva seccion y llama al metodo Save, del repositorio
@@ -63,18 +76,30 @@ func (ser *service) Update(ctx context.Context, s domain.Section) (domain.Sectio
 	if err != nil {
 		return domain.Section{}, err
 	}
+	s.Version++
 	return s, nil
 }
 
 // Dado un id de una seccion, la encuentra lo elimina usando el metodo Delete del repositorio
-func (ser *service) Delete(ctx context.Context, id int) error {
-	return ser.repository.Delete(ctx, id)
+func (ser *service) Delete(ctx context.Context, id, version int) error {
+	return ser.repository.Delete(ctx, id, version)
 }
 
 func (ser *service) ReportProductsGetAll(ctx context.Context) ([]domain.ProductReport, error) {
 	return ser.repository.ReportProductsAll(ctx)
 }
 
+// Llama a fn con el reporte de productos de cada seccion sin cargarlos todos en memoria
+func (ser *service) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	return ser.repository.StreamReportProducts(ctx, fn)
+}
+
 func (ser *service) ReportProductsGet(ctx context.Context, id int) (domain.ProductReport, error) {
 	return ser.repository.ReportProductsGet(ctx, id)
 }
+
+// Restore brings back a deleted section. It returns ErrNotFound when the section
+// does not exist or is not deleted.
+func (ser *service) Restore(ctx context.Context, id int) error {
+	return ser.repository.Restore(ctx, id)
+}
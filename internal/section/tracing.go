@@ -0,0 +1,170 @@
+package section
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "section.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("section.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Section, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.Section, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Save(ctx context.Context, s domain.Section) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, s)
+}
+
+func (t *tracedService) Update(ctx context.Context, s domain.Section) (_ domain.Section, err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Update(ctx, s)
+}
+
+func (t *tracedService) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Delete(ctx, id, version)
+}
+
+func (t *tracedService) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Restore(ctx, id)
+}
+
+func (t *tracedService) ReportProductsGetAll(ctx context.Context) (_ []domain.ProductReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "ReportProductsGetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.ReportProductsGetAll(ctx)
+}
+
+func (t *tracedService) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamReportProducts")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamReportProducts(ctx, fn)
+}
+
+func (t *tracedService) ReportProductsGet(ctx context.Context, id int) (_ domain.ProductReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "ReportProductsGet")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.ReportProductsGet(ctx, id)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "section.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("section.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Section, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Section, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, cid int) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, cid)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, s domain.Section) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, s)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, s domain.Section) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Update(ctx, s)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, id, version)
+}
+
+func (t *tracedRepository) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Restore(ctx, id)
+}
+
+func (t *tracedRepository) Dependents(ctx context.Context, id int) (_ []domain.Dependent, err error) {
+	ctx, span := t.tracer.Start(ctx, "Dependents")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Dependents(ctx, id)
+}
+
+func (t *tracedRepository) ReportProductsAll(ctx context.Context) (_ []domain.ProductReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "ReportProductsAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ReportProductsAll(ctx)
+}
+
+func (t *tracedRepository) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamReportProducts")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamReportProducts(ctx, fn)
+}
+
+func (t *tracedRepository) ReportProductsGet(ctx context.Context, id int) (_ domain.ProductReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "ReportProductsGet")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ReportProductsGet(ctx, id)
+}
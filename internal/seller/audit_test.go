@@ -0,0 +1,82 @@
+package seller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuditedSellerService() (Service, *mocks.MockAuditRepository) {
+	sellers := append([]domain.Seller{}, mocks.MockListSellers...)
+	auditRepository := &mocks.MockAuditRepository{}
+	service := NewAuditedService(NewService(&mocks.MockSellerRepo{MockSeller: sellers}), audit.NewService(auditRepository))
+	return service, auditRepository
+}
+
+func TestAuditedSaveSeller(t *testing.T) {
+	service, auditRepository := newAuditedSellerService()
+
+	id, err := service.Save(context.TODO(), domain.Seller{CID: 50, CompanyName: "MELI"})
+
+	assert.Nil(t, err)
+	assert.Len(t, auditRepository.MockData, 1)
+	assert.Equal(t, audit.ActionCreate, auditRepository.MockData[0].Action)
+	assert.Equal(t, id, auditRepository.MockData[0].EntityID)
+}
+
+func TestAuditedUpdateSeller(t *testing.T) {
+	service, auditRepository := newAuditedSellerService()
+
+	err := service.Update(context.TODO(), mocks.MockUpdateSeller)
+
+	assert.Nil(t, err)
+	assert.Len(t, auditRepository.MockData, 1)
+	var before, after domain.Seller
+	_ = json.Unmarshal(auditRepository.MockData[0].Before, &before)
+	_ = json.Unmarshal(auditRepository.MockData[0].After, &after)
+	assert.Equal(t, mocks.MockListSellers[1], before)
+	assert.Equal(t, mocks.MockUpdateSeller, after)
+}
+
+func TestAuditedDeleteSellerNotFound(t *testing.T) {
+	service, auditRepository := newAuditedSellerService()
+
+	err := service.Delete(context.TODO(), 99)
+
+	assert.NotNil(t, err)
+	assert.Empty(t, auditRepository.MockData)
+}
+
+func TestAuditedImportSeller(t *testing.T) {
+	service, auditRepository := newAuditedSellerService()
+
+	results, err := service.Import(context.TODO(), []domain.Seller{
+		{CID: 60, CompanyName: "MELI"},
+		{CID: 2, CompanyName: "DUPLICATED"},
+		{CID: 61, CompanyName: "DIGITAL HOUSE"},
+	}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.BulkCreated, results[0].Status)
+	assert.Equal(t, domain.BulkConflict, results[1].Status)
+	assert.Equal(t, domain.BulkCreated, results[2].Status)
+	assert.Len(t, auditRepository.MockData, 2)
+	assert.Equal(t, results[2].ID, auditRepository.MockData[1].EntityID)
+}
+
+func TestAuditedImportSellerDryRun(t *testing.T) {
+	service, auditRepository := newAuditedSellerService()
+
+	results, err := service.Import(context.TODO(), []domain.Seller{{CID: 60}, {CID: 60}}, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.BulkValid, results[0].Status)
+	assert.Equal(t, domain.BulkConflict, results[1].Status)
+	assert.Empty(t, auditRepository.MockData)
+}
@@ -0,0 +1,81 @@
+package seller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEventedSellerService() (Service, *mocks.MockOutboxRepository) {
+	sellers := append([]domain.Seller{}, mocks.MockListSellers...)
+	outboxRepository := &mocks.MockOutboxRepository{}
+	service := NewEventedService(NewService(&mocks.MockSellerRepo{MockSeller: sellers}), outbox.NewService(outboxRepository, nil))
+	return service, outboxRepository
+}
+
+func TestEventedSaveSeller(t *testing.T) {
+	service, outboxRepository := newEventedSellerService()
+
+	id, err := service.Save(context.TODO(), domain.Seller{CID: 50, CompanyName: "MELI"})
+
+	assert.Nil(t, err)
+	assert.Len(t, outboxRepository.MockData, 1)
+	assert.Equal(t, "seller.created", outboxRepository.MockData[0].Type)
+	assert.Equal(t, id, outboxRepository.MockData[0].EntityID)
+	assert.Equal(t, outbox.StatusPending, outboxRepository.MockData[0].Status)
+}
+
+func TestEventedSaveSellerFailsWithoutEvent(t *testing.T) {
+	service, outboxRepository := newEventedSellerService()
+	outboxRepository.ErrSave = errors.New("outbox unavailable")
+
+	_, err := service.Save(context.TODO(), domain.Seller{CID: 50, CompanyName: "MELI"})
+
+	assert.EqualError(t, err, "outbox unavailable")
+}
+
+func TestEventedUpdateSeller(t *testing.T) {
+	service, outboxRepository := newEventedSellerService()
+
+	err := service.Update(context.TODO(), mocks.MockUpdateSeller)
+
+	assert.Nil(t, err)
+	assert.Len(t, outboxRepository.MockData, 1)
+	var event domain.SellerUpdated
+	_ = json.Unmarshal(outboxRepository.MockData[0].Payload, &event)
+	assert.Equal(t, mocks.MockUpdateSeller, event.Seller)
+}
+
+func TestEventedDeleteSellerNotFound(t *testing.T) {
+	service, outboxRepository := newEventedSellerService()
+
+	err := service.Delete(context.TODO(), 99)
+
+	assert.NotNil(t, err)
+	assert.Empty(t, outboxRepository.MockData)
+}
+
+func TestEventedImportSeller(t *testing.T) {
+	service, outboxRepository := newEventedSellerService()
+
+	results, err := service.Import(context.TODO(), []domain.Seller{
+		{CID: 60, CompanyName: "MELI"},
+		{CID: 2, CompanyName: "DUPLICATED"},
+		{CID: 61, CompanyName: "DIGITAL HOUSE"},
+	}, false)
+
+	assert.Nil(t, err)
+	assert.Len(t, outboxRepository.MockData, 2)
+	assert.Equal(t, results[2].ID, outboxRepository.MockData[1].EntityID)
+
+	_, err = service.Import(context.TODO(), []domain.Seller{{CID: 70}}, true)
+	assert.Nil(t, err)
+	assert.Len(t, outboxRepository.MockData, 2, "a dry run publishes nothing")
+}
@@ -0,0 +1,300 @@
+// Package memory implements seller.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/seller"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a seller.Repository that keeps the sellers in db.
+func NewRepository(db *memdb.DB) seller.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the seller with id, or -1.
+func (r *repository) index(id int) int {
+	for i, s := range r.db.Sellers {
+		if s.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error) {
+	var sellers []domain.Seller
+	err := r.StreamAll(ctx, includeDeleted, func(s domain.Seller) error {
+		sellers = append(sellers, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sellers, nil
+}
+
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error {
+	r.db.RLock()
+	var sellers []domain.Seller
+	for _, s := range r.db.Sellers {
+		if includeDeleted || s.DeletedAt == nil {
+			sellers = append(sellers, s)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, s := range sellers {
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Seller, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sellers[i].DeletedAt != nil {
+		return domain.Seller{}, sql.ErrNoRows
+	}
+	return r.db.Sellers[i], nil
+}
+
+func (r *repository) Exists(ctx context.Context, cid int) bool {
+	return r.CIDExist(ctx, cid)
+}
+
+func (r *repository) CIDExist(ctx context.Context, cid int) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.cidExist(cid)
+}
+
+func (r *repository) cidExist(cid int) bool {
+	for _, s := range r.db.Sellers {
+		if s.CID == cid {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) ExistingCIDs(ctx context.Context, cids []int) (map[int]bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	existing := map[int]bool{}
+	for _, cid := range cids {
+		if r.cidExist(cid) {
+			existing[cid] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *repository) ExistingLocalities(ctx context.Context, ids []int) (map[int]bool, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	wanted := map[int]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	existing := map[int]bool{}
+	for _, l := range r.db.Localities {
+		if wanted[l.ID] {
+			existing[l.ID] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if r.cidExist(s.CID) {
+		return 0, fmt.Errorf("seller with cid %v already exists", s.CID)
+	}
+	return r.insert(s), nil
+}
+
+func (r *repository) SaveBatch(ctx context.Context, sellers []domain.Seller) ([]int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	ids := make([]int, 0, len(sellers))
+	for _, s := range sellers {
+		ids = append(ids, r.insert(s))
+	}
+	return ids, nil
+}
+
+func (r *repository) insert(s domain.Seller) int {
+	s.ID = r.db.NextID("sellers")
+	s.DeletedAt = nil
+	r.db.Sellers = append(r.db.Sellers, s)
+	return s.ID
+}
+
+// Update changes every column and, like the UPDATE it replaces, doesn't
+// fail when no seller matches.
+func (r *repository) Update(ctx context.Context, s domain.Seller) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(s.ID)
+	if i < 0 || r.db.Sellers[i].DeletedAt != nil {
+		return nil
+	}
+	current := &r.db.Sellers[i]
+	current.CID, current.CompanyName, current.Address, current.Telephone, current.LocalityId = s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityId
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sellers[i].DeletedAt != nil {
+		return seller.ErrNotFound
+	}
+	if dependents := r.dependents(id); len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "seller", ID: id, Dependents: dependents}
+	}
+	now := r.db.Now()
+	r.db.Sellers[i].DeletedAt = &now
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sellers[i].DeletedAt == nil {
+		return seller.ErrNotFound
+	}
+	r.db.Sellers[i].DeletedAt = nil
+	return nil
+}
+
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.dependents(id), nil
+}
+
+// dependents counts the records referencing the seller id. The caller holds
+// the lock of r.db.
+func (r *repository) dependents(id int) []domain.Dependent {
+	products := 0
+	for _, p := range r.db.Products {
+		if p.SellerID == id && p.DeletedAt == nil {
+			products++
+		}
+	}
+	return domain.NewDependents([]string{"products"}, []int{products})
+}
+
+// report sums up the seller s the way queries.SellerReportQuery does.
+func (r *repository) report(s domain.Seller, dates domain.DateRange) domain.SellerReport {
+	sr := domain.SellerReport{SellerID: s.ID, CID: s.CID, CompanyName: s.CompanyName}
+	for _, p := range r.db.Products {
+		if p.SellerID != s.ID || p.DeletedAt != nil {
+			continue
+		}
+		sr.ProductsCount++
+		for _, b := range r.db.ProductBatches {
+			if b.ProductId == p.ID {
+				sr.Stock += b.CurrentQuantity
+			}
+		}
+	}
+
+	orders := map[int]bool{}
+	for _, od := range r.db.OrderDetails {
+		po, ok := r.db.PurchaseOrder(od.PurchaseOrderID)
+		if !ok || !memdb.InRange(po.OrderDate, dates) {
+			continue
+		}
+		rc, ok := r.db.ProductRecord(od.ProductRecordID)
+		if !ok {
+			continue
+		}
+		if p, ok := r.db.Product(rc.ProductID); !ok || p.SellerID != s.ID {
+			continue
+		}
+		orders[po.ID] = true
+		sr.Revenue += float64(od.Quantity) * rc.SalePrice
+	}
+	sr.PurchaseOrders = len(orders)
+	return sr
+}
+
+func (r *repository) Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Sellers[i].DeletedAt != nil {
+		return domain.SellerReport{}, sql.ErrNoRows
+	}
+	return r.report(r.db.Sellers[i], dates), nil
+}
+
+func (r *repository) Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error) {
+	reports := []domain.SellerReport{}
+	err := r.StreamReports(ctx, dates, func(sr domain.SellerReport) error {
+		reports = append(reports, sr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *repository) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error {
+	r.db.RLock()
+	var reports []domain.SellerReport
+	for _, s := range r.db.Sellers {
+		if s.DeletedAt == nil {
+			reports = append(reports, r.report(s, dates))
+		}
+	}
+	r.db.RUnlock()
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].Revenue != reports[j].Revenue {
+			return reports[i].Revenue > reports[j].Revenue
+		}
+		return reports[i].SellerID < reports[j].SellerID
+	})
+	for _, sr := range reports {
+		if err := fn(sr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
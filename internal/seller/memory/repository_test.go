@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveSellerMemoryDuplicatedCID(t *testing.T) {
+	db := memdb.New()
+	memdb.Seed(db)
+	repo := NewRepository(db)
+
+	_, err := repo.Save(context.TODO(), domain.Seller{CID: 1})
+
+	assert.EqualError(t, err, "seller with cid 1 already exists")
+}
+
+func TestUpdateSellerMemoryLocality(t *testing.T) {
+	db := memdb.New()
+	db.Sellers = []domain.Seller{{ID: 1, CID: 10, LocalityId: 1}}
+	repo := NewRepository(db)
+
+	assert.Nil(t, repo.Update(context.TODO(), domain.Seller{ID: 1, CID: 10, LocalityId: 2}))
+
+	s, err := repo.Get(context.TODO(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, s.LocalityId)
+}
+
+func TestReportsSellerMemory(t *testing.T) {
+	db := memdb.New()
+	db.Sellers = []domain.Seller{{ID: 1, CID: 10}, {ID: 2, CID: 20}}
+	db.Products = []domain.Product{{ID: 1, SellerID: 1}, {ID: 2, SellerID: 2}}
+	db.ProductBatches = []domain.ProductBatches{{Id: 1, ProductId: 1, CurrentQuantity: 4}}
+	db.ProductRecords = []memdb.ProductRecord{{ID: 1, ProductID: 2, SalePrice: 2.5}}
+	db.PurchaseOrders = []domain.PurchaseOrders{
+		{ID: 1, OrderDate: "2022-01-10 00:00:00"},
+		{ID: 2, OrderDate: "2022-03-10 00:00:00"},
+	}
+	db.OrderDetails = []memdb.OrderDetail{
+		{ID: 1, ProductRecordID: 1, PurchaseOrderID: 1, Quantity: 2},
+		{ID: 2, ProductRecordID: 1, PurchaseOrderID: 2, Quantity: 4},
+	}
+	repo := NewRepository(db)
+
+	reports, err := repo.Reports(context.TODO(), domain.DateRange{To: "2022-02-01 00:00:00"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.SellerReport{
+		{SellerID: 2, CID: 20, ProductsCount: 1, PurchaseOrders: 1, Revenue: 5},
+		{SellerID: 1, CID: 10, ProductsCount: 1, Stock: 4},
+	}, reports)
+}
@@ -0,0 +1,105 @@
+package seller
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "seller".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("seller"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (m *measuredRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error {
+	defer m.timer.Since("StreamAll", time.Now())
+	return m.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Seller, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, cid int) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, cid)
+}
+
+func (m *measuredRepository) ExistingCIDs(ctx context.Context, cids []int) (map[int]bool, error) {
+	defer m.timer.Since("ExistingCIDs", time.Now())
+	return m.Repository.ExistingCIDs(ctx, cids)
+}
+
+func (m *measuredRepository) ExistingLocalities(ctx context.Context, ids []int) (map[int]bool, error) {
+	defer m.timer.Since("ExistingLocalities", time.Now())
+	return m.Repository.ExistingLocalities(ctx, ids)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, s domain.Seller) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, s)
+}
+
+func (m *measuredRepository) SaveBatch(ctx context.Context, sellers []domain.Seller) ([]int, error) {
+	defer m.timer.Since("SaveBatch", time.Now())
+	return m.Repository.SaveBatch(ctx, sellers)
+}
+
+func (m *measuredRepository) Update(ctx context.Context, s domain.Seller) error {
+	defer m.timer.Since("Update", time.Now())
+	return m.Repository.Update(ctx, s)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, id int) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, id)
+}
+
+func (m *measuredRepository) Restore(ctx context.Context, id int) error {
+	defer m.timer.Since("Restore", time.Now())
+	return m.Repository.Restore(ctx, id)
+}
+
+func (m *measuredRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	defer m.timer.Since("Dependents", time.Now())
+	return m.Repository.Dependents(ctx, id)
+}
+
+func (m *measuredRepository) CIDExist(ctx context.Context, cid int) bool {
+	defer m.timer.Since("CIDExist", time.Now())
+	return m.Repository.CIDExist(ctx, cid)
+}
+
+func (m *measuredRepository) Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error) {
+	defer m.timer.Since("Report", time.Now())
+	return m.Repository.Report(ctx, id, dates)
+}
+
+func (m *measuredRepository) Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error) {
+	defer m.timer.Since("Reports", time.Now())
+	return m.Repository.Reports(ctx, dates)
+}
+
+func (m *measuredRepository) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error {
+	defer m.timer.Since("StreamReports", time.Now())
+	return m.Repository.StreamReports(ctx, dates, fn)
+}
@@ -4,55 +4,96 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a Seller.
 type Repository interface {
-	GetAll(ctx context.Context) ([]domain.Seller, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error
 	Get(ctx context.Context, id int) (domain.Seller, error)
 	Exists(ctx context.Context, cid int) bool
+	ExistingCIDs(ctx context.Context, cids []int) (map[int]bool, error)
+	ExistingLocalities(ctx context.Context, ids []int) (map[int]bool, error)
 	Save(ctx context.Context, s domain.Seller) (int, error)
+	SaveBatch(ctx context.Context, sellers []domain.Seller) ([]int, error)
 	Update(ctx context.Context, s domain.Seller) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) ([]domain.Dependent, error)
 	CIDExist(ctx context.Context, cid int) bool
+	Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error)
+	Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error)
+	StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	query := "SELECT * FROM sellers"
-	rows, err := r.db.Query(query)
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error) {
+	var sellers []domain.Seller
+	err := r.StreamAll(ctx, includeDeleted, func(s domain.Seller) error {
+		sellers = append(sellers, s)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var sellers []domain.Seller
+	return sellers, nil
+}
+
+// StreamAll calls fn with each seller as it is read from the database,
+// stopping at the first error fn returns.
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error {
+	query := queries.SellerGetAllQuery
+	if !includeDeleted {
+		query += queries.SellerNotDeleted
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		s := domain.Seller{}
-		_ = rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone)
-		sellers = append(sellers, s)
+		if err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityId, &s.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
 	}
 
-	return sellers, nil
+	return rows.Err()
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Seller, error) {
-	query := "SELECT * FROM sellers WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	stmt, err := r.stmts.Prepare(ctx, queries.SellerGetQuery)
+	if err != nil {
+		return domain.Seller{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
 	s := domain.Seller{}
-	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone)
+	err = row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityId, &s.DeletedAt)
 	if err != nil {
 		return domain.Seller{}, err
 	}
@@ -61,28 +102,102 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Seller, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, cid int) bool {
-	query := "SELECT cid FROM sellers WHERE cid=?;"
-	row := r.db.QueryRow(query, cid)
-	err := row.Scan(&cid)
+	stmt, err := r.stmts.Prepare(ctx, queries.SelectCidSeller)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, cid)
+	err = row.Scan(&cid)
 	return err == nil
 }
 
+// ExistingCIDs returns which of cids are already used by a seller, looking
+// them up BulkBatchSize at a time.
+func (r *repository) ExistingCIDs(ctx context.Context, cids []int) (map[int]bool, error) {
+	return r.existingInts(ctx, queries.SellerExistingCIDsQuery, cids)
+}
+
+// ExistingLocalities returns which of ids are localities that exist, looking
+// them up BulkBatchSize at a time.
+func (r *repository) ExistingLocalities(ctx context.Context, ids []int) (map[int]bool, error) {
+	return r.existingInts(ctx, queries.SellerExistingLocalitiesQuery, ids)
+}
+
+// existingInts returns which of values query, open after its first
+// placeholder, selects.
+func (r *repository) existingInts(ctx context.Context, query string, values []int) (map[int]bool, error) {
+	existing := map[int]bool{}
+	for from := 0; from < len(values); from += domain.BulkBatchSize {
+		to := from + domain.BulkBatchSize
+		if to > len(values) {
+			to = len(values)
+		}
+		args := make([]interface{}, 0, to-from)
+		for _, value := range values[from:to] {
+			args = append(args, value)
+		}
+		rows, err := storage.ExecutorOf(ctx, r.db).QueryContext(ctx, query+strings.Repeat(",?", len(args)-1)+")", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var value int
+			if err := rows.Scan(&value); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			existing[value] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// SaveBatch inserts sellers in a single transaction, or in a savepoint
+// of the one ctx carries, and returns their ids.
+func (r *repository) SaveBatch(ctx context.Context, sellers []domain.Seller) ([]int, error) {
+	ids := make([]int, 0, len(sellers))
+	err := storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		stmt, err := r.stmts.Prepare(ctx, queries.InsertSeller)
+		if err != nil {
+			return err
+		}
+		for _, s := range sellers {
+			res, err := stmt.ExecContext(ctx, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityId)
+			if err != nil {
+				return err
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			ids = append(ids, int(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
 	if r.CIDExist(ctx, s.CID) {
 		return 0, fmt.Errorf("seller with cid %v already exists", s.CID)
 	}
 
-	stmt, err := r.db.PrepareContext(ctx, queries.InsertSeller)
+	stmt, err := r.stmts.Prepare(ctx, queries.InsertSeller)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
 
 	res, err := stmt.ExecContext(ctx, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityId)
 	if err != nil {
 		return 0, err
 	}
-	stmt.Close()
 	id, err := res.LastInsertId()
 	if err != nil {
 		return 0, err
@@ -92,13 +207,12 @@ func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Seller) error {
-	query := "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Prepare(ctx, queries.SellerUpdateQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.ID)
+	res, err := stmt.ExecContext(ctx, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityId, s.ID)
 	if err != nil {
 		return err
 	}
@@ -111,14 +225,58 @@ func (r *repository) Update(ctx context.Context, s domain.Seller) error {
 	return nil
 }
 
+// Delete soft deletes the seller unless records still reference it, which
+// returns a *domain.DependentsError. The references are counted after the
+// delete, in its transaction, which is rolled back when there are any.
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM sellers WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	return storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		stmt, err := r.stmts.Prepare(ctx, queries.SellerDeleteQuery)
+		if err != nil {
+			return err
+		}
+
+		res, err := stmt.ExecContext(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affect < 1 {
+			return ErrNotFound
+		}
+
+		dependents, err := r.Dependents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return &domain.DependentsError{Entity: "seller", ID: id, Dependents: dependents}
+		}
+		return nil
+	})
+}
+
+func (r *repository) CIDExist(ctx context.Context, cid int) bool {
+	stmt, err := r.stmts.Prepare(ctx, queries.SelectCidSeller)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, cid)
+	err = row.Scan(&cid)
+	return err == nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.SellerRestoreQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -135,8 +293,89 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
-func (r *repository) CIDExist(ctx context.Context, cid int) bool {
-	row := r.db.QueryRowContext(ctx, queries.SelectCidSeller, cid)
-	err := row.Scan(&cid)
-	return err == nil
+// Dependents counts the records that still reference the seller.
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.SellerDependentsQuery)
+	if err != nil {
+		return nil, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
+	counts := make([]int, 1)
+	if err := row.Scan(&counts[0]); err != nil {
+		return nil, err
+	}
+
+	return domain.NewDependents([]string{"products"}, counts), nil
+}
+
+// reportQuery builds the seller report query, counting only the orders
+// placed within dates, and returns it with its arguments.
+func reportQuery(dates domain.DateRange) (string, []interface{}) {
+	var conditions string
+	var args []interface{}
+	if dates.From != "" {
+		conditions += queries.SellerReportFrom
+		args = append(args, dates.From)
+	}
+	if dates.To != "" {
+		conditions += queries.SellerReportTo
+		args = append(args, dates.To)
+	}
+	return fmt.Sprintf(queries.SellerReportQuery, conditions), args
+}
+
+func scanReport(row interface{ Scan(...interface{}) error }) (domain.SellerReport, error) {
+	sr := domain.SellerReport{}
+	err := row.Scan(&sr.SellerID, &sr.CID, &sr.CompanyName, &sr.ProductsCount, &sr.Stock, &sr.PurchaseOrders, &sr.Revenue)
+	return sr, err
+}
+
+// Report sums up the products, stock and purchase orders of the seller id.
+func (r *repository) Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error) {
+	query, args := reportQuery(dates)
+	stmt, err := r.stmts.Prepare(ctx, query+queries.SellerReportByID)
+	if err != nil {
+		return domain.SellerReport{}, err
+	}
+	return scanReport(stmt.QueryRowContext(ctx, append(args, id)...))
+}
+
+func (r *repository) Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error) {
+	reports := []domain.SellerReport{}
+	err := r.StreamReports(ctx, dates, func(sr domain.SellerReport) error {
+		reports = append(reports, sr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// StreamReports calls fn with the report of each seller, ranked by revenue,
+// as it is read from the database, stopping at the first error fn returns.
+func (r *repository) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error {
+	query, args := reportQuery(dates)
+	stmt, err := r.stmts.Prepare(ctx, query+queries.SellerReportRanking)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sr, err := scanReport(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(sr); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
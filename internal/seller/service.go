@@ -3,8 +3,11 @@ package seller
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
 )
 
 // Errors
@@ -13,12 +16,18 @@ var (
 )
 
 type Service interface {
-	GetAll(ctx context.Context) ([]domain.Seller, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error
 	Get(ctx context.Context, id int) (domain.Seller, error)
 	Exists(ctx context.Context, cid int) bool
 	Save(ctx context.Context, s domain.Seller) (int, error)
+	Import(ctx context.Context, sellers []domain.Seller, dryRun bool) ([]domain.BulkResult, error)
 	Update(ctx context.Context, s domain.Seller) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error)
+	Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error)
+	StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error
 }
 
 type service struct {
@@ -30,8 +39,13 @@ func NewService(r Repository) Service {
 }
 
 // La funcion Extraer todos los sellers existentes
-func (s *service) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	return s.repository.GetAll(ctx)
+func (s *service) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error) {
+	return s.repository.GetAll(ctx, includeDeleted)
+}
+
+// La funcion llama a fn con cada seller existente sin cargarlos todos
+func (s *service) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error {
+	return s.repository.StreamAll(ctx, includeDeleted, fn)
 }
 
 // La funcion permite Extrae un seller especifico segun su id
@@ -50,6 +64,49 @@ func (se *service) Save(ctx context.Context, s domain.Seller) (int, error) {
 	return se.repository.Save(ctx, s)
 }
 
+// Import saves sellers in batched transactions, skipping those whose cid is
+// taken or whose locality doesn't exist, and reports the result of each in
+// the same order. In a dry run nothing is saved.
+func (se *service) Import(ctx context.Context, sellers []domain.Seller, dryRun bool) ([]domain.BulkResult, error) {
+	cids := make([]int, len(sellers))
+	localityIDs := make([]int, len(sellers))
+	keys := make([]string, len(sellers))
+	for i, s := range sellers {
+		cids[i] = s.CID
+		localityIDs[i] = s.LocalityId
+		keys[i] = strconv.Itoa(s.CID)
+	}
+	existingCIDs, err := se.repository.ExistingCIDs(ctx, cids)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(existingCIDs))
+	for cid := range existingCIDs {
+		existing[strconv.Itoa(cid)] = true
+	}
+	localities, err := se.repository.ExistingLocalities(ctx, localityIDs)
+	if err != nil {
+		return nil, err
+	}
+	invalid := make([]string, len(sellers))
+	for i, s := range sellers {
+		if !localities[s.LocalityId] {
+			invalid[i] = fmt.Sprintf("locality %d does not exist", s.LocalityId)
+		}
+	}
+	return domain.BulkImport(keys, existing, invalid, dryRun, func(rows []int) ([]int, error) {
+		batch := make([]domain.Seller, len(rows))
+		for j, i := range rows {
+			batch[j] = sellers[i]
+		}
+		ids, err := se.repository.SaveBatch(ctx, batch)
+		if err != nil {
+			web.Logger(ctx).ErrorContext(ctx, "saving imported sellers failed", "error", err)
+		}
+		return ids, err
+	}), nil
+}
+
 // La funcion Actualiza un seller segun su id
 // Se le pasan los datos a actualizar, valida
 // que cumpla los requerimientos y actualiza
@@ -62,3 +119,24 @@ func (se *service) Update(ctx context.Context, s domain.Seller) error {
 func (se *service) Delete(ctx context.Context, id int) error {
 	return se.repository.Delete(ctx, id)
 }
+
+// Restore brings back a deleted seller. It returns ErrNotFound when the seller
+// does not exist or is not deleted.
+func (se *service) Restore(ctx context.Context, id int) error {
+	return se.repository.Restore(ctx, id)
+}
+
+// La funcion resume los productos, el stock y las ventas de un seller
+func (s *service) Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error) {
+	return s.repository.Report(ctx, id, dates)
+}
+
+// La funcion resume todos los sellers ordenados por ventas
+func (s *service) Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error) {
+	return s.repository.Reports(ctx, dates)
+}
+
+// La funcion llama a fn con el resumen de cada seller sin cargarlos todos
+func (s *service) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error {
+	return s.repository.StreamReports(ctx, dates, fn)
+}
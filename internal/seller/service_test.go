@@ -49,7 +49,7 @@ func TestFindAllSeller(t *testing.T) {
 	//act
 	service := NewService(mockRepository)
 
-	resp, err := service.GetAll(context.TODO())
+	resp, err := service.GetAll(context.TODO(), false)
 	//assert
 	assert.Nil(t, err)
 	assert.Equal(t, mockRepository.MockSeller, resp)
@@ -92,7 +92,7 @@ func TestUpdateOkSeller(t *testing.T) {
 	s := NewService(mockRepository)
 	ctx := context.TODO()
 	err := s.Update(ctx, mocks.MockUpdateSeller)
-	db, _ := s.GetAll(ctx)
+	db, _ := s.GetAll(ctx, false)
 	//assert
 	assert.Nil(t, err)
 	assert.Equal(t, db[1], mocks.MockUpdateSeller)
@@ -162,3 +162,24 @@ func TestNonExistsSellerCid(t *testing.T) {
 	//assert
 	assert.False(t, exists)
 }
+
+func TestImportSellerUnknownLocality(t *testing.T) {
+	mockRepository := &mocks.MockSellerRepo{
+		MockSeller:            append([]domain.Seller{}, mocks.MockListSellers...),
+		MockMissingLocalities: map[int]bool{9: true},
+	}
+	service := NewService(mockRepository)
+
+	results, err := service.Import(context.TODO(), []domain.Seller{
+		{CID: 60, CompanyName: "MELI", LocalityId: 9},
+		{CID: 61, CompanyName: "DIGITAL HOUSE", LocalityId: 1},
+		{CID: 60, CompanyName: "MELI", LocalityId: 1},
+	}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.BulkInvalid, results[0].Status)
+	assert.Equal(t, "locality 9 does not exist", results[0].Message)
+	assert.Equal(t, domain.BulkCreated, results[1].Status)
+	assert.Equal(t, domain.BulkCreated, results[2].Status, "an invalid row doesn't take its cid")
+	assert.Len(t, mockRepository.MockSeller, len(mocks.MockListSellers)+2)
+}
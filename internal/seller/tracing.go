@@ -0,0 +1,206 @@
+package seller
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "seller.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("seller.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Seller, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.Seller, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Exists(ctx context.Context, cid int) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Service.Exists(ctx, cid)
+}
+
+func (t *tracedService) Save(ctx context.Context, s domain.Seller) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, s)
+}
+
+func (t *tracedService) Import(ctx context.Context, sellers []domain.Seller, dryRun bool) (_ []domain.BulkResult, err error) {
+	ctx, span := t.tracer.Start(ctx, "Import")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Import(ctx, sellers, dryRun)
+}
+
+func (t *tracedService) Update(ctx context.Context, s domain.Seller) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Update(ctx, s)
+}
+
+func (t *tracedService) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Delete(ctx, id)
+}
+
+func (t *tracedService) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Restore(ctx, id)
+}
+
+func (t *tracedService) Report(ctx context.Context, id int, dates domain.DateRange) (_ domain.SellerReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "Report")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Report(ctx, id, dates)
+}
+
+func (t *tracedService) Reports(ctx context.Context, dates domain.DateRange) (_ []domain.SellerReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "Reports")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Reports(ctx, dates)
+}
+
+func (t *tracedService) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamReports(ctx, dates, fn)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "seller.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("seller.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Seller, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Seller, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, cid int) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, cid)
+}
+
+func (t *tracedRepository) ExistingCIDs(ctx context.Context, cids []int) (_ map[int]bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "ExistingCIDs")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ExistingCIDs(ctx, cids)
+}
+
+func (t *tracedRepository) ExistingLocalities(ctx context.Context, ids []int) (_ map[int]bool, err error) {
+	ctx, span := t.tracer.Start(ctx, "ExistingLocalities")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ExistingLocalities(ctx, ids)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, s domain.Seller) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, s)
+}
+
+func (t *tracedRepository) SaveBatch(ctx context.Context, sellers []domain.Seller) (_ []int, err error) {
+	ctx, span := t.tracer.Start(ctx, "SaveBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SaveBatch(ctx, sellers)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, s domain.Seller) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Update(ctx, s)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, id)
+}
+
+func (t *tracedRepository) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Restore(ctx, id)
+}
+
+func (t *tracedRepository) Dependents(ctx context.Context, id int) (_ []domain.Dependent, err error) {
+	ctx, span := t.tracer.Start(ctx, "Dependents")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Dependents(ctx, id)
+}
+
+func (t *tracedRepository) CIDExist(ctx context.Context, cid int) bool {
+	ctx, span := t.tracer.Start(ctx, "CIDExist")
+	defer span.End()
+	return t.Repository.CIDExist(ctx, cid)
+}
+
+func (t *tracedRepository) Report(ctx context.Context, id int, dates domain.DateRange) (_ domain.SellerReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "Report")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Report(ctx, id, dates)
+}
+
+func (t *tracedRepository) Reports(ctx context.Context, dates domain.DateRange) (_ []domain.SellerReport, err error) {
+	ctx, span := t.tracer.Start(ctx, "Reports")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Reports(ctx, dates)
+}
+
+func (t *tracedRepository) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamReports")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamReports(ctx, dates, fn)
+}
@@ -0,0 +1,85 @@
+// Package storage opens the SQL database the repositories run on. The
+// repositories write their queries for MySQL; a Dialect rewrites them for
+// the other databases and tells their errors apart, so the same repositories
+// run on MySQL and on an embedded SQLite file.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Supported databases.
+const (
+	MySQL  = "mysql"
+	SQLite = "sqlite"
+)
+
+// ErrDuplicate reports that a row would break a unique key, whatever the
+// database.
+var ErrDuplicate = errors.New("duplicate entry")
+
+// Dialect hides what changes between the SQL databases. Both support
+// LastInsertId on the result of an insert, so ids are read the same way.
+type Dialect interface {
+	// Name is the name of the database, MySQL or SQLite.
+	Name() string
+	// Rebind rewrites a query written for MySQL: its ? placeholders and the
+	// functions the database lacks.
+	Rebind(query string) string
+	// IsDuplicate tells whether err reports a unique key violation.
+	IsDuplicate(err error) bool
+}
+
+// DialectFor returns the dialect of the database name.
+func DialectFor(name string) (Dialect, error) {
+	switch name {
+	case MySQL:
+		return mysqlDialect{}, nil
+	case SQLite:
+		return sqliteDialect{}, nil
+	}
+	return nil, fmt.Errorf("storage: unknown database %q", name)
+}
+
+// IsDuplicate tells whether err, returned by any of the databases, reports a
+// unique key violation.
+func IsDuplicate(err error) bool {
+	return errors.Is(err, ErrDuplicate) || mysqlDialect{}.IsDuplicate(err) || sqliteDialect{}.IsDuplicate(err)
+}
+
+// mysqlDuplicateEntry is the MySQL error number for a unique key violation.
+const mysqlDuplicateEntry = 1062
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return MySQL }
+
+func (mysqlDialect) Rebind(query string) string { return query }
+
+func (mysqlDialect) IsDuplicate(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// sqliteFunctions maps the MySQL functions used by the queries to their
+// SQLite equivalent. NOW() is in local time in both.
+var sqliteFunctions = strings.NewReplacer(
+	"NOW()", "datetime('now', 'localtime')",
+)
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return SQLite }
+
+func (sqliteDialect) Rebind(query string) string { return sqliteFunctions.Replace(query) }
+
+func (sqliteDialect) IsDuplicate(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer(tracing.Instrumentation)
+
+// Open opens the database name at dsn, the path of the file for SQLite.
+// Every statement run on the returned DB is rewritten by the dialect of the
+// database first. A new SQLite file gets the schema and data of db.sql.
+func Open(name, dsn string) (*sql.DB, error) {
+	dialect, err := DialectFor(name)
+	if err != nil {
+		return nil, err
+	}
+	if name == MySQL {
+		return sql.OpenDB(&connector{dsn: dsn, driver: mysql.MySQLDriver{}, dialect: dialect}), nil
+	}
+
+	db := sql.OpenDB(&connector{dsn: "file:" + dsn + "?_busy_timeout=5000", driver: &sqlite3.SQLiteDriver{}, dialect: dialect})
+	// SQLite takes one writer at a time; a single connection queues them
+	// instead of failing with "database is locked".
+	db.SetMaxOpenConns(1)
+	if err := createSQLiteSchema(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// connector opens connections of driver that rebind their statements.
+type connector struct {
+	dsn     string
+	driver  driver.Driver
+	dialect Dialect
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: dc, dialect: c.dialect}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// conn runs every statement, rebound by the dialect, on the connection of
+// the driver, tracing each.
+type conn struct {
+	driver.Conn
+	dialect Dialect
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query = c.dialect.Rebind(query)
+	var ds driver.Stmt
+	var err error
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		ds, err = p.PrepareContext(ctx, query)
+	} else {
+		ds, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: ds, query: query, dialect: c.dialect}, nil
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	query = c.dialect.Rebind(query)
+	start := time.Now()
+	res, err := e.ExecContext(ctx, query, args)
+	traceQuery(ctx, c.dialect, query, start, err)
+	return res, err
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	query = c.dialect.Rebind(query)
+	start := time.Now()
+	rows, err := q.QueryContext(ctx, query, args)
+	traceQuery(ctx, c.dialect, query, start, err)
+	return rows, err
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // drivers without BeginTx only have Begin
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// stmt is a statement prepared on a conn, each run of it traced.
+type stmt struct {
+	driver.Stmt
+	query   string
+	dialect Dialect
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var res driver.Result
+	var err error
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		res, err = s.Stmt.Exec(values(args)) //nolint:staticcheck // drivers without ExecContext only have Exec
+	}
+	traceQuery(ctx, s.dialect, s.query, start, err)
+	return res, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(values(args)) //nolint:staticcheck // drivers without QueryContext only have Query
+	}
+	traceQuery(ctx, s.dialect, s.query, start, err)
+	return rows, err
+}
+
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if c, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return c.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// traceQuery records the span of query, run from start until now and
+// named after its first keyword, such as SELECT or INSERT. The span doesn't
+// cover reading the rows the query returns. A driver.ErrSkip, which only
+// asks database/sql to prepare the statement instead, leaves no span.
+func traceQuery(ctx context.Context, dialect Dialect, query string, start time.Time, err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+	_, span := tracer.Start(ctx, operation(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(dialect.Name()),
+			semconv.DBQueryText(query),
+		),
+	)
+	tracing.End(span, err)
+}
+
+// operation returns the first keyword of query, past the comments.
+func operation(query string) string {
+	for _, line := range strings.Split(query, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		return strings.ToUpper(strings.Fields(line)[0])
+	}
+	return "QUERY"
+}
+
+func values(args []driver.NamedValue) []driver.Value {
+	vs := make([]driver.Value, len(args))
+	for i, arg := range args {
+		vs[i] = arg.Value
+	}
+	return vs
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"database/sql"
+	_ "embed"
+)
+
+// sqliteSchema creates the tables of db.sql in SQLite, with the same data.
+//
+//go:embed sqlite.sql
+var sqliteSchema string
+
+// createSQLiteSchema creates the tables of a new SQLite database. A database
+// that already has them is left as is.
+func createSQLiteSchema(db *sql.DB) error {
+	var tables int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='products'").Scan(&tables)
+	if err != nil || tables > 0 {
+		return err
+	}
+
+	_, err = db.Exec(sqliteSchema)
+	return err
+}
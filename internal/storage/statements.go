@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+)
+
+// Statements caches the prepared statements of a connection pool, so each
+// query is prepared once and reused by every request instead of prepared,
+// run once and left open. database/sql prepares a cached statement again on
+// each connection of the pool that runs it.
+//
+// The statements are closed by Close; callers must not close them.
+type Statements struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+
+	prepares uint64
+	hits     uint64
+}
+
+// StatementStats counts the work of a Statements.
+type StatementStats struct {
+	// Prepares is how many queries were prepared on the database.
+	Prepares uint64 `json:"prepares"`
+	// Hits is how many times a cached statement was reused.
+	Hits uint64 `json:"hits"`
+	// Open is how many statements are cached.
+	Open int `json:"open"`
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[*sql.DB]*Statements{}
+)
+
+// StatementsOf returns the statements of db, shared by every repository that
+// runs on it.
+func StatementsOf(db *sql.DB) *Statements {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	s, ok := registry[db]
+	if !ok {
+		s = &Statements{db: db, stmts: map[string]*sql.Stmt{}}
+		registry[db] = s
+	}
+	return s
+}
+
+// Prepare returns the statement of query, preparing it the first time.
+// When ctx carries a transaction begun by Transact, the statement runs in
+// it and is closed when the transaction ends. A query not cached yet is then
+// prepared on the connection of the transaction and left uncached, as the
+// pool may have no other connection to prepare it on.
+func (s *Statements) Prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	tx := txOf(ctx, s.db)
+	if tx == nil {
+		return s.prepare(ctx, query)
+	}
+
+	s.mu.Lock()
+	stmt, ok := s.stmts[query]
+	s.mu.Unlock()
+	if !ok {
+		atomic.AddUint64(&s.prepares, 1)
+		return tx.PrepareContext(ctx, query)
+	}
+	atomic.AddUint64(&s.hits, 1)
+	return tx.StmtContext(ctx, stmt), nil
+}
+
+func (s *Statements) prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	s.mu.Lock()
+	stmt, ok := s.stmts[query]
+	s.mu.Unlock()
+	if ok {
+		atomic.AddUint64(&s.hits, 1)
+		return stmt, nil
+	}
+
+	// The query is prepared without holding mu: on a pool of one connection,
+	// as SQLite's, preparing waits for a transaction to give the connection
+	// back, and the transaction needs mu to look up its statements.
+	stmt, err := s.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddUint64(&s.prepares, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.stmts[query]; ok {
+		// Another request prepared it meanwhile.
+		stmt.Close()
+		return cached, nil
+	}
+	s.stmts[query] = stmt
+	return stmt, nil
+}
+
+// PrepareAll prepares queries up front, failing with the first query that
+// doesn't prepare, so a wrong query stops the server at startup.
+func (s *Statements) PrepareAll(ctx context.Context, queries []string) error {
+	for _, query := range queries {
+		if _, err := s.prepare(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns the counters of s.
+func (s *Statements) Stats() StatementStats {
+	s.mu.Lock()
+	open := len(s.stmts)
+	s.mu.Unlock()
+
+	return StatementStats{
+		Prepares: atomic.LoadUint64(&s.prepares),
+		Hits:     atomic.LoadUint64(&s.hits),
+		Open:     open,
+	}
+}
+
+// Close closes the cached statements and forgets them; statements asked for
+// afterwards are prepared again.
+func (s *Statements) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var first error
+	for query, stmt := range s.stmts {
+		if err := stmt.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(s.stmts, query)
+	}
+	return first
+}
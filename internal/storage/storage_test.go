@@ -0,0 +1,313 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/idempotency"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product"
+	product_batch "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product_batches"
+	productbatchmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/product_batches/memory"
+	purchaseOrder "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/warehouse"
+	warehousememory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/warehouse/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	db, err := storage.Open(storage.SQLite, filepath.Join(t.TempDir(), "test.db"))
+	require.Nil(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestOpenSQLiteSeedsNewDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := storage.Open(storage.SQLite, path)
+	require.Nil(t, err)
+	products, err := product.NewRepository(db).GetAll(context.TODO(), false)
+	db.Close()
+
+	assert.Nil(t, err)
+	assert.Len(t, products, 5)
+
+	// Opening it again keeps the data instead of seeding it twice.
+	db, err = storage.Open(storage.SQLite, path)
+	require.Nil(t, err)
+	defer db.Close()
+	products, err = product.NewRepository(db).GetAll(context.TODO(), false)
+	assert.Nil(t, err)
+	assert.Len(t, products, 5)
+}
+
+func TestProductRepositoryOnSQLite(t *testing.T) {
+	repo := product.NewRepository(openSQLite(t))
+	ctx := context.TODO()
+
+	id, err := repo.Save(ctx, domain.Product{ProductCode: "NEW-1", ProductTypeID: 1, SellerID: 1})
+	require.Nil(t, err)
+	assert.Equal(t, 6, id)
+
+	p, err := repo.Get(ctx, id)
+	require.Nil(t, err)
+	assert.Nil(t, repo.Update(ctx, p))
+	assert.ErrorIs(t, repo.Update(ctx, p), domain.ErrVersionConflict)
+
+	assert.ErrorIs(t, repo.Delete(ctx, id, p.Version), domain.ErrVersionConflict)
+	assert.Nil(t, repo.Delete(ctx, id, p.Version+1))
+	_, err = repo.Get(ctx, id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.ErrorIs(t, repo.Delete(ctx, id, 0), product.ErrNotFound)
+	assert.Nil(t, repo.Restore(ctx, id))
+}
+
+func TestDeleteOfReferencedWarehouseRollsBackOnSQLite(t *testing.T) {
+	repo := warehouse.NewRepository(openSQLite(t))
+	ctx := context.TODO()
+
+	var depErr *domain.DependentsError
+	require.ErrorAs(t, repo.Delete(ctx, 1, 0), &depErr)
+	assert.Equal(t, "sections", depErr.Dependents[0].Entity)
+
+	_, err := repo.Get(ctx, 1)
+	assert.Nil(t, err, "the warehouse is still there")
+}
+
+func TestIsDuplicateOnSQLite(t *testing.T) {
+	repo := idempotency.NewRepository(openSQLite(t))
+	rec := domain.IdempotencyRecord{Key: "key", Method: "POST", Path: "/api/v1/products", RequestHash: "hash"}
+
+	assert.Nil(t, repo.Reserve(context.TODO(), rec))
+	assert.ErrorIs(t, repo.Reserve(context.TODO(), rec), idempotency.ErrKeyExists)
+
+	rec.User = "other"
+	assert.Nil(t, repo.Reserve(context.TODO(), rec), "keys are per user")
+}
+
+func TestDialectForUnknownDatabase(t *testing.T) {
+	_, err := storage.DialectFor("postgres")
+
+	assert.NotNil(t, err)
+}
+
+func TestStaticQueriesPrepareOnSQLite(t *testing.T) {
+	stmts := storage.StatementsOf(openSQLite(t))
+
+	err := stmts.PrepareAll(context.TODO(), queries.Static)
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(queries.Static), stmts.Stats().Open)
+	assert.Nil(t, stmts.Close())
+}
+
+func TestStatementsAreSharedAndCounted(t *testing.T) {
+	db := openSQLite(t)
+	repo := product.NewRepository(db)
+
+	_, err := repo.Get(context.TODO(), 1)
+	require.Nil(t, err)
+	_, err = product.NewRepository(db).Get(context.TODO(), 2)
+	require.Nil(t, err)
+
+	stats := storage.StatementsOf(db).Stats()
+	assert.Equal(t, storage.StatementStats{Prepares: 1, Hits: 1, Open: 1}, stats)
+
+	assert.Nil(t, storage.StatementsOf(db).Close())
+	assert.Equal(t, 0, storage.StatementsOf(db).Stats().Open)
+	_, err = repo.Get(context.TODO(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), storage.StatementsOf(db).Stats().Prepares)
+}
+
+func TestStatementsAreTraced(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	repo := purchaseOrder.NewRepository(openSQLite(t))
+	_, err := repo.Save(context.TODO(), domain.PurchaseOrders{
+		OrderNumber: "PO-T", OrderDate: "2022-02-01", TrackingCode: "TRK-T", BuyerId: 1, ProductRecordId: 1, Quantity: 1, OrderStatusId: 1,
+	})
+	require.Nil(t, err)
+
+	var inserts []string
+	for _, span := range recorder.Ended() {
+		attrs := attribute.NewSet(span.Attributes()...)
+		system, _ := attrs.Value(semconv.DBSystemKey)
+		query, _ := attrs.Value(semconv.DBQueryTextKey)
+		if span.Name() == "INSERT" && system.AsString() == storage.SQLite {
+			inserts = append(inserts, query.AsString())
+		}
+	}
+	assert.Equal(t, []string{queries.PurchaseOrderInsertIntoPO, queries.PurchaseOrderInsertIntoOD}, inserts)
+}
+
+func TestPrepareWaitingForConnectionDoesNotBlockTransactOnSQLite(t *testing.T) {
+	db := openSQLite(t)
+	stmts := storage.StatementsOf(db)
+	ctx := context.TODO()
+	_, err := stmts.Prepare(ctx, queries.ProductGetQuery)
+	require.Nil(t, err)
+
+	prepared := make(chan error, 1)
+	committed := make(chan error, 1)
+	go func() {
+		committed <- storage.Transact(ctx, db, func(ctx context.Context) error {
+			go func() {
+				_, err := stmts.Prepare(context.TODO(), queries.WarehouseGetQuery)
+				prepared <- err
+			}()
+			// Let the prepare above wait for the connection this transaction holds.
+			time.Sleep(50 * time.Millisecond)
+			_, err := stmts.Prepare(ctx, queries.ProductGetQuery)
+			return err
+		})
+	}()
+
+	for _, done := range []chan error{committed, prepared} {
+		select {
+		case err := <-done:
+			assert.Nil(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("prepare and transaction deadlocked")
+		}
+	}
+}
+
+func TestTransactCommitsOrRollsBack(t *testing.T) {
+	db := openSQLite(t)
+	repo := product.NewRepository(db)
+	ctx := context.TODO()
+	errFailed := errors.New("failed")
+
+	err := storage.Transact(ctx, db, func(ctx context.Context) error {
+		_, err := repo.Save(ctx, domain.Product{ProductCode: "TX-1", ProductTypeID: 1, SellerID: 1})
+		require.Nil(t, err)
+		return errFailed
+	})
+	assert.Equal(t, errFailed, err)
+	_, err = repo.Get(ctx, 6)
+	assert.NotNil(t, err, "the product was rolled back")
+
+	err = storage.Transact(ctx, db, func(ctx context.Context) error {
+		_, err := repo.Save(ctx, domain.Product{ProductCode: "TX-2", ProductTypeID: 1, SellerID: 1})
+		return err
+	})
+	assert.Nil(t, err)
+	p, err := repo.Get(ctx, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, "TX-2", p.ProductCode)
+}
+
+func TestTransactNestedRollsBackToSavepoint(t *testing.T) {
+	db := openSQLite(t)
+	repo := product.NewRepository(db)
+	ctx := context.TODO()
+
+	err := storage.Transact(ctx, db, func(ctx context.Context) error {
+		_, err := repo.SaveBatch(ctx, []domain.Product{{ProductCode: "SP-1", ProductTypeID: 1, SellerID: 1}})
+		require.Nil(t, err)
+		err = storage.Transact(ctx, db, func(ctx context.Context) error {
+			_, err := repo.Save(ctx, domain.Product{ProductCode: "SP-2", ProductTypeID: 1, SellerID: 1})
+			require.Nil(t, err)
+			return errors.New("failed")
+		})
+		assert.NotNil(t, err)
+		return nil
+	})
+	require.Nil(t, err)
+
+	codes, err := repo.ExistingCodes(ctx, []string{"SP-1", "SP-2"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"SP-1": true}, codes)
+}
+
+func TestImportProductsWithUnknownReferencesOnSQLite(t *testing.T) {
+	service := product.NewService(product.NewRepository(openSQLite(t)))
+
+	results, err := service.Import(context.TODO(), []domain.Product{
+		{ProductCode: "NEW-1", ProductTypeID: 1, SellerID: 999},
+		{ProductCode: "NEW-2", ProductTypeID: 999, SellerID: 1},
+		{ProductCode: "NEW-3", ProductTypeID: 1, SellerID: 1},
+	}, false)
+
+	require.Nil(t, err)
+	assert.Equal(t, "seller 999 does not exist", results[0].Message)
+	assert.Equal(t, "product type 999 does not exist", results[1].Message)
+	assert.Equal(t, domain.BulkCreated, results[2].Status)
+}
+
+func TestProductBatchesRejectDeletedReferencesOnSQLite(t *testing.T) {
+	db := openSQLite(t)
+	repo := product_batch.NewRepository(db)
+	_, err := db.Exec("UPDATE sections SET deleted_at=CURRENT_TIMESTAMP WHERE id=1")
+	require.Nil(t, err)
+	_, err = db.Exec("UPDATE products SET deleted_at=CURRENT_TIMESTAMP WHERE id=2")
+	require.Nil(t, err)
+	save := func(productID, sectionID int) error {
+		_, err := repo.Save(context.TODO(), domain.ProductBatches{BatchNumber: 99, CurrentQuantity: 1, InitialQuantity: 1, DueDate: "2023-01-01",
+			ManufacturingDate: "2022-01-01", ManufacturingHour: "2022-01-01 10:00:00", ProductId: productID, SectionId: sectionID})
+		return err
+	}
+
+	assert.EqualError(t, save(1, 1), "section with id: 1 doesnt exists")
+	assert.EqualError(t, save(2, 2), "product with id: 2 doesnt exists")
+	assert.Nil(t, save(1, 2))
+}
+
+func TestProductTypeMixMatchesMemoryOnSQLite(t *testing.T) {
+	db := openSQLite(t)
+	mem := memdb.New()
+	memdb.Seed(mem)
+	batch := domain.ProductBatches{BatchNumber: 99, CurrentQuantity: 7, InitialQuantity: 7, DueDate: "2023-01-01",
+		ManufacturingDate: "2022-01-01", ManufacturingHour: "2022-01-01 10:00:00", ProductId: 1, SectionId: 1}
+	_, err := product_batch.NewRepository(db).Save(context.TODO(), batch)
+	require.Nil(t, err)
+	_, err = productbatchmemory.NewRepository(mem).Save(context.TODO(), batch)
+	require.Nil(t, err)
+
+	got, err := warehouse.NewRepository(db).ProductTypeMix(context.TODO(), 0)
+	require.Nil(t, err)
+	want, err := warehousememory.NewRepository(mem).ProductTypeMix(context.TODO(), 0)
+	require.Nil(t, err)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "consectetuer eget rutrum at lorem", got[0].Description)
+	assert.Equal(t, want, got)
+}
+
+func TestUpdatePurchaseOrderStatusOnSQLite(t *testing.T) {
+	service := purchaseOrder.NewService(purchaseOrder.NewRepository(openSQLite(t)))
+	ctx := context.TODO()
+	id, err := service.Save(ctx, domain.PurchaseOrders{
+		OrderNumber: "PO-S", OrderDate: "2022-02-01", TrackingCode: "TRK-S", BuyerId: 1, ProductRecordId: 1, Quantity: 1, OrderStatusId: 1,
+	})
+	require.Nil(t, err)
+
+	previous, err := service.UpdateStatus(ctx, id, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, previous)
+	previous, err = service.UpdateStatus(ctx, id, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, previous)
+
+	_, err = service.UpdateStatus(ctx, id, 99)
+	assert.ErrorIs(t, err, purchaseOrder.ErrStatusNotFound)
+	_, err = service.UpdateStatus(ctx, 999, 2)
+	assert.ErrorIs(t, err, purchaseOrder.ErrNotFound)
+}
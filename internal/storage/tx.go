@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Executor runs statements that are not prepared through Statements. Both
+// *sql.DB and *sql.Tx are Executors.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txKey struct{}
+
+// transaction is the transaction a context carries, with the pool it was
+// begun on and how many savepoints deep the context is.
+type transaction struct {
+	db    *sql.DB
+	tx    *sql.Tx
+	depth int
+}
+
+// Transact runs fn in a transaction of db, committed when fn returns nil and
+// rolled back otherwise. The context fn gets carries the transaction: the
+// statements of Statements and ExecutorOf run in it. A Transact with that
+// context runs its fn in a savepoint of the transaction instead, so only the
+// changes of that fn are rolled back when it fails. A nil db, as the
+// in-memory storage has, runs fn as it is.
+func Transact(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if db == nil {
+		return fn(ctx)
+	}
+	if t, ok := transactionOf(ctx, db); ok {
+		return savepoint(ctx, t, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, transaction{db: db, tx: tx})); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint runs fn in a savepoint of t, released when fn returns nil and
+// rolled back to otherwise.
+func savepoint(ctx context.Context, t transaction, fn func(ctx context.Context) error) error {
+	t.depth++
+	name := fmt.Sprintf("sp%d", t.depth)
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, t)); err != nil {
+		if _, rollbackErr := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err := t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// ExecutorOf returns the transaction of db carried by ctx, or db itself
+// when there is none.
+func ExecutorOf(ctx context.Context, db *sql.DB) Executor {
+	if tx := txOf(ctx, db); tx != nil {
+		return tx
+	}
+	return db
+}
+
+// txOf returns the transaction of db carried by ctx, or nil.
+func txOf(ctx context.Context, db *sql.DB) *sql.Tx {
+	if t, ok := transactionOf(ctx, db); ok {
+		return t.tx
+	}
+	return nil
+}
+
+func transactionOf(ctx context.Context, db *sql.DB) (transaction, bool) {
+	t, ok := ctx.Value(txKey{}).(transaction)
+	return t, ok && t.db == db
+}
@@ -0,0 +1,95 @@
+// Package tracing records OpenTelemetry spans for the requests the server
+// handles, the service and repository methods they call and the statements
+// run on the database, and propagates the trace between services with the
+// W3C traceparent header.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters the spans can be sent to.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Instrumentation names the tracer of every span the server starts.
+const Instrumentation = "github.com/extmatperez/meli_bootcamp_go_w5-5"
+
+const serviceName = "melisprint"
+
+// Setup installs the W3C trace context propagator and, unless exporter is
+// ExporterNone, a tracer provider sending the spans to out, for
+// ExporterStdout, or to the OTLP/HTTP collector at endpoint, a host:port
+// reached without TLS, for ExporterOTLP. The returned function flushes the
+// spans not yet sent and must be called before the program exits.
+func Setup(ctx context.Context, exporter, endpoint string, out io.Writer) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(out))
+	case ExporterOTLP:
+		exp, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Tracer starts the spans of the methods of a component, such as
+// "product.Repository", naming each after the component and the method.
+type Tracer struct {
+	component string
+	tracer    trace.Tracer
+}
+
+// New returns the Tracer of component. Its spans go to the provider
+// installed by Setup, even if Setup runs later.
+func New(component string) Tracer {
+	return Tracer{
+		component: component,
+		tracer:    otel.Tracer(Instrumentation),
+	}
+}
+
+// Start starts the span of method, a child of the span in ctx if any.
+func (t Tracer) Start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, t.component+"."+method)
+}
+
+// End records err, when not nil, as the status of span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
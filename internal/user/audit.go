@@ -0,0 +1,33 @@
+package user
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/audit"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+const auditEntity = "user"
+
+type auditedService struct {
+	Service
+	audit audit.Recorder
+}
+
+// NewAuditedService wraps s so that Register is recorded in the audit log.
+// The password hash is never part of the recorded state.
+func NewAuditedService(s Service, a audit.Recorder) Service {
+	return &auditedService{
+		Service: s,
+		audit:   a,
+	}
+}
+
+func (a *auditedService) Register(ctx context.Context, username, password string) (domain.User, error) {
+	u, err := a.Service.Register(ctx, username, password)
+	if err != nil {
+		return u, err
+	}
+	a.audit.Record(ctx, auditEntity, u.ID, audit.ActionCreate, nil, u)
+	return u, nil
+}
@@ -0,0 +1,67 @@
+// Package memory implements user.Repository over the tables of a memdb.DB,
+// with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/user"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a user.Repository that keeps the users in db.
+func NewRepository(db *memdb.DB) user.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.User, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, u := range r.db.Users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return domain.User{}, user.ErrNotFound
+}
+
+func (r *repository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.byUsername(username)
+}
+
+func (r *repository) byUsername(username string) (domain.User, error) {
+	for _, u := range r.db.Users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return domain.User{}, user.ErrNotFound
+}
+
+func (r *repository) Exists(ctx context.Context, username string) bool {
+	_, err := r.GetByUsername(ctx, username)
+	return err == nil
+}
+
+func (r *repository) Save(ctx context.Context, u domain.User) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	if _, err := r.byUsername(u.Username); err == nil {
+		return 0, memdb.ErrDuplicate
+	}
+	u.ID = r.db.NextID("users")
+	r.db.Users = append(r.db.Users, u)
+	return u.ID, nil
+}
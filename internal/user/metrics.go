@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped
+// Repository takes.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "user".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("user"),
+	}
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.User, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	defer m.timer.Since("GetByUsername", time.Now())
+	return m.Repository.GetByUsername(ctx, username)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, username string) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, username)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, u domain.User) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, u)
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
+)
+
+// Repository encapsulates the storage of a user.
+type Repository interface {
+	Get(ctx context.Context, id int) (domain.User, error)
+	GetByUsername(ctx context.Context, username string) (domain.User, error)
+	Exists(ctx context.Context, username string) bool
+	Save(ctx context.Context, u domain.User) (int, error)
+}
+
+type repository struct {
+	db    *sql.DB
+	stmts *storage.Statements
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db:    db,
+		stmts: storage.StatementsOf(db),
+	}
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.User, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.UserGetQuery)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return scanUser(stmt.QueryRowContext(ctx, id))
+}
+
+func (r *repository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.UserGetByUsernameQuery)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return scanUser(stmt.QueryRowContext(ctx, username))
+}
+
+func (r *repository) Exists(ctx context.Context, username string) bool {
+	stmt, err := r.stmts.Prepare(ctx, queries.UserExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, username)
+	err = row.Scan(&username)
+	return err == nil
+}
+
+func (r *repository) Save(ctx context.Context, u domain.User) (int, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.UserSaveQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, u.Username, u.Password)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func scanUser(row *sql.Row) (domain.User, error) {
+	u := domain.User{}
+	err := row.Scan(&u.ID, &u.Username, &u.Password)
+	if err == sql.ErrNoRows {
+		return domain.User{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return u, nil
+}
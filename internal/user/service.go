@@ -0,0 +1,87 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Errors
+var (
+	ErrNotFound           = errors.New("user not found")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUsernameTaken      = errors.New("username already exists")
+)
+
+type Service interface {
+	Get(ctx context.Context, id int) (domain.User, error)
+	Register(ctx context.Context, username, password string) (domain.User, error)
+	Login(ctx context.Context, username, password string) (domain.User, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{
+		repository: r,
+	}
+}
+
+func (s *service) Get(ctx context.Context, id int) (domain.User, error) {
+	return s.repository.Get(ctx, id)
+}
+
+// Register stores a new user with its password hashed with bcrypt.
+func (s *service) Register(ctx context.Context, username, password string) (domain.User, error) {
+	if s.repository.Exists(ctx, username) {
+		return domain.User{}, ErrUsernameTaken
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	u := domain.User{
+		Username: username,
+		Password: hash,
+	}
+	id, err := s.repository.Save(ctx, u)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	u.ID = id
+	return u, nil
+}
+
+// Login returns the user when password matches the stored hash. Unknown users
+// and wrong passwords both return ErrInvalidCredentials.
+func (s *service) Login(ctx context.Context, username, password string) (domain.User, error) {
+	u, err := s.repository.GetByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		return domain.User{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
+		return domain.User{}, ErrInvalidCredentials
+	}
+
+	return u, nil
+}
+
+// HashPassword returns the bcrypt hash of password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
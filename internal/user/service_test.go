@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockUserRepository() *mocks.MockUserRepository {
+	return &mocks.MockUserRepository{
+		MockData:    []domain.User{},
+		ErrNotFound: ErrNotFound,
+	}
+}
+
+func TestRegisterHashesPassword(t *testing.T) {
+	repo := newMockUserRepository()
+	s := NewService(repo)
+
+	u, err := s.Register(context.TODO(), "operator", "secret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, u.ID)
+	assert.NotEqual(t, "secret", repo.MockData[0].Password)
+}
+
+func TestRegisterUsernameTaken(t *testing.T) {
+	s := NewService(newMockUserRepository())
+	_, err := s.Register(context.TODO(), "operator", "secret")
+	assert.NoError(t, err)
+
+	_, err = s.Register(context.TODO(), "operator", "other")
+
+	assert.ErrorIs(t, err, ErrUsernameTaken)
+}
+
+func TestLoginOk(t *testing.T) {
+	s := NewService(newMockUserRepository())
+	created, err := s.Register(context.TODO(), "operator", "secret")
+	assert.NoError(t, err)
+
+	u, err := s.Login(context.TODO(), "operator", "secret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, u.ID)
+}
+
+func TestLoginWrongPassword(t *testing.T) {
+	s := NewService(newMockUserRepository())
+	_, err := s.Register(context.TODO(), "operator", "secret")
+	assert.NoError(t, err)
+
+	_, err = s.Login(context.TODO(), "operator", "wrong")
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLoginUnknownUser(t *testing.T) {
+	s := NewService(newMockUserRepository())
+
+	_, err := s.Login(context.TODO(), "nobody", "secret")
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
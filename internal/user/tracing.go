@@ -0,0 +1,80 @@
+package user
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "user.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("user.Service"),
+	}
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.User, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Register(ctx context.Context, username string, password string) (_ domain.User, err error) {
+	ctx, span := t.tracer.Start(ctx, "Register")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Register(ctx, username, password)
+}
+
+func (t *tracedService) Login(ctx context.Context, username string, password string) (_ domain.User, err error) {
+	ctx, span := t.tracer.Start(ctx, "Login")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Login(ctx, username, password)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "user.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("user.Repository"),
+	}
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.User, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) GetByUsername(ctx context.Context, username string) (_ domain.User, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetByUsername")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetByUsername(ctx, username)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, username string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, username)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, u domain.User) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, u)
+}
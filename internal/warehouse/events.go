@@ -0,0 +1,74 @@
+package warehouse
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+)
+
+// eventedService publishes an event for every change made through the
+// wrapped Service, in the transaction of the change.
+type eventedService struct {
+	Service
+	outbox outbox.Publisher
+}
+
+// NewEventedService wraps s so that Save, Update, Delete and Restore publish
+// WarehouseCreated, WarehouseUpdated, WarehouseDeleted and WarehouseRestored events to o.
+func NewEventedService(s Service, o outbox.Publisher) Service {
+	return &eventedService{
+		Service: s,
+		outbox:  o,
+	}
+}
+
+func (e *eventedService) Save(ctx context.Context, w domain.Warehouse) (int, error) {
+	err := e.outbox.Transact(ctx, func(ctx context.Context) error {
+		id, err := e.Service.Save(ctx, w)
+		if err != nil {
+			return err
+		}
+		w.ID = id
+		return e.outbox.Publish(ctx, domain.WarehouseCreated{Warehouse: w})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return w.ID, nil
+}
+
+func (e *eventedService) Update(ctx context.Context, w domain.Warehouse) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Update(ctx, w); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, w.ID)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.WarehouseUpdated{Warehouse: after})
+	})
+}
+
+func (e *eventedService) Delete(ctx context.Context, id, version int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.WarehouseDeleted{ID: id})
+	})
+}
+
+func (e *eventedService) Restore(ctx context.Context, id int) error {
+	return e.outbox.Transact(ctx, func(ctx context.Context) error {
+		if err := e.Service.Restore(ctx, id); err != nil {
+			return err
+		}
+		after, err := e.Service.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return e.outbox.Publish(ctx, domain.WarehouseRestored{Warehouse: after})
+	})
+}
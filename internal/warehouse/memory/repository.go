@@ -0,0 +1,252 @@
+// Package memory implements warehouse.Repository over the tables of a
+// memdb.DB, with the same results and errors as the MySQL repository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/warehouse"
+)
+
+type repository struct {
+	db *memdb.DB
+}
+
+// NewRepository returns a warehouse.Repository that keeps the warehouses in
+// db.
+func NewRepository(db *memdb.DB) warehouse.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// index returns the position of the warehouse with id, or -1.
+func (r *repository) index(id int) int {
+	for i, w := range r.db.Warehouses {
+		if w.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
+	var warehouses []domain.Warehouse
+	err := r.StreamAll(ctx, includeDeleted, func(w domain.Warehouse) error {
+		warehouses = append(warehouses, w)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouses, nil
+}
+
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	r.db.RLock()
+	var warehouses []domain.Warehouse
+	for _, w := range r.db.Warehouses {
+		if includeDeleted || w.DeletedAt == nil {
+			warehouses = append(warehouses, w)
+		}
+	}
+	r.db.RUnlock()
+
+	for _, w := range warehouses {
+		if err := fn(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Warehouses[i].DeletedAt != nil {
+		return domain.Warehouse{}, sql.ErrNoRows
+	}
+	return r.db.Warehouses[i], nil
+}
+
+func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	for _, w := range r.db.Warehouses {
+		if w.WarehouseCode == warehouseCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error) {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	w.ID = r.db.NextID("warehouses")
+	w.Version = 1
+	w.DeletedAt = nil
+	r.db.Warehouses = append(r.db.Warehouses, w)
+	return w.ID, nil
+}
+
+func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(w.ID)
+	if i < 0 || r.db.Warehouses[i].Version != w.Version || r.db.Warehouses[i].DeletedAt != nil {
+		return domain.ErrVersionConflict
+	}
+	w.Version++
+	r.db.Warehouses[i] = w
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Warehouses[i].DeletedAt != nil {
+		return warehouse.ErrNotFound
+	}
+	if version != 0 && r.db.Warehouses[i].Version != version {
+		return domain.ErrVersionConflict
+	}
+	if dependents := r.dependents(id); len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "warehouse", ID: id, Dependents: dependents}
+	}
+	now := r.db.Now()
+	r.db.Warehouses[i].DeletedAt = &now
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	r.db.Lock()
+	defer r.db.Unlock()
+
+	i := r.index(id)
+	if i < 0 || r.db.Warehouses[i].DeletedAt == nil {
+		return warehouse.ErrNotFound
+	}
+	r.db.Warehouses[i].DeletedAt = nil
+	return nil
+}
+
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	return r.dependents(id), nil
+}
+
+// dependents counts the records referencing the warehouse id. The caller holds
+// the lock of r.db.
+func (r *repository) dependents(id int) []domain.Dependent {
+	counts := make([]int, 2)
+	for _, s := range r.db.Sections {
+		if s.WarehouseID == id && s.DeletedAt == nil {
+			counts[0]++
+		}
+	}
+	for _, e := range r.db.Employees {
+		if e.WarehouseID == id && e.DeletedAt == nil {
+			counts[1]++
+		}
+	}
+	return domain.NewDependents([]string{"sections", "employees"}, counts)
+}
+
+// sections returns the sections not deleted of the warehouse, or of every
+// warehouse when warehouseID is 0.
+func (r *repository) sections(warehouseID int) []domain.Section {
+	var sections []domain.Section
+	for _, s := range r.db.Sections {
+		if s.DeletedAt == nil && (warehouseID == 0 || s.WarehouseID == warehouseID) {
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
+
+func (r *repository) SectionUsage(ctx context.Context, warehouseID int) ([]domain.SectionUtilization, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	usage := []domain.SectionUtilization{}
+	for _, s := range r.sections(warehouseID) {
+		u := domain.SectionUtilization{
+			WarehouseID:     s.WarehouseID,
+			SectionID:       s.ID,
+			SectionNumber:   s.SectionNumber,
+			ProductTypeID:   s.ProductTypeID,
+			MinimumCapacity: s.MinimumCapacity,
+			MaximumCapacity: s.MaximumCapacity,
+		}
+		for _, b := range r.db.ProductBatches {
+			if b.SectionId == s.ID {
+				u.Used += b.CurrentQuantity
+			}
+		}
+		usage = append(usage, u)
+	}
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].WarehouseID != usage[j].WarehouseID {
+			return usage[i].WarehouseID < usage[j].WarehouseID
+		}
+		return usage[i].SectionNumber < usage[j].SectionNumber
+	})
+	return usage, nil
+}
+
+func (r *repository) ProductTypeMix(ctx context.Context, warehouseID int) ([]domain.ProductTypeMix, error) {
+	r.db.RLock()
+	defer r.db.RUnlock()
+
+	mix := []domain.ProductTypeMix{}
+	for _, s := range r.sections(warehouseID) {
+		for _, b := range r.db.ProductBatches {
+			if b.SectionId != s.ID {
+				continue
+			}
+			p, ok := r.db.Product(b.ProductId)
+			if !ok {
+				continue
+			}
+			i := 0
+			for i < len(mix) && (mix[i].WarehouseID != s.WarehouseID || mix[i].ProductTypeID != p.ProductTypeID) {
+				i++
+			}
+			if i == len(mix) {
+				mix = append(mix, domain.ProductTypeMix{WarehouseID: s.WarehouseID, ProductTypeID: p.ProductTypeID, Description: r.productType(p.ProductTypeID)})
+			}
+			mix[i].Quantity += b.CurrentQuantity
+		}
+	}
+	sort.SliceStable(mix, func(i, j int) bool {
+		if mix[i].WarehouseID != mix[j].WarehouseID {
+			return mix[i].WarehouseID < mix[j].WarehouseID
+		}
+		return mix[i].Quantity > mix[j].Quantity
+	})
+	return mix, nil
+}
+
+func (r *repository) productType(id int) string {
+	for _, pt := range r.db.ProductTypes {
+		if pt.ID == id {
+			return pt.Description
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/metrics"
+)
+
+// measuredRepository records how long each method of the wrapped Repository
+// takes. The time of a Stream method includes the time taken by its fn.
+type measuredRepository struct {
+	Repository
+	timer metrics.Timer
+}
+
+// NewMeasuredRepository wraps r so the duration of its methods is recorded
+// in m under the repository name "warehouse".
+func NewMeasuredRepository(r Repository, m *metrics.Metrics) Repository {
+	return &measuredRepository{
+		Repository: r,
+		timer:      m.Repository("warehouse"),
+	}
+}
+
+func (m *measuredRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
+	defer m.timer.Since("GetAll", time.Now())
+	return m.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (m *measuredRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	defer m.timer.Since("StreamAll", time.Now())
+	return m.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (m *measuredRepository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
+	defer m.timer.Since("Get", time.Now())
+	return m.Repository.Get(ctx, id)
+}
+
+func (m *measuredRepository) Exists(ctx context.Context, warehouseCode string) bool {
+	defer m.timer.Since("Exists", time.Now())
+	return m.Repository.Exists(ctx, warehouseCode)
+}
+
+func (m *measuredRepository) Save(ctx context.Context, w domain.Warehouse) (int, error) {
+	defer m.timer.Since("Save", time.Now())
+	return m.Repository.Save(ctx, w)
+}
+
+func (m *measuredRepository) Update(ctx context.Context, w domain.Warehouse) error {
+	defer m.timer.Since("Update", time.Now())
+	return m.Repository.Update(ctx, w)
+}
+
+func (m *measuredRepository) Delete(ctx context.Context, id, version int) error {
+	defer m.timer.Since("Delete", time.Now())
+	return m.Repository.Delete(ctx, id, version)
+}
+
+func (m *measuredRepository) Restore(ctx context.Context, id int) error {
+	defer m.timer.Since("Restore", time.Now())
+	return m.Repository.Restore(ctx, id)
+}
+
+func (m *measuredRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	defer m.timer.Since("Dependents", time.Now())
+	return m.Repository.Dependents(ctx, id)
+}
+
+func (m *measuredRepository) SectionUsage(ctx context.Context, warehouseID int) ([]domain.SectionUtilization, error) {
+	defer m.timer.Since("SectionUsage", time.Now())
+	return m.Repository.SectionUsage(ctx, warehouseID)
+}
+
+func (m *measuredRepository) ProductTypeMix(ctx context.Context, warehouseID int) ([]domain.ProductTypeMix, error) {
+	defer m.timer.Since("ProductTypeMix", time.Now())
+	return m.Repository.ProductTypeMix(ctx, warehouseID)
+}
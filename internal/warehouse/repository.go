@@ -3,52 +3,91 @@ package warehouse
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/storage"
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/utils/queries"
 )
 
 // Repository encapsulates the storage of a warehouse.
 type Repository interface {
-	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error
 	Get(ctx context.Context, id int) (domain.Warehouse, error)
 	Exists(ctx context.Context, warehouseCode string) bool
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) ([]domain.Dependent, error)
+	SectionUsage(ctx context.Context, warehouseID int) ([]domain.SectionUtilization, error)
+	ProductTypeMix(ctx context.Context, warehouseID int) ([]domain.ProductTypeMix, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *storage.Statements
 }
 
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
-		db: db,
+		db:    db,
+		stmts: storage.StatementsOf(db),
 	}
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	rows, err := r.db.QueryContext(ctx, queries.WarehouseGetAllQuery)
+func (r *repository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
+	var warehouses []domain.Warehouse
+	err := r.StreamAll(ctx, includeDeleted, func(w domain.Warehouse) error {
+		warehouses = append(warehouses, w)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var warehouses []domain.Warehouse
+	return warehouses, nil
+}
+
+// StreamAll calls fn with each warehouse as it is read from the database,
+// stopping at the first error fn returns.
+func (r *repository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	query := queries.WarehouseGetAllQuery
+	if !includeDeleted {
+		query += queries.WarehouseNotDeleted
+	}
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return err
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		w := domain.Warehouse{}
-		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
-		warehouses = append(warehouses, w)
+		if err := rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.Version, &w.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(w); err != nil {
+			return err
+		}
 	}
 
-	return warehouses, nil
+	return rows.Err()
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
-	row := r.db.QueryRowContext(ctx, queries.WarehouseGetQuery, id)
+	stmt, err := r.stmts.Prepare(ctx, queries.WarehouseGetQuery)
+	if err != nil {
+		return domain.Warehouse{}, err
+	}
+	row := stmt.QueryRowContext(ctx, id)
 	w := domain.Warehouse{}
-	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	err = row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.Version, &w.DeletedAt)
 	if err != nil {
 		return domain.Warehouse{}, err
 	}
@@ -57,13 +96,17 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error)
 }
 
 func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
-	row := r.db.QueryRowContext(ctx, queries.WarehouseExistsQuery, warehouseCode)
-	err := row.Scan(&warehouseCode)
+	stmt, err := r.stmts.Prepare(ctx, queries.WarehouseExistsQuery)
+	if err != nil {
+		return false
+	}
+	row := stmt.QueryRowContext(ctx, warehouseCode)
+	err = row.Scan(&warehouseCode)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error) {
-	stmt, err := r.db.PrepareContext(ctx, queries.WarehouseSaveQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.WarehouseSaveQuery)
 	if err != nil {
 		return 0, err
 	}
@@ -82,26 +125,74 @@ func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error)
 }
 
 func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
-	stmt, err := r.db.PrepareContext(ctx, queries.WarehouseUpdateQuery)
+	stmt, err := r.stmts.Prepare(ctx, queries.WarehouseUpdateQuery)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.ID)
+	res, err := stmt.ExecContext(ctx, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.ID, &w.Version)
 	if err != nil {
 		return err
 	}
 
-	_, err = res.RowsAffected()
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return domain.ErrVersionConflict
+	}
+
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.PrepareContext(ctx, queries.WarehouseDeleteQuery)
+// Delete soft deletes the warehouse unless records still reference it, which
+// returns a *domain.DependentsError. The references are counted after the
+// delete, in its transaction, which is rolled back when there are any. A
+// version other than 0 deletes the warehouse only at that version, returning
+// domain.ErrVersionConflict otherwise.
+func (r *repository) Delete(ctx context.Context, id, version int) error {
+	return storage.Transact(ctx, r.db, func(ctx context.Context) error {
+		query, args := queries.WarehouseDeleteQuery, []interface{}{id}
+		if version != 0 {
+			query, args = queries.WarehouseDeleteVersionQuery, []interface{}{id, version}
+		}
+		stmt, err := r.stmts.Prepare(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		res, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affect < 1 && version != 0 {
+			return domain.ErrVersionConflict
+		}
+		if affect < 1 {
+			return ErrNotFound
+		}
+
+		dependents, err := r.Dependents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return &domain.DependentsError{Entity: "warehouse", ID: id, Dependents: dependents}
+		}
+		return nil
+	})
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Prepare(ctx, queries.WarehouseRestoreQuery)
 	if err != nil {
 		return err
 	}
@@ -122,3 +213,79 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// Dependents counts the records that still reference the warehouse.
+func (r *repository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	stmt, err := r.stmts.Prepare(ctx, queries.WarehouseDependentsQuery)
+	if err != nil {
+		return nil, err
+	}
+	row := stmt.QueryRowContext(ctx, id, id)
+	counts := make([]int, 2)
+	if err := row.Scan(&counts[0], &counts[1]); err != nil {
+		return nil, err
+	}
+
+	return domain.NewDependents([]string{"sections", "employees"}, counts), nil
+}
+
+// usageQuery fills the warehouse filter of query, restricting it to
+// warehouseID unless it is 0.
+func usageQuery(query string, warehouseID int) (string, []interface{}) {
+	if warehouseID == 0 {
+		return fmt.Sprintf(query, ""), nil
+	}
+	return fmt.Sprintf(query, queries.WarehouseUsageFilter), []interface{}{warehouseID}
+}
+
+// SectionUsage returns the capacities of the sections of the warehouse, or of
+// every warehouse when warehouseID is 0, with the quantity stored in each.
+func (r *repository) SectionUsage(ctx context.Context, warehouseID int) ([]domain.SectionUtilization, error) {
+	query, args := usageQuery(queries.WarehouseSectionUsageQuery, warehouseID)
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := []domain.SectionUtilization{}
+	for rows.Next() {
+		s := domain.SectionUtilization{}
+		if err := rows.Scan(&s.WarehouseID, &s.SectionID, &s.SectionNumber, &s.ProductTypeID, &s.MinimumCapacity, &s.MaximumCapacity, &s.Used); err != nil {
+			return nil, err
+		}
+		sections = append(sections, s)
+	}
+
+	return sections, rows.Err()
+}
+
+// ProductTypeMix returns the quantity of each product type stored in the
+// warehouse, or in every warehouse when warehouseID is 0.
+func (r *repository) ProductTypeMix(ctx context.Context, warehouseID int) ([]domain.ProductTypeMix, error) {
+	query, args := usageQuery(queries.WarehouseProductTypeMixQuery, warehouseID)
+	stmt, err := r.stmts.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mix := []domain.ProductTypeMix{}
+	for rows.Next() {
+		m := domain.ProductTypeMix{}
+		if err := rows.Scan(&m.WarehouseID, &m.ProductTypeID, &m.Description, &m.Quantity); err != nil {
+			return nil, err
+		}
+		mix = append(mix, m)
+	}
+
+	return mix, rows.Err()
+}
@@ -13,12 +13,16 @@ var (
 )
 
 type Service interface {
-	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error)
+	StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error
 	Get(ctx context.Context, id int) (domain.Warehouse, error)
 	Exists(ctx context.Context, warehouseCode string) bool
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
+	Utilization(ctx context.Context, id int) (domain.WarehouseUtilization, error)
+	Utilizations(ctx context.Context) ([]domain.WarehouseUtilization, error)
 }
 
 type service struct {
@@ -31,8 +35,12 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	return s.repository.GetAll(ctx)
+func (s *service) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
+	return s.repository.GetAll(ctx, includeDeleted)
+}
+
+func (s *service) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	return s.repository.StreamAll(ctx, includeDeleted, fn)
 }
 
 func (s *service) Get(ctx context.Context, id int) (domain.Warehouse, error) {
@@ -51,6 +59,60 @@ func (s *service) Update(ctx context.Context, w domain.Warehouse) error {
 	return s.repository.Update(ctx, w)
 }
 
-func (s *service) Delete(ctx context.Context, id int) error {
-	return s.repository.Delete(ctx, id)
+func (s *service) Delete(ctx context.Context, id, version int) error {
+	return s.repository.Delete(ctx, id, version)
+}
+
+// Restore brings back a deleted warehouse. It returns ErrNotFound when the warehouse
+// does not exist or is not deleted.
+func (s *service) Restore(ctx context.Context, id int) error {
+	return s.repository.Restore(ctx, id)
+}
+
+// Utilization reports how full the warehouse id is.
+func (s *service) Utilization(ctx context.Context, id int) (domain.WarehouseUtilization, error) {
+	w, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.WarehouseUtilization{}, err
+	}
+	sections, err := s.repository.SectionUsage(ctx, id)
+	if err != nil {
+		return domain.WarehouseUtilization{}, err
+	}
+	mix, err := s.repository.ProductTypeMix(ctx, id)
+	if err != nil {
+		return domain.WarehouseUtilization{}, err
+	}
+	return domain.NewWarehouseUtilization(w, sections, mix), nil
+}
+
+// Utilizations reports how full every warehouse is.
+func (s *service) Utilizations(ctx context.Context) ([]domain.WarehouseUtilization, error) {
+	warehouses, err := s.repository.GetAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.repository.SectionUsage(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	mix, err := s.repository.ProductTypeMix(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionsByWarehouse := map[int][]domain.SectionUtilization{}
+	for _, section := range sections {
+		sectionsByWarehouse[section.WarehouseID] = append(sectionsByWarehouse[section.WarehouseID], section)
+	}
+	mixByWarehouse := map[int][]domain.ProductTypeMix{}
+	for _, m := range mix {
+		mixByWarehouse[m.WarehouseID] = append(mixByWarehouse[m.WarehouseID], m)
+	}
+
+	utilizations := make([]domain.WarehouseUtilization, len(warehouses))
+	for i, w := range warehouses {
+		utilizations[i] = domain.NewWarehouseUtilization(w, sectionsByWarehouse[w.ID], mixByWarehouse[w.ID])
+	}
+	return utilizations, nil
 }
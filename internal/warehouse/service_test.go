@@ -63,7 +63,7 @@ func TestFindAllWarehouse(t *testing.T) {
 	}
 	service := NewService(mockRepository)
 
-	resp, err := service.GetAll(context.TODO())
+	resp, err := service.GetAll(context.TODO(), false)
 
 	//Test sin error
 	assert.Nil(t, err)
@@ -150,7 +150,7 @@ func TestDeleteNonExistentWarehouse(t *testing.T) {
 
 	oldDATA := mockRepository.MockData
 
-	err := service.Delete(context.TODO(), 3)
+	err := service.Delete(context.TODO(), 3, 0)
 
 	//Test con error
 	assert.NotNil(t, err)
@@ -159,6 +159,26 @@ func TestDeleteNonExistentWarehouse(t *testing.T) {
 	assert.Equal(t, oldDATA, mockRepository.MockData)
 }
 
+func TestDeleteWarehouseWithDependents(t *testing.T) {
+	mockRepository := &mocks.MockWarehouseRepository{
+		MockData:       mocks.MockDataWarehouse,
+		MockDependents: map[int][]domain.Dependent{1: {{Entity: "employees", Count: 3}}},
+	}
+	service := NewService(mockRepository)
+
+	oldDATA := mockRepository.MockData
+
+	err := service.Delete(context.TODO(), 1, 0)
+
+	//Test con error de dependientes
+	var depErr *domain.DependentsError
+	assert.ErrorAs(t, err, &depErr)
+	assert.Equal(t, []domain.Dependent{{Entity: "employees", Count: 3}}, depErr.Dependents)
+
+	//Test BBDD no actualizada
+	assert.Equal(t, oldDATA, mockRepository.MockData)
+}
+
 func TestDeleteExistentWarehouse(t *testing.T) {
 	var data []domain.Warehouse = []domain.Warehouse{}
 	data = append(data, mocks.MockDataWarehouse...)
@@ -169,7 +189,7 @@ func TestDeleteExistentWarehouse(t *testing.T) {
 	service := NewService(mockRepository)
 	oldDATA := mockRepository.MockData
 
-	err := service.Delete(context.TODO(), 1)
+	err := service.Delete(context.TODO(), 1, 0)
 
 	//Test sin error
 	assert.Nil(t, err)
@@ -200,3 +220,43 @@ func TestNonExistsWarehouseCode(t *testing.T) {
 	//Test de Existencia falso
 	assert.False(t, exists)
 }
+
+func TestUtilizationsWarehouse(t *testing.T) {
+	service := NewService(&mocks.MockWarehouseRepository{
+		MockData: []domain.Warehouse{{ID: 1, WarehouseCode: "DHM"}, {ID: 2, WarehouseCode: "EMPTY"}},
+		MockSections: []domain.SectionUtilization{
+			{WarehouseID: 1, SectionID: 10, MinimumCapacity: 20, MaximumCapacity: 100, Used: 10},
+			{WarehouseID: 1, SectionID: 11, MinimumCapacity: 10, MaximumCapacity: 100, Used: 150},
+		},
+		MockMix: []domain.ProductTypeMix{
+			{WarehouseID: 1, ProductTypeID: 1, Quantity: 120},
+			{WarehouseID: 1, ProductTypeID: 2, Quantity: 40},
+		},
+	})
+
+	utilizations, err := service.Utilizations(context.TODO())
+
+	assert.Nil(t, err)
+	assert.Len(t, utilizations, 2)
+
+	u := utilizations[0]
+	assert.Equal(t, 200, u.Capacity)
+	assert.Equal(t, 160, u.Used)
+	assert.Equal(t, 40, u.Free)
+	assert.Equal(t, 0.8, u.Utilization)
+	assert.Equal(t, []int{10}, u.SectionsBelowMinimum)
+	assert.Equal(t, []int{11}, u.SectionsAboveMaximum)
+	assert.Equal(t, 90, u.Sections[0].Free)
+	assert.Equal(t, 0, u.Sections[1].Free)
+	assert.Equal(t, 0.75, u.ProductTypes[0].Share)
+	assert.Equal(t, 0.25, u.ProductTypes[1].Share)
+
+	assert.Equal(t, domain.WarehouseUtilization{
+		WarehouseID:          2,
+		WarehouseCode:        "EMPTY",
+		SectionsBelowMinimum: []int{},
+		SectionsAboveMaximum: []int{},
+		Sections:             []domain.SectionUtilization{},
+		ProductTypes:         []domain.ProductTypeMix{},
+	}, utilizations[1])
+}
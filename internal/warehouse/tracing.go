@@ -0,0 +1,164 @@
+package warehouse
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/tracing"
+)
+
+// tracedService runs each method of the wrapped Service in a span.
+type tracedService struct {
+	Service
+	tracer tracing.Tracer
+}
+
+// NewTracedService wraps s so each of its methods runs in a span named
+// "warehouse.Service.<method>".
+func NewTracedService(s Service) Service {
+	return &tracedService{
+		Service: s,
+		tracer:  tracing.New("warehouse.Service"),
+	}
+}
+
+func (t *tracedService) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Warehouse, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedService) Get(ctx context.Context, id int) (_ domain.Warehouse, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Get(ctx, id)
+}
+
+func (t *tracedService) Exists(ctx context.Context, warehouseCode string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Service.Exists(ctx, warehouseCode)
+}
+
+func (t *tracedService) Save(ctx context.Context, w domain.Warehouse) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Save(ctx, w)
+}
+
+func (t *tracedService) Update(ctx context.Context, w domain.Warehouse) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Update(ctx, w)
+}
+
+func (t *tracedService) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Delete(ctx, id, version)
+}
+
+func (t *tracedService) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Restore(ctx, id)
+}
+
+func (t *tracedService) Utilization(ctx context.Context, id int) (_ domain.WarehouseUtilization, err error) {
+	ctx, span := t.tracer.Start(ctx, "Utilization")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Utilization(ctx, id)
+}
+
+func (t *tracedService) Utilizations(ctx context.Context) (_ []domain.WarehouseUtilization, err error) {
+	ctx, span := t.tracer.Start(ctx, "Utilizations")
+	defer func() { tracing.End(span, err) }()
+	return t.Service.Utilizations(ctx)
+}
+
+// tracedRepository runs each method of the wrapped Repository in a span.
+type tracedRepository struct {
+	Repository
+	tracer tracing.Tracer
+}
+
+// NewTracedRepository wraps r so each of its methods runs in a span named
+// "warehouse.Repository.<method>".
+func NewTracedRepository(r Repository) Repository {
+	return &tracedRepository{
+		Repository: r,
+		tracer:     tracing.New("warehouse.Repository"),
+	}
+}
+
+func (t *tracedRepository) GetAll(ctx context.Context, includeDeleted bool) (_ []domain.Warehouse, err error) {
+	ctx, span := t.tracer.Start(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.GetAll(ctx, includeDeleted)
+}
+
+func (t *tracedRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) (err error) {
+	ctx, span := t.tracer.Start(ctx, "StreamAll")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.StreamAll(ctx, includeDeleted, fn)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, id int) (_ domain.Warehouse, err error) {
+	ctx, span := t.tracer.Start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Get(ctx, id)
+}
+
+func (t *tracedRepository) Exists(ctx context.Context, warehouseCode string) bool {
+	ctx, span := t.tracer.Start(ctx, "Exists")
+	defer span.End()
+	return t.Repository.Exists(ctx, warehouseCode)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, w domain.Warehouse) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Save(ctx, w)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, w domain.Warehouse) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Update(ctx, w)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Delete(ctx, id, version)
+}
+
+func (t *tracedRepository) Restore(ctx context.Context, id int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "Restore")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Restore(ctx, id)
+}
+
+func (t *tracedRepository) Dependents(ctx context.Context, id int) (_ []domain.Dependent, err error) {
+	ctx, span := t.tracer.Start(ctx, "Dependents")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.Dependents(ctx, id)
+}
+
+func (t *tracedRepository) SectionUsage(ctx context.Context, warehouseID int) (_ []domain.SectionUtilization, err error) {
+	ctx, span := t.tracer.Start(ctx, "SectionUsage")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.SectionUsage(ctx, warehouseID)
+}
+
+func (t *tracedRepository) ProductTypeMix(ctx context.Context, warehouseID int) (_ []domain.ProductTypeMix, err error) {
+	ctx, span := t.tracer.Start(ctx, "ProductTypeMix")
+	defer func() { tracing.End(span, err) }()
+	return t.Repository.ProductTypeMix(ctx, warehouseID)
+}
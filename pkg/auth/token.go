@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ClaimsKey is the key under which the authenticated caller's claims are
+// stored in the request context.
+const ClaimsKey = "auth.claims"
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Claims identifies the caller of a request. Roles are copied into the token
+// at login, so role changes apply once the user gets a new token.
+type Claims struct {
+	UserID   int      `json:"uid"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// HasRole reports whether the caller has any of the given roles.
+func (c Claims) HasRole(roles ...string) bool {
+	for _, have := range c.Roles {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Tokens issues and verifies HMAC signed JWTs.
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokens(secret string, ttl time.Duration) *Tokens {
+	return &Tokens{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// Issue signs a token for the given user that expires after the configured ttl.
+func (t *Tokens) Issue(userID int, username string, roles []string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(t.ttl)
+	claims := Claims{
+		UserID:   userID,
+		Username: username,
+		Roles:    roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   fmt.Sprint(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse verifies the signature and expiration of token and returns its claims.
+func (t *Tokens) Parse(token string) (Claims, error) {
+	claims := Claims{}
+	parsed, err := jwt.ParseWithClaims(token, &claims, func(tk *jwt.Token) (interface{}, error) {
+		if _, ok := tk.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", tk.Header["alg"])
+		}
+		return t.secret, nil
+	})
+	if err != nil || !parsed.Valid {
+		return Claims{}, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// FromContext returns the claims of the authenticated caller, if any.
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(ClaimsKey).(Claims)
+	return claims, ok
+}
@@ -0,0 +1,52 @@
+// Package cache keeps computed values, encoded as JSON, for a limited time.
+// LRU keeps them in process; the Cache interface leaves room for a shared
+// store such as Redis.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+)
+
+// Cache stores values under string keys for a limited time. Errors mean the
+// store could not be reached; callers treat them as misses.
+type Cache interface {
+	// Get returns the value stored under key, or false when there is none
+	// or it expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key until ttl elapses.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every value whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// Fetch fills v, a pointer, with the value cached under key. On a miss it
+// calls load, which must fill v, and caches the result for ttl unless load
+// fails. Errors of c are logged and handled as misses, so Fetch only fails
+// when load does.
+func Fetch(ctx context.Context, c Cache, key string, ttl time.Duration, v interface{}, load func() error) error {
+	data, ok, err := c.Get(ctx, key)
+	if err != nil {
+		web.Logger(ctx).WarnContext(ctx, "reading cache failed", "key", key, "error", err)
+	}
+	if ok && json.Unmarshal(data, v) == nil {
+		return nil
+	}
+
+	if err := load(); err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	if data, err = json.Marshal(v); err == nil {
+		err = c.Set(ctx, key, data, ttl)
+	}
+	if err != nil {
+		web.Logger(ctx).WarnContext(ctx, "writing cache failed", "key", key, "error", err)
+	}
+	return nil
+}
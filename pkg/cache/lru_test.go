@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(2)
+
+	assert.NoError(t, l.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, l.Set(ctx, "b", []byte("2"), time.Minute))
+	_, ok, _ := l.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.NoError(t, l.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, ok, _ = l.Get(ctx, "b")
+	assert.False(t, ok, "b was the least recently used")
+	value, ok, _ := l.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, l.Len())
+}
+
+func TestLRUExpiresValues(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLRU(10)
+	l.now = func() time.Time { return now }
+
+	assert.NoError(t, l.Set(ctx, "a", []byte("1"), time.Second))
+	now = now.Add(999 * time.Millisecond)
+	_, ok, _ := l.Get(ctx, "a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Millisecond)
+	_, ok, _ = l.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, l.Len())
+}
+
+func TestLRUDeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(10)
+	assert.NoError(t, l.Set(ctx, "report:1", []byte("1"), time.Minute))
+	assert.NoError(t, l.Set(ctx, "report:all", []byte("2"), time.Minute))
+	assert.NoError(t, l.Set(ctx, "other:1", []byte("3"), time.Minute))
+
+	assert.NoError(t, l.DeletePrefix(ctx, "report:"))
+
+	_, ok, _ := l.Get(ctx, "report:1")
+	assert.False(t, ok)
+	_, ok, _ = l.Get(ctx, "other:1")
+	assert.True(t, ok)
+	assert.Equal(t, 1, l.Len())
+}
+
+func TestFetchCachesLoadedValues(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(10)
+	loads := 0
+	load := func(v *[]int) func() error {
+		return func() error {
+			loads++
+			*v = []int{1, 2}
+			return nil
+		}
+	}
+
+	var first, second []int
+	assert.NoError(t, Fetch(ctx, l, "k", time.Minute, &first, load(&first)))
+	assert.NoError(t, Fetch(ctx, l, "k", time.Minute, &second, load(&second)))
+	assert.Equal(t, []int{1, 2}, first)
+	assert.Equal(t, []int{1, 2}, second)
+	assert.Equal(t, 1, loads)
+}
+
+func TestFetchDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(10)
+	errLoad := errors.New("load failed")
+
+	var v int
+	err := Fetch(ctx, l, "k", time.Minute, &v, func() error { return errLoad })
+	assert.ErrorIs(t, err, errLoad)
+	assert.Equal(t, 0, l.Len())
+
+	assert.NoError(t, Fetch(ctx, l, "k", time.Minute, &v, func() error { v = 7; return nil }))
+	assert.Equal(t, 7, v)
+}
+
+func TestFetchWithoutTTLDoesNotCache(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(10)
+
+	var v int
+	assert.NoError(t, Fetch(ctx, l, "k", 0, &v, func() error { v = 7; return nil }))
+	assert.Equal(t, 7, v)
+	assert.Equal(t, 0, l.Len())
+}
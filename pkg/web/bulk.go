@@ -0,0 +1,168 @@
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CSVContentType    = "text/csv"
+	NDJSONContentType = "application/x-ndjson"
+)
+
+var ErrUnsupportedBulkType = errors.New("content type must be " + CSVContentType + " or " + NDJSONContentType)
+
+// BulkRow is one row of a bulk request body. Row counts the rows from 1,
+// leaving out the CSV header and blank NDJSON lines. Err holds why the row
+// could not be decoded or did not pass validation, in which case Value must
+// not be used.
+type BulkRow struct {
+	Row   int
+	Value interface{}
+	Err   error
+}
+
+// BulkRows decodes every row of a bulk request body into a new value from
+// newRow, a pointer to a struct, and validates it against its binding tags.
+// CSV bodies start with a header naming the JSON members of the columns and
+// leave empty the cells of members not given; NDJSON bodies hold a JSON
+// object per line. An error is returned only when the body as a whole can't
+// be read.
+func BulkRows(c *gin.Context, newRow func() interface{}) ([]BulkRow, error) {
+	var rows []BulkRow
+	var err error
+	switch c.ContentType() {
+	case CSVContentType:
+		rows, err = csvRows(c.Request.Body, newRow)
+	case NDJSONContentType:
+		rows, err = ndjsonRows(c.Request.Body, newRow)
+	default:
+		return nil, ErrUnsupportedBulkType
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if rows[i].Err == nil {
+			rows[i].Err = Validate(rows[i].Value)
+		}
+	}
+	return rows, nil
+}
+
+func csvRows(body io.Reader, newRow func() interface{}) ([]BulkRow, error) {
+	r := csv.NewReader(body)
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := jsonFields(reflect.TypeOf(newRow()))
+	columns := make([]reflect.StructField, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		header[i], columns[i] = name, field
+	}
+
+	var rows []BulkRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		row := BulkRow{Row: len(rows) + 1, Value: newRow()}
+		switch {
+		case errors.Is(err, csv.ErrFieldCount):
+			row.Err = fmt.Errorf("has %d columns, the header has %d", len(record), len(header))
+		case err != nil:
+			return nil, err
+		default:
+			row.Err = setColumns(reflect.ValueOf(row.Value).Elem(), header, columns, record)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// setColumns stores the cells of record in the fields of v, leaving the zero
+// value in those with an empty cell.
+func setColumns(v reflect.Value, header []string, columns []reflect.StructField, record []string) error {
+	for i, cell := range record {
+		if cell == "" {
+			continue
+		}
+		field := v.FieldByIndex(columns[i].Index)
+		if field.Kind() == reflect.Ptr {
+			field.Set(reflect.New(field.Type().Elem()))
+			field = field.Elem()
+		}
+		if err := setCell(field, cell); err != nil {
+			return fmt.Errorf("column %s: %q is not a valid %s", header[i], cell, field.Kind())
+		}
+	}
+	return nil
+}
+
+func setCell(field reflect.Value, cell string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(cell, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported kind %s", field.Kind())
+	}
+	return nil
+}
+
+func ndjsonRows(body io.Reader, newRow func() interface{}) ([]BulkRow, error) {
+	r := bufio.NewReader(body)
+	var rows []BulkRow
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			row := BulkRow{Row: len(rows) + 1, Value: newRow()}
+			row.Err = json.Unmarshal(line, row.Value)
+			rows = append(rows, row)
+		}
+		if err == io.EOF {
+			return rows, nil
+		}
+	}
+}
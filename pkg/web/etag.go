@@ -0,0 +1,76 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// ETag formats the version of a record as a strong entity tag.
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// SetETag sends the entity tag of the record version in the response.
+func SetETag(c *gin.Context, version int) {
+	c.Header(ETagHeader, ETag(version))
+}
+
+// IfMatch reports whether the request's If-Match header allows changing the
+// record at version. A request without the header, or with "*", always
+// matches.
+func IfMatch(c *gin.Context, version int) bool {
+	header := c.GetHeader(IfMatchHeader)
+	if header == "" {
+		return true
+	}
+
+	current := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	IfNoneMatchHeader  = "If-None-Match"
+	CacheControlHeader = "Cache-Control"
+)
+
+// SuccessCacheable is like Success for responses clients may keep and
+// revalidate, such as reports: they are sent with an entity tag computed
+// from the body and "Cache-Control: private, no-cache", and a request whose
+// If-None-Match header holds that tag is answered with a 304 and no body.
+func SuccessCacheable(c *gin.Context, status int, data interface{}) {
+	body, err := json.Marshal(response{Data: data})
+	if err != nil {
+		Success(c, status, data)
+		return
+	}
+	sum := sha256.Sum256(body)
+	tag := strconv.Quote(hex.EncodeToString(sum[:16]))
+	c.Header(ETagHeader, tag)
+	c.Header(CacheControlHeader, "private, no-cache")
+
+	for _, t := range strings.Split(c.GetHeader(IfNoneMatchHeader), ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == tag {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	c.Data(status, gin.MIMEJSON+"; charset=utf-8", body)
+}
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportFlushRows is how many rows are written between flushes of a
+// streamed export.
+const exportFlushRows = 100
+
+// BufferExportsKey is the gin context key that, set to true, makes Export
+// read every row before writing the first. A streamed export holds its
+// database connection until the client has downloaded the last row, which
+// blocks every other request on a database with a single connection, such
+// as SQLite, for as long as the slowest client takes.
+const BufferExportsKey = "web.buffer_exports"
+
+// ExportFormat returns the streaming format the Accept header of the request
+// asks for, CSVContentType or NDJSONContentType, or "" when the client wants
+// the usual JSON response.
+func ExportFormat(c *gin.Context) string {
+	switch format := c.NegotiateFormat(gin.MIMEJSON, CSVContentType, NDJSONContentType); format {
+	case CSVContentType, NDJSONContentType:
+		return format
+	}
+	return ""
+}
+
+// Export answers with a body in format streamed row by row. each is called
+// with a function that writes one row; row is the zero value of the rows,
+// used to write the CSV header when there are none. The response starts with
+// the first row, so an error returned before any row is sent as a JSON error
+// instead; once rows were sent the body is cut short. With BufferExportsKey
+// set the rows are kept in memory until each returns.
+func Export(c *gin.Context, format string, row interface{}, each func(write func(row interface{}) error) error) {
+	e := &exporter{c: c, format: format}
+	var err error
+	if c.GetBool(BufferExportsKey) {
+		var rows []interface{}
+		err = each(func(row interface{}) error {
+			rows = append(rows, row)
+			return nil
+		})
+		for i := 0; err == nil && i < len(rows); i++ {
+			err = e.write(rows[i])
+		}
+	} else {
+		err = each(e.write)
+	}
+	if err == nil && e.rows == 0 {
+		err = e.start(reflect.TypeOf(row))
+	}
+	if err == nil {
+		err = e.flush()
+	}
+	if err != nil {
+		if e.rows == 0 && !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			ServerError(c, err, "internal server error")
+			return
+		}
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+type exporter struct {
+	c       *gin.Context
+	format  string
+	csv     *csv.Writer
+	json    *json.Encoder
+	columns [][]int
+	rows    int
+}
+
+func (e *exporter) start(t reflect.Type) error {
+	e.c.Header("Content-Type", e.format+"; charset=utf-8")
+	e.c.Status(http.StatusOK)
+	if e.format == NDJSONContentType {
+		e.json = json.NewEncoder(e.c.Writer)
+		return nil
+	}
+
+	e.csv = csv.NewWriter(e.c.Writer)
+	var header []string
+	header, e.columns = jsonColumns(t)
+	return e.csv.Write(header)
+}
+
+func (e *exporter) write(row interface{}) error {
+	if e.json == nil && e.csv == nil {
+		if err := e.start(reflect.TypeOf(row)); err != nil {
+			return err
+		}
+	}
+	e.rows++
+
+	if e.json != nil {
+		if err := e.json.Encode(row); err != nil {
+			return err
+		}
+	} else {
+		v := reflect.Indirect(reflect.ValueOf(row))
+		record := make([]string, len(e.columns))
+		for i, index := range e.columns {
+			cell, err := csvCell(v.FieldByIndex(index))
+			if err != nil {
+				return err
+			}
+			record[i] = cell
+		}
+		if err := e.csv.Write(record); err != nil {
+			return err
+		}
+	}
+
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *exporter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	e.c.Writer.Flush()
+	return nil
+}
+
+// jsonColumns lists, in declaration order, the member names encoding/json
+// uses for the fields of the struct t, or points to, with the index of each
+// field.
+func jsonColumns(t reflect.Type) ([]string, [][]int) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var names []string
+	var indexes [][]int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		names = append(names, name)
+		indexes = append(indexes, f.Index)
+	}
+	return names, indexes
+}
+
+// csvCell formats a field as a CSV cell: nil pointers are empty, strings and
+// numbers are written as is and anything else as JSON.
+func csvCell(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+	b, err := json.Marshal(v.Interface())
+	return string(b), err
+}
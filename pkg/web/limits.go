@@ -0,0 +1,39 @@
+package web
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RetryAfterHeader = "Retry-After"
+
+// TooManyRequests answers with a 429 telling the client to wait retryAfter,
+// rounded up to whole seconds, before trying again.
+func TooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header(RetryAfterHeader, strconv.Itoa(seconds))
+	Error(c, http.StatusTooManyRequests, "rate limit exceeded, retry in %d seconds", seconds)
+}
+
+// RequestTooLarge answers with a 413 for a body longer than limit bytes.
+func RequestTooLarge(c *gin.Context, limit int64) {
+	Error(c, http.StatusRequestEntityTooLarge, "request body must be at most %d bytes", limit)
+}
+
+// BodyTooLarge reports whether err comes from reading a request body past
+// the limit set with http.MaxBytesReader, returning the limit.
+func BodyTooLarge(err error) (int64, bool) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return maxErr.Limit, true
+	}
+	return 0, false
+}
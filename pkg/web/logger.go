@@ -0,0 +1,45 @@
+package web
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoggerKey is the gin context key holding the logger of the request, the
+// server's logger with the request id attached.
+const LoggerKey = "web.logger"
+
+// NewLogger returns a logger writing JSON lines to w. level is one of
+// "debug", "info", "warn" or "error"; anything else means "info".
+func NewLogger(w io.Writer, level string) *slog.Logger {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		l = slog.LevelInfo
+	}
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: l}))
+}
+
+// Logger returns the logger of the request ctx belongs to, or the default
+// logger outside a request. It accepts the *gin.Context passed down to
+// services.
+func Logger(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(LoggerKey).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// ServerError logs err with the logger of the request and answers with a 500
+// and the message formatted from format and args. err itself is not sent to
+// the client.
+func ServerError(c *gin.Context, err error, format string, args ...interface{}) {
+	Logger(c).ErrorContext(c, "request failed", "error", err)
+	Error(c, http.StatusInternalServerError, format, args...)
+}
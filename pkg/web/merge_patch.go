@@ -0,0 +1,130 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const MergePatchContentType = "application/merge-patch+json"
+
+var (
+	ErrUnsupportedMediaType = errors.New("content type must be " + MergePatchContentType + " or application/json")
+	ErrPatchNotObject       = errors.New("merge patch must be a JSON object")
+)
+
+// MergePatch applies the RFC 7396 merge patch in the request body to the JSON
+// document of current and decodes the result into target, a pointer to a
+// struct. Members set to null are removed, so target gets their zero value.
+// Members of current that target does not declare, such as the id, are
+// accepted but ignored; members unknown to both are reported as errors.
+func MergePatch(c *gin.Context, current, target interface{}) error {
+	switch c.ContentType() {
+	case MergePatchContentType, gin.MIMEJSON, "":
+	default:
+		return ErrUnsupportedMediaType
+	}
+
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	var patch interface{}
+	if err := decodeNumbers(body, &patch); err != nil {
+		return err
+	}
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return ErrPatchNotObject
+	}
+
+	known := jsonFields(reflect.TypeOf(target))
+	for name, field := range jsonFields(reflect.TypeOf(current)) {
+		known[name] = field
+	}
+	var unknown []string
+	for field := range patchObj {
+		if _, ok := known[field]; !ok {
+			unknown = append(unknown, fmt.Sprintf("%q", field))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown fields: %s", strings.Join(unknown, ", "))
+	}
+
+	currentDoc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := decodeNumbers(currentDoc, &doc); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(doc, patchObj))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(merged, target)
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396 section 2.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+			continue
+		}
+		targetObj[name] = mergePatch(targetObj[name], value)
+	}
+	return targetObj
+}
+
+// decodeNumbers unmarshals data keeping numbers as json.Number, so values
+// round-trip without going through float64.
+func decodeNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// jsonFields maps the member names encoding/json uses for the fields of the
+// struct t, or points to, to those fields.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
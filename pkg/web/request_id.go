@@ -0,0 +1,20 @@
+package web
+
+import "context"
+
+const (
+	// RequestIDHeader carries the request id in both directions.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the gin context key holding the request id.
+	RequestIDKey = "web.request_id"
+)
+
+// RequestID returns the id of the request ctx belongs to, or an empty string
+// outside a request. It accepts the *gin.Context passed down to services.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(RequestIDKey).(string)
+	return id
+}
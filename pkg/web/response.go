@@ -13,9 +13,11 @@ type response struct {
 }
 
 type errorResponse struct {
-	Status  int    `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Status    int         `json:"-"`
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
 func Response(c *gin.Context, status int, data interface{}) {
@@ -30,9 +32,24 @@ func Success(c *gin.Context, status int, data interface{}) {
 // formatted according to args and format.
 func Error(c *gin.Context, status int, format string, args ...interface{}) {
 	err := errorResponse{
-		Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
-		Message: fmt.Sprintf(format, args...),
-		Status:  status,
+		Code:      strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
+		Message:   fmt.Sprintf(format, args...),
+		Status:    status,
+		RequestID: c.GetString(RequestIDKey),
+	}
+
+	Response(c, status, err)
+}
+
+// ErrorWithDetails is like Error but also sends details, a value describing
+// the error further such as the records that caused a conflict.
+func ErrorWithDetails(c *gin.Context, status int, details interface{}, format string, args ...interface{}) {
+	err := errorResponse{
+		Code:      strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
+		Message:   fmt.Sprintf(format, args...),
+		Status:    status,
+		Details:   details,
+		RequestID: c.GetString(RequestIDKey),
 	}
 
 	Response(c, status, err)
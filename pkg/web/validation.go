@@ -0,0 +1,166 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Temperatures are in degrees Celsius.
+const (
+	MinTemperature = -100
+	MaxTemperature = 100
+)
+
+// DateLayouts are the layouts accepted by the date validation, the ones the
+// database takes for DATETIME columns.
+var DateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05"}
+
+var productCode = regexp.MustCompile(`^[A-Za-z0-9]+([-_][A-Za-z0-9]+)*$`)
+
+// FieldError describes a request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrValidatorEngine is returned by RegisterValidators when gin binds
+// requests with a validator other than go-playground's.
+var ErrValidatorEngine = errors.New("the binding validator is not a *validator.Validate")
+
+// RegisterValidators adds the validations the binding tags of the requests
+// use, such as "positive" and "product_code", to the validator gin binds
+// requests with, and names the fields in its errors after their JSON
+// members. The router calls it before serving any request.
+func RegisterValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return ErrValidatorEngine
+	}
+	v.RegisterTagNameFunc(jsonName)
+	validations := map[string]validator.Func{
+		"positive":     positive,
+		"product_code": func(fl validator.FieldLevel) bool { return productCode.MatchString(fl.Field().String()) },
+		"temperature":  temperature,
+		"date":         date,
+	}
+	for tag, fn := range validations {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks obj against its binding tags, the same way binding a
+// request body does.
+func Validate(obj interface{}) error {
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// FieldErrors lists the fields that failed validation in err, or returns nil
+// when err is not a validation error.
+func FieldErrors(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	fields := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, FieldError{Field: fe.Field(), Message: fieldMessage(fe)})
+	}
+	return fields
+}
+
+// ValidationError sends the fields that failed validation in err as the
+// details of the error, with the first of them as the message. Handlers
+// answer validation failures with http.StatusUnprocessableEntity. A body too
+// large to read is answered with a 413 and any other error is sent as the
+// message alone.
+func ValidationError(c *gin.Context, status int, err error) {
+	if limit, ok := BodyTooLarge(err); ok {
+		RequestTooLarge(c, limit)
+		return
+	}
+	fields := FieldErrors(err)
+	if len(fields) == 0 {
+		Error(c, status, "%s", err.Error())
+		return
+	}
+	ErrorWithDetails(c, status, fields, "field %s %s", fields[0].Field, fields[0].Message)
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte", "min":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "positive":
+		return "must be greater than zero"
+	case "product_code":
+		return "must be letters and digits, optionally separated by - or _"
+	case "temperature":
+		return fmt.Sprintf("must be a temperature between %d and %d", MinTemperature, MaxTemperature)
+	case "date":
+		return fmt.Sprintf("must be a date formatted as %s", strings.Join(DateLayouts, " or "))
+	default:
+		return fmt.Sprintf("is invalid (%s)", fe.Tag())
+	}
+}
+
+// jsonName names fields after their JSON member so errors match the body.
+func jsonName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+func positive(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() > 0
+	case reflect.Float32, reflect.Float64:
+		return field.Float() > 0
+	}
+	return false
+}
+
+func temperature(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	var t float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		t = float64(field.Int())
+	case reflect.Float32, reflect.Float64:
+		t = field.Float()
+	default:
+		return false
+	}
+	return t >= MinTemperature && t <= MaxTemperature
+}
+
+func date(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	for _, layout := range DateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
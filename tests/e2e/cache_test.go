@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReportCache reads a locality report twice, checks the second read
+// came from the cache, then creates a seller there and checks the report
+// was computed again.
+func TestReportCache(t *testing.T) {
+	s := newServer(t)
+	var before, after domain.ReportSeller
+
+	first := s.request(http.MethodGet, "/api/v1/localities/reportSellers?id=1", nil, http.StatusOK)
+	s.decode(first, &before)
+	tag := first.Header().Get(web.ETagHeader)
+	require.NotEmpty(t, tag)
+	assert.Equal(t, "private, no-cache", first.Header().Get(web.CacheControlHeader))
+
+	req, rr := s.newRequest(http.MethodGet, "/api/v1/localities/reportSellers?id=1", nil)
+	req.Header.Set(web.IfNoneMatchHeader, tag)
+	s.engine.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+
+	metrics := s.do(http.MethodGet, "/metrics", nil).Body.String()
+	assert.Contains(t, metrics, `melisprint_repository_call_duration_seconds_count{method="SellerReport",repository="locality"} 1`)
+
+	s.request(http.MethodPost, "/api/v1/sellers/", map[string]interface{}{
+		"cid": 100, "company_name": "Acme", "address": "Main St 1", "telephone": "555-0100", "locality_id": 1,
+	}, http.StatusCreated)
+
+	s.decode(s.request(http.MethodGet, "/api/v1/localities/reportSellers?id=1", nil, http.StatusOK), &after)
+	assert.Equal(t, before.SellersCount+1, after.SellersCount)
+}
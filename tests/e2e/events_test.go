@@ -0,0 +1,142 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/config"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/routes"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox"
+	outboxmemory "github.com/extmatperez/meli_bootcamp_go_w5-5/internal/outbox/memory"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEvents creates a purchase order, tries to create it again, and checks
+// only the first one left an event, which then reaches a subscriber.
+func TestEvents(t *testing.T) {
+	s := newServer(t)
+	order := map[string]interface{}{
+		"order_number": "PO-E", "order_date": "2022-02-01", "tracking_code": "TRK-E", "buyer_id": 1,
+		"product_record_id": 1, "quantity": 2, "order_status_id": 1,
+	}
+	rr := s.request(http.MethodPost, "/api/v1/purchaseOrders/", order, http.StatusCreated)
+	requestID := rr.Header().Get(web.RequestIDHeader)
+	require.NotEmpty(t, requestID)
+	s.request(http.MethodPost, "/api/v1/purchaseOrders/", order, http.StatusConflict)
+
+	require.Len(t, s.db.OutboxEvents, 1)
+	stored := s.db.OutboxEvents[0]
+	assert.Equal(t, "purchase_order.created", stored.Type)
+	assert.Equal(t, outbox.StatusPending, stored.Status)
+	assert.Equal(t, requestID, stored.RequestID)
+
+	var received []domain.PurchaseOrderCreated
+	s.router.Events().Subscribe("purchase_order.created", func(ctx context.Context, e domain.OutboxEvent) error {
+		var event domain.PurchaseOrderCreated
+		require.NoError(t, json.Unmarshal(e.Payload, &event))
+		received = append(received, event)
+		return nil
+	})
+	dispatcher := outbox.NewDispatcher(outboxmemory.NewRepository(s.db), slog.New(slog.NewTextHandler(io.Discard, nil)), 3, s.router.Events())
+
+	delivered, err := dispatcher.Dispatch(context.TODO())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	require.Len(t, received, 1)
+	assert.Equal(t, "PO-E", received[0].PurchaseOrder.OrderNumber)
+	assert.Equal(t, stored.EntityID, received[0].PurchaseOrder.ID)
+	assert.Equal(t, outbox.StatusDelivered, s.db.OutboxEvents[0].Status)
+}
+
+// TestEntityEvents checks the changes to sections, warehouses, employees,
+// localities and carries each leave an event of their own.
+func TestEntityEvents(t *testing.T) {
+	s := newServer(t)
+
+	var section domain.Section
+	s.decode(s.request(http.MethodPost, "/api/v1/sections/", map[string]interface{}{
+		"section_number": 100, "current_temperature": -18, "minimum_temperature": -20, "current_capacity": 0,
+		"minimum_capacity": 10, "maximum_capacity": 100, "warehouse_id": 1, "product_type_id": 1,
+	}, http.StatusCreated), &section)
+	s.request(http.MethodDelete, fmt.Sprintf("/api/v1/sections/%d", section.ID), nil, http.StatusNoContent)
+	s.request(http.MethodPatch, "/api/v1/warehouses/1", map[string]interface{}{"address": "1 New Street"}, http.StatusOK)
+	s.request(http.MethodPatch, "/api/v1/employees/1", map[string]interface{}{"first_name": "Mat"}, http.StatusOK)
+	s.request(http.MethodPost, "/api/v1/localities/", map[string]interface{}{
+		"id": 6, "locality_name": "Palermo", "province_name": "Buenos Aires", "country_name": "Argentina",
+	}, http.StatusCreated)
+	s.request(http.MethodPost, "/api/v1/carries/", map[string]interface{}{"cid": "6", "company_name": "Fletes", "locality_id": 6}, http.StatusCreated)
+
+	var types []string
+	for _, e := range s.db.OutboxEvents {
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []string{"section.created", "section.deleted", "warehouse.updated", "employee.updated", "locality.created", "carry.created"}, types)
+	assert.Equal(t, section.ID, s.db.OutboxEvents[1].EntityID)
+}
+
+// TestPurchaseOrderStatusChangedEvent moves a purchase order to another
+// status and checks the change, and only the change, leaves an event.
+func TestPurchaseOrderStatusChangedEvent(t *testing.T) {
+	s := newServer(t)
+	var order domain.PurchaseOrders
+	s.decode(s.request(http.MethodPost, "/api/v1/purchaseOrders/", map[string]interface{}{
+		"order_number": "PO-S", "order_date": "2022-02-01", "tracking_code": "TRK-S", "buyer_id": 1,
+		"product_record_id": 1, "quantity": 1, "order_status_id": 1,
+	}, http.StatusCreated), &order)
+	url := fmt.Sprintf("/api/v1/purchaseOrders/%d/status", order.ID)
+
+	s.request(http.MethodPatch, url, map[string]interface{}{"order_status_id": 2}, http.StatusOK)
+	s.request(http.MethodPatch, url, map[string]interface{}{"order_status_id": 2}, http.StatusOK)
+	s.request(http.MethodPatch, url, map[string]interface{}{"order_status_id": 99}, http.StatusUnprocessableEntity)
+	s.request(http.MethodPatch, "/api/v1/purchaseOrders/99/status", map[string]interface{}{"order_status_id": 2}, http.StatusNotFound)
+
+	require.Len(t, s.db.OutboxEvents, 2)
+	stored := s.db.OutboxEvents[1]
+	assert.Equal(t, "purchase_order.status_changed", stored.Type)
+	var event domain.PurchaseOrderStatusChanged
+	require.NoError(t, json.Unmarshal(stored.Payload, &event))
+	assert.Equal(t, domain.PurchaseOrderStatusChanged{ID: order.ID, FromStatusID: 1, ToStatusID: 2}, event)
+}
+
+// TestDispatcherStopsWithContext checks Wait returns once the context given
+// to MapRoutes is done, and not before.
+func TestDispatcherStopsWithContext(t *testing.T) {
+	db := memdb.New()
+	memdb.Seed(db)
+	router := routes.NewMemoryRouter(gin.New(), db, config.Config{
+		JWTSecret: "e2e-secret", Storage: config.StorageMemory, LogLevel: "error",
+		EventsPollInterval: time.Millisecond, EventsMaxAttempts: 3,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	router.MapRoutes(ctx)
+
+	stopped := make(chan struct{})
+	go func() {
+		router.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("the dispatcher stopped before its context was done")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the dispatcher did not stop")
+	}
+}
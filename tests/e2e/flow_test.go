@@ -0,0 +1,140 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// productRecord prices the product the flow creates, the sixth one, since no
+// endpoint creates product records.
+func productRecord(db *memdb.DB) {
+	db.ProductRecords = append(db.ProductRecords, memdb.ProductRecord{ID: 1, LastUpdateDate: "2022-01-01 00:00:00", PurchasePrice: 6, SalePrice: 10, ProductID: 6})
+}
+
+// TestCreateReceiveSell creates a seller with a product and a section,
+// receives a batch of the product in the section and sells part of it,
+// checking the reports at each step.
+func TestCreateReceiveSell(t *testing.T) {
+	s := newServer(t, productRecord)
+
+	var seller domain.Seller
+	s.decode(s.request(http.MethodPost, "/api/v1/sellers/", map[string]interface{}{
+		"cid": 100, "company_name": "Acme", "address": "Main St 1", "telephone": "555-0100", "locality_id": 1,
+	}, http.StatusCreated), &seller)
+
+	var product domain.Product
+	s.decode(s.request(http.MethodPost, "/api/v1/products/", map[string]interface{}{
+		"description": "frozen peas", "expiration_rate": 1, "freezing_rate": 1, "height": 1, "length": 1, "netweight": 1,
+		"product_code": "PEAS-1", "recommended_freezing_temperature": -18, "width": 1, "product_type_id": 1, "seller_id": seller.ID,
+	}, http.StatusCreated), &product)
+	assert.Equal(t, 6, product.ID)
+
+	var section domain.Section
+	s.decode(s.request(http.MethodPost, "/api/v1/sections/", map[string]interface{}{
+		"section_number": 100, "current_temperature": -18, "minimum_temperature": -20, "current_capacity": 0,
+		"minimum_capacity": 10, "maximum_capacity": 100, "warehouse_id": 1, "product_type_id": 1,
+	}, http.StatusCreated), &section)
+
+	var batch domain.ProductBatches
+	s.decode(s.request(http.MethodPost, "/api/v1/productBatches/", map[string]interface{}{
+		"BatchNumber": 1, "CurrentQuantity": 40, "DueDate": "2023-01-01", "InitialQuantity": 40,
+		"ManufacturingDate": "2022-01-01", "ManufacturingHour": "2022-01-01 10:00:00", "MinumumTemperature": -20,
+		"ProductId": product.ID, "SectionId": section.ID,
+	}, http.StatusCreated), &batch)
+	s.request(http.MethodPost, "/api/v1/inboundOrders/", map[string]interface{}{
+		"order_date": "2022-01-02", "order_number": "IN-1", "employee_id": 1, "product_batch_id": batch.Id, "warehouse_id": 1,
+	}, http.StatusCreated)
+
+	var sectionReport domain.ProductReport
+	s.decode(s.request(http.MethodGet, "/api/v1/sections/reportProducts?id=6", nil, http.StatusOK), &sectionReport)
+	assert.Equal(t, domain.ProductReport{SectionId: section.ID, SectionNumber: 100, ProductCount: 40}, sectionReport)
+
+	var utilization domain.WarehouseUtilization
+	s.decode(s.request(http.MethodGet, "/api/v1/warehouses/1/utilization", nil, http.StatusOK), &utilization)
+	assert.Equal(t, 101, utilization.Capacity)
+	assert.Equal(t, 40, utilization.Used)
+
+	var employees []domain.EmployeeOrders
+	s.decode(s.request(http.MethodGet, "/api/v1/employees/reportInboundOrders?id=1", nil, http.StatusOK), &employees)
+	assert.Equal(t, 1, employees[0].InboundOrdersCount)
+
+	s.request(http.MethodPost, "/api/v1/purchaseOrders/", map[string]interface{}{
+		"order_number": "PO-1", "order_date": "2022-02-01", "tracking_code": "TRK-1", "buyer_id": 1,
+		"product_record_id": 1, "quantity": 3, "order_status_id": 1,
+	}, http.StatusCreated)
+
+	var sellerReport domain.SellerReport
+	s.decode(s.request(http.MethodGet, "/api/v1/sellers/6/report", nil, http.StatusOK), &sellerReport)
+	assert.Equal(t, domain.SellerReport{SellerID: 6, CID: 100, CompanyName: "Acme", ProductsCount: 1, Stock: 40, PurchaseOrders: 1, Revenue: 30}, sellerReport)
+
+	var history domain.BuyerHistory
+	s.decode(s.request(http.MethodGet, "/api/v1/buyers/1/purchaseOrders", nil, http.StatusOK), &history)
+	assert.Equal(t, 1, history.OrdersCount)
+	assert.Equal(t, 30.0, history.TotalSpent)
+	assert.Equal(t, "mi nulla ac enim in tempor", history.Orders[0].Status)
+	assert.Equal(t, product.ID, history.Orders[0].Items[0].ProductID)
+
+	var audit []domain.AuditEntry
+	s.decode(s.request(http.MethodGet, "/api/v1/audit?entity=seller", nil, http.StatusOK), &audit)
+	assert.Len(t, audit, 1)
+}
+
+// TestSoftDeleteAndRestore deletes a seed product, checks it is hidden and
+// that its seller can then be deleted, and restores it.
+func TestSoftDeleteAndRestore(t *testing.T) {
+	s := newServer(t)
+
+	s.request(http.MethodDelete, "/api/v1/sellers/1", nil, http.StatusConflict)
+	s.request(http.MethodDelete, "/api/v1/products/1", nil, http.StatusNoContent)
+	s.request(http.MethodGet, "/api/v1/products/1", nil, http.StatusNotFound)
+
+	var products []domain.Product
+	s.decode(s.request(http.MethodGet, "/api/v1/products/?include_deleted=true", nil, http.StatusOK), &products)
+	assert.Len(t, products, 5)
+	assert.NotNil(t, products[0].DeletedAt)
+
+	s.request(http.MethodDelete, "/api/v1/sellers/1", nil, http.StatusNoContent)
+	s.request(http.MethodPost, "/api/v1/products/1/restore", nil, http.StatusOK)
+	s.request(http.MethodGet, "/api/v1/products/1", nil, http.StatusOK)
+}
+
+// TestOptimisticConcurrency updates a warehouse with the ETag of its first
+// version twice; the second update is rejected.
+func TestOptimisticConcurrency(t *testing.T) {
+	s := newServer(t)
+	etag := s.request(http.MethodGet, "/api/v1/warehouses/1", nil, http.StatusOK).Header().Get("ETag")
+
+	update := func() int {
+		req, rr := s.newRequest(http.MethodPatch, "/api/v1/warehouses/1", map[string]interface{}{"address": "New Address 1"})
+		req.Header.Set("If-Match", etag)
+		s.engine.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, update())
+	assert.Equal(t, http.StatusPreconditionFailed, update())
+}
+
+// TestAuditRecordsStoredUpdate checks the audit log keeps the states of a
+// warehouse read before and after an update.
+func TestAuditRecordsStoredUpdate(t *testing.T) {
+	s := newServer(t)
+
+	s.request(http.MethodPatch, "/api/v1/warehouses/1", map[string]interface{}{"address": "1 New Street"}, http.StatusOK)
+
+	var audit []domain.AuditEntry
+	s.decode(s.request(http.MethodGet, "/api/v1/audit?entity=warehouse", nil, http.StatusOK), &audit)
+	require.Len(t, audit, 1)
+	var before, after domain.Warehouse
+	require.Nil(t, json.Unmarshal(audit[0].Before, &before))
+	require.Nil(t, json.Unmarshal(audit[0].After, &after))
+	assert.Equal(t, "2985 Lunder Center", before.Address)
+	assert.Equal(t, "1 New Street", after.Address)
+	assert.Equal(t, before.WarehouseCode, after.WarehouseCode)
+}
@@ -0,0 +1,96 @@
+// Package e2e runs scenarios against the real router, with every repository
+// kept in memory and loaded with the seed data of db.sql.
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/config"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/routes"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/tests"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// server is a router over a database of its own, logged in as the admin
+// created from its config.
+type server struct {
+	t      *testing.T
+	engine *gin.Engine
+	router routes.Router
+	db     *memdb.DB
+	token  string
+}
+
+// newServer builds the router over a fresh database with the seed data;
+// fixtures, when given, add rows before the first request.
+func newServer(t *testing.T, fixtures ...func(*memdb.DB)) *server {
+	gin.SetMode(gin.TestMode)
+	db := memdb.New()
+	memdb.Seed(db)
+	for _, fixture := range fixtures {
+		fixture(db)
+	}
+
+	engine := gin.New()
+	cfg := config.Config{
+		JWTSecret:       "e2e-secret",
+		JWTExpiration:   time.Hour,
+		AdminUsername:   "admin",
+		AdminPassword:   "admin",
+		Storage:         config.StorageMemory,
+		LogLevel:        "error",
+		ReportCacheTTL:  time.Minute,
+		ReportCacheSize: 100,
+	}
+	router := routes.NewMemoryRouter(engine, db, cfg)
+	router.MapRoutes(context.Background())
+
+	s := &server{t: t, engine: engine, router: router, db: db}
+	var login struct {
+		Token string `json:"token"`
+	}
+	s.decode(s.request(http.MethodPost, "/api/v1/login", map[string]string{"username": "admin", "password": "admin"}, http.StatusOK), &login)
+	s.token = login.Token
+	return s
+}
+
+// newRequest builds a JSON request carrying the admin token.
+func (s *server) newRequest(method, url string, body interface{}) (*http.Request, *httptest.ResponseRecorder) {
+	req, rr := tests.CreateRequestTest(method, url, body)
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return req, rr
+}
+
+// do sends the request with the admin token and returns the response.
+func (s *server) do(method, url string, body interface{}) *httptest.ResponseRecorder {
+	req, rr := s.newRequest(method, url, body)
+	s.engine.ServeHTTP(rr, req)
+	return rr
+}
+
+// request sends the request and fails the test unless it answers code.
+func (s *server) request(method, url string, body interface{}, code int) *httptest.ResponseRecorder {
+	s.t.Helper()
+	rr := s.do(method, url, body)
+	require.Equal(s.t, code, rr.Code, "%s %s answered %s", method, url, rr.Body.String())
+	return rr
+}
+
+// decode reads the data member of the response into v.
+func (s *server) decode(rr *httptest.ResponseRecorder, v interface{}) {
+	s.t.Helper()
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(s.t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.NoError(s.t, json.Unmarshal(body.Data, v))
+}
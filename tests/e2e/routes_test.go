@@ -0,0 +1,119 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/config"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/cmd/server/routes"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/memdb"
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRoutesNotShadowedByID(t *testing.T) {
+	s := newServer(t)
+	urls := []string{
+		"/api/v1/sections/reportProducts",
+		"/api/v1/employees/reportInboundOrders",
+		"/api/v1/buyers/reportPurchaseOrders",
+		"/api/v1/buyers/topBuyers",
+		"/api/v1/sellers/report",
+		"/api/v1/warehouses/utilization",
+		"/api/v1/localities/reportSellers",
+		"/api/v1/localities/reportCarries",
+	}
+	for _, url := range urls {
+		t.Run(url, func(t *testing.T) {
+			rr := s.do(http.MethodGet, url, nil)
+
+			assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthenticationRequired(t *testing.T) {
+	s := newServer(t)
+	s.token = ""
+
+	assert.Equal(t, http.StatusUnauthorized, s.do(http.MethodGet, "/api/v1/sellers/", nil).Code)
+	assert.Equal(t, http.StatusOK, s.do(http.MethodGet, "/health", nil).Code)
+}
+
+func TestSeedData(t *testing.T) {
+	s := newServer(t)
+	var sellers, buyers []map[string]interface{}
+
+	s.decode(s.request(http.MethodGet, "/api/v1/sellers/", nil, http.StatusOK), &sellers)
+	s.decode(s.request(http.MethodGet, "/api/v1/buyers/", nil, http.StatusOK), &buyers)
+
+	assert.Len(t, sellers, 5)
+	assert.Equal(t, "Skyba", sellers[0]["company_name"])
+	assert.Len(t, buyers, 5)
+}
+
+func TestMetrics(t *testing.T) {
+	s := newServer(t)
+	s.request(http.MethodGet, "/api/v1/products/1", nil, http.StatusOK)
+
+	body := s.do(http.MethodGet, "/metrics", nil).Body.String()
+
+	assert.Contains(t, body, `melisprint_http_requests_total{method="GET",route="/api/v1/products/:id",status="200"} 1`)
+	assert.Contains(t, body, `melisprint_repository_call_duration_seconds_count{method="Get",repository="product"} 1`)
+}
+
+func TestErrorsCarryRequestID(t *testing.T) {
+	s := newServer(t)
+	s.token = ""
+
+	req, rr := s.newRequest(http.MethodGet, "/api/v1/sellers/", nil)
+	req.Header.Set(web.RequestIDHeader, "e2e-42")
+	s.engine.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "e2e-42", rr.Header().Get(web.RequestIDHeader))
+	assert.JSONEq(t, `{"code":"unauthorized","message":"missing bearer token","request_id":"e2e-42"}`, rr.Body.String())
+
+	rr = s.do(http.MethodGet, "/api/v1/sellers/", nil)
+	assert.NotEmpty(t, rr.Header().Get(web.RequestIDHeader))
+	assert.Contains(t, rr.Body.String(), `"request_id":"`+rr.Header().Get(web.RequestIDHeader)+`"`)
+}
+
+func TestForwardedForIgnoredWithoutTrustedProxies(t *testing.T) {
+	s := newServer(t)
+	s.engine.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+
+	req, rr := s.newRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	s.engine.ServeHTTP(rr, req)
+
+	assert.Equal(t, "192.0.2.1", rr.Body.String())
+}
+
+// TestIPRateLimitBeforeAuthentication checks requests with invalid tokens
+// count against the quota of their IP address.
+func TestIPRateLimitBeforeAuthentication(t *testing.T) {
+	db := memdb.New()
+	memdb.Seed(db)
+	engine := gin.New()
+	routes.NewMemoryRouter(engine, db, config.Config{
+		JWTSecret: "e2e-secret", Storage: config.StorageMemory, LogLevel: "error",
+		IPRateLimit: config.RateLimit{Requests: 2, Per: time.Minute},
+	}).MapRoutes(context.Background())
+	call := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/", nil)
+		req.Header.Set("Authorization", "Bearer invalid")
+		rr := httptest.NewRecorder()
+		engine.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, call())
+	assert.Equal(t, http.StatusUnauthorized, call())
+	assert.Equal(t, http.StatusTooManyRequests, call())
+}
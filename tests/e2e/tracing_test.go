@@ -0,0 +1,55 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TestTracing follows POST /purchaseOrders from the caller's traceparent
+// down to the repository calls it makes.
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	s := newServer(t)
+	req, rr := s.newRequest(http.MethodPost, "/api/v1/purchaseOrders/", map[string]interface{}{
+		"order_number": "PO-T", "order_date": "2022-02-01", "tracking_code": "TRK-T", "buyer_id": 1,
+		"product_record_id": 1, "quantity": 1, "order_status_id": 1,
+	})
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	s.engine.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+			spans[span.Name()] = span
+		}
+	}
+	server, ok := spans["POST /api/v1/purchaseOrders/"]
+	require.True(t, ok, "server span missing from %v", spans)
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+
+	exists := spans["purchase_orders.Service.Exists"]
+	require.NotNil(t, exists)
+	assert.Equal(t, server.SpanContext().SpanID(), exists.Parent().SpanID())
+	assert.Equal(t, exists.SpanContext().SpanID(), spans["purchase_orders.Repository.Exists"].Parent().SpanID())
+
+	save := spans["purchase_orders.Service.Save"]
+	require.NotNil(t, save)
+	assert.Equal(t, server.SpanContext().SpanID(), save.Parent().SpanID())
+	assert.Contains(t, spans, "purchase_orders.Repository.Save")
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+type MockAuditRepository struct {
+	MockData []domain.AuditEntry
+	ErrSave  error
+}
+
+func (m *MockAuditRepository) Save(ctx context.Context, e domain.AuditEntry) (int, error) {
+	if m.ErrSave != nil {
+		return 0, m.ErrSave
+	}
+	e.ID = len(m.MockData) + 1
+	m.MockData = append(m.MockData, e)
+	return e.ID, nil
+}
+
+func (m *MockAuditRepository) Find(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error) {
+	entries := []domain.AuditEntry{}
+	for _, e := range m.MockData {
+		if f.Entity != "" && e.Entity != f.Entity {
+			continue
+		}
+		if f.EntityID != 0 && e.EntityID != f.EntityID {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (m *MockAuditRepository) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+	items, err := m.Find(ctx, f)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -9,7 +9,10 @@ import (
 )
 
 type MockBuyerRepository struct {
-	DataMock []domain.Buyer
+	DataMock       []domain.Buyer
+	MockDependents map[int][]domain.Dependent
+	MockHistory    map[int]domain.BuyerHistory
+	MockSpend      []domain.BuyerSpend
 }
 
 var MockDataBuyers []domain.Buyer = []domain.Buyer{
@@ -17,7 +20,7 @@ var MockDataBuyers []domain.Buyer = []domain.Buyer{
 	{ID: 2, CardNumberID: "XYZ1234", FirstName: "MIA", LastName: "RODRIGUEZ"},
 }
 
-func (m *MockBuyerRepository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
+func (m *MockBuyerRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error) {
 	return m.DataMock, nil
 }
 
@@ -81,6 +84,9 @@ func (m *MockBuyerRepository) Update(ctx context.Context, b domain.Buyer) error
 }
 
 func (m *MockBuyerRepository) Delete(ctx context.Context, id int) error {
+	if dependents := m.MockDependents[id]; len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "buyer", ID: id, Dependents: dependents}
+	}
 
 	_, err := m.Get(ctx, id)
 	if err != nil {
@@ -102,3 +108,85 @@ func (m *MockBuyerRepository) Delete(ctx context.Context, id int) error {
 func (m *MockBuyerRepository) GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error) {
 	return []domain.BuyerOrders{}, nil
 }
+
+func (m *MockBuyerRepository) Restore(ctx context.Context, id int) error {
+	for i, item := range m.DataMock {
+		if item.ID == id && item.DeletedAt != nil {
+			m.DataMock[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted buyer not found")
+}
+
+func (m *MockBuyerRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	return m.MockDependents[id], nil
+}
+
+func (m *MockBuyerRepository) ExistingCardNumbers(ctx context.Context, cardNumberIDs []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	for _, cardNumberID := range cardNumberIDs {
+		if m.Exists(ctx, cardNumberID) {
+			existing[cardNumberID] = true
+		}
+	}
+	return existing, nil
+}
+
+func (m *MockBuyerRepository) SaveBatch(ctx context.Context, buyers []domain.Buyer) ([]int, error) {
+	ids := make([]int, len(buyers))
+	for i, b := range buyers {
+		id, err := m.Save(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (m *MockBuyerRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error {
+	items, err := m.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockBuyerRepository) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error {
+	items, err := m.GetPurchaseOrders(ctx, 0)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockBuyerRepository) PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error) {
+	history, ok := m.MockHistory[id]
+	if !ok {
+		return domain.BuyerHistory{}, fmt.Errorf("error: buyer with id:%v not found", id)
+	}
+	return history, nil
+}
+
+func (m *MockBuyerRepository) TopBuyers(ctx context.Context, limit, offset int) ([]domain.BuyerSpend, error) {
+	buyers := []domain.BuyerSpend{}
+	for i := offset; i < len(m.MockSpend) && i < offset+limit; i++ {
+		buyers = append(buyers, m.MockSpend[i])
+	}
+	return buyers, nil
+}
+
+func (m *MockBuyerRepository) CountTopBuyers(ctx context.Context) (int, error) {
+	return len(m.MockSpend), nil
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
@@ -9,14 +10,23 @@ import (
 )
 
 type MockBuyerService struct {
-	DataMock []domain.Buyer
+	DataMock    []domain.Buyer
+	MockHistory map[int]domain.BuyerHistory
+	MockSpend   []domain.BuyerSpend
+	// ErrNotFound, when set, is returned by Delete for unknown ids.
+	ErrNotFound error
+	// ErrLookup, when set, is returned by Get for every id.
+	ErrLookup error
 }
 
-func (m *MockBuyerService) GetAll(ctx context.Context) ([]domain.Buyer, error) {
+func (m *MockBuyerService) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Buyer, error) {
 	return m.DataMock, nil
 }
 
 func (m *MockBuyerService) Get(ctx context.Context, id int) (domain.Buyer, error) {
+	if m.ErrLookup != nil {
+		return domain.Buyer{}, m.ErrLookup
+	}
 
 	var buyerObtained domain.Buyer
 	for i, buyer := range m.DataMock {
@@ -25,8 +35,7 @@ func (m *MockBuyerService) Get(ctx context.Context, id int) (domain.Buyer, error
 			return buyerObtained, nil
 		}
 	}
-	err := fmt.Sprintf("error: buyer with id:%v not found", id)
-	return domain.Buyer{}, errors.New(err)
+	return domain.Buyer{}, fmt.Errorf("error: buyer with id:%v not found: %w", id, sql.ErrNoRows)
 }
 
 func (m *MockBuyerService) Exists(ctx context.Context, cardNumberID string) bool {
@@ -79,6 +88,9 @@ func (m *MockBuyerService) Update(ctx context.Context, b domain.Buyer) error {
 func (m *MockBuyerService) Delete(ctx context.Context, id int) error {
 
 	_, err := m.Get(ctx, id)
+	if err != nil && m.ErrNotFound != nil {
+		return m.ErrNotFound
+	}
 	if err != nil {
 		return err
 	}
@@ -98,3 +110,70 @@ func (m *MockBuyerService) Delete(ctx context.Context, id int) error {
 func (m *MockBuyerService) GetPurchaseOrders(ctx context.Context, id int) ([]domain.BuyerOrders, error) {
 	return []domain.BuyerOrders{}, nil
 }
+
+func (m *MockBuyerService) Restore(ctx context.Context, id int) error {
+	for i, item := range m.DataMock {
+		if item.ID == id && item.DeletedAt != nil {
+			m.DataMock[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted buyer not found")
+}
+
+func (m *MockBuyerService) Import(ctx context.Context, buyers []domain.Buyer, dryRun bool) ([]domain.BulkResult, error) {
+	cardNumberIDs := make([]string, len(buyers))
+	existing := map[string]bool{}
+	for i, b := range buyers {
+		cardNumberIDs[i] = b.CardNumberID
+		existing[b.CardNumberID] = m.Exists(ctx, b.CardNumberID)
+	}
+	return domain.BulkImport(cardNumberIDs, existing, nil, dryRun, func(rows []int) ([]int, error) {
+		ids := make([]int, len(rows))
+		for j, i := range rows {
+			id, err := m.Save(ctx, buyers[i])
+			if err != nil {
+				return nil, err
+			}
+			ids[j] = id
+		}
+		return ids, nil
+	}), nil
+}
+
+func (m *MockBuyerService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Buyer) error) error {
+	items, err := m.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockBuyerService) StreamPurchaseOrders(ctx context.Context, fn func(domain.BuyerOrders) error) error {
+	items, err := m.GetPurchaseOrders(ctx, 0)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockBuyerService) PurchaseHistory(ctx context.Context, id int) (domain.BuyerHistory, error) {
+	repo := MockBuyerRepository{MockHistory: m.MockHistory}
+	return repo.PurchaseHistory(ctx, id)
+}
+
+func (m *MockBuyerService) TopBuyers(ctx context.Context, page, pageSize int) (domain.Page, error) {
+	repo := MockBuyerRepository{MockSpend: m.MockSpend}
+	buyers, _ := repo.TopBuyers(ctx, pageSize, (page-1)*pageSize)
+	return domain.Page{Page: page, PageSize: pageSize, Total: len(m.MockSpend), Items: buyers}, nil
+}
@@ -9,6 +9,13 @@ import (
 
 type MockEmployeeRepository struct {
 	MockData []domain.Employee
+	// ErrNotFound, when set, is returned for the employees and users that
+	// are not found, and ErrUserLookup fails every GetByUserID. ErrDelete
+	// and ErrInboundOrders fail every Delete and GetInboundOrders.
+	ErrNotFound      error
+	ErrUserLookup    error
+	ErrDelete        error
+	ErrInboundOrders error
 }
 
 var MockNewEmployee domain.Employee = domain.Employee{
@@ -68,7 +75,7 @@ var MockEmployees []domain.Employee = []domain.Employee{
 	},
 }
 
-func (r *MockEmployeeRepository) GetAll(ctx context.Context) ([]domain.Employee, error) {
+func (r *MockEmployeeRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error) {
 	return r.MockData, nil
 }
 
@@ -78,7 +85,7 @@ func (r *MockEmployeeRepository) Get(ctx context.Context, id int) (domain.Employ
 			return empTest, nil
 		}
 	}
-	return domain.Employee{}, errors.New("El id no existe")
+	return domain.Employee{}, r.notFound()
 }
 
 func (r *MockEmployeeRepository) Exists(ctx context.Context, cardNumberID string) bool {
@@ -115,16 +122,80 @@ func (r *MockEmployeeRepository) Update(ctx context.Context, e domain.Employee)
 	return errors.New("El id no existe")
 }
 
-func (r *MockEmployeeRepository) Delete(ctx context.Context, id int) error {
+func (r *MockEmployeeRepository) Delete(ctx context.Context, id, version int) error {
+	if r.ErrDelete != nil {
+		return r.ErrDelete
+	}
 	for i, empTest := range r.MockData {
 		if empTest.ID == id {
+			if version != 0 && empTest.Version != version {
+				return domain.ErrVersionConflict
+			}
 			r.MockData = append(r.MockData[:i], r.MockData[i+1:]...)
 			return nil
 		}
 	}
+	return r.notFound()
+}
+
+func (r *MockEmployeeRepository) notFound() error {
+	if r.ErrNotFound != nil {
+		return r.ErrNotFound
+	}
 	return errors.New("El id no existe")
 }
 
 func (r *MockEmployeeRepository) GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error) {
-	return nil, nil
+	return nil, r.ErrInboundOrders
+}
+
+func (r *MockEmployeeRepository) GetByUserID(ctx context.Context, userID int) (domain.Employee, error) {
+	if r.ErrUserLookup != nil {
+		return domain.Employee{}, r.ErrUserLookup
+	}
+	for _, empTest := range r.MockData {
+		if empTest.UserID != nil && *empTest.UserID == userID {
+			return empTest, nil
+		}
+	}
+	if r.ErrNotFound != nil {
+		return domain.Employee{}, r.ErrNotFound
+	}
+	return domain.Employee{}, errors.New("employee not found")
+}
+
+func (r *MockEmployeeRepository) Restore(ctx context.Context, id int) error {
+	for i, item := range r.MockData {
+		if item.ID == id && item.DeletedAt != nil {
+			r.MockData[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted employee not found")
+}
+
+func (r *MockEmployeeRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error {
+	items, err := r.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MockEmployeeRepository) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error {
+	items, err := r.GetInboundOrders(ctx, 0)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
 }
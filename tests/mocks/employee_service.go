@@ -10,8 +10,8 @@ type MockEmployeeService struct {
 	MockRepository MockEmployeeRepository
 }
 
-func (r *MockEmployeeService) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	return r.MockRepository.GetAll(ctx)
+func (r *MockEmployeeService) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Employee, error) {
+	return r.MockRepository.GetAll(ctx, includeDeleted)
 }
 
 func (r *MockEmployeeService) Get(ctx context.Context, id int) (domain.Employee, error) {
@@ -30,10 +30,44 @@ func (r *MockEmployeeService) Update(ctx context.Context, e domain.Employee) err
 	return r.MockRepository.Update(ctx, e)
 }
 
-func (r *MockEmployeeService) Delete(ctx context.Context, id int) error {
-	return r.MockRepository.Delete(ctx, id)
+func (r *MockEmployeeService) Delete(ctx context.Context, id, version int) error {
+	return r.MockRepository.Delete(ctx, id, version)
+}
+
+func (r *MockEmployeeService) Restore(ctx context.Context, id int) error {
+	return r.MockRepository.Restore(ctx, id)
 }
 
 func (r *MockEmployeeService) GetInboundOrders(ctx context.Context, id int) ([]domain.EmployeeOrders, error) {
-	return nil, nil
+	return r.MockRepository.GetInboundOrders(ctx, id)
+}
+
+func (r *MockEmployeeService) GetByUserID(ctx context.Context, userID int) (domain.Employee, error) {
+	return r.MockRepository.GetByUserID(ctx, userID)
+}
+
+func (r *MockEmployeeService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Employee) error) error {
+	items, err := r.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MockEmployeeService) StreamInboundOrders(ctx context.Context, fn func(domain.EmployeeOrders) error) error {
+	items, err := r.GetInboundOrders(ctx, 0)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
 }
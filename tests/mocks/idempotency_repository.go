@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+// MockIdempotencyKey is the user and key a MockIdempotencyRepository finds a
+// record by.
+type MockIdempotencyKey struct {
+	User string
+	Key  string
+}
+
+// MockIdempotencyRepository keeps the records in a map. Errors match the ones
+// returned by the idempotency package so its service can tell them apart.
+type MockIdempotencyRepository struct {
+	MockData    map[MockIdempotencyKey]domain.IdempotencyRecord
+	ErrNotFound error
+	ErrExists   error
+}
+
+func (m *MockIdempotencyRepository) Get(ctx context.Context, user, key string) (domain.IdempotencyRecord, error) {
+	rec, ok := m.MockData[MockIdempotencyKey{User: user, Key: key}]
+	if !ok {
+		return domain.IdempotencyRecord{}, m.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (m *MockIdempotencyRepository) Reserve(ctx context.Context, rec domain.IdempotencyRecord) error {
+	id := MockIdempotencyKey{User: rec.User, Key: rec.Key}
+	if _, ok := m.MockData[id]; ok {
+		return m.ErrExists
+	}
+	m.MockData[id] = rec
+	return nil
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, rec domain.IdempotencyRecord) error {
+	id := MockIdempotencyKey{User: rec.User, Key: rec.Key}
+	if _, ok := m.MockData[id]; !ok {
+		return errors.New("idempotency key not found")
+	}
+	m.MockData[id] = rec
+	return nil
+}
+
+func (m *MockIdempotencyRepository) Delete(ctx context.Context, user, key string) error {
+	delete(m.MockData, MockIdempotencyKey{User: user, Key: key})
+	return nil
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context, before string) error {
+	for id, rec := range m.MockData {
+		if rec.CreatedAt < before {
+			delete(m.MockData, id)
+		}
+	}
+	return nil
+}
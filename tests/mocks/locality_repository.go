@@ -26,6 +26,8 @@ func LocalityCreateOKMockDB() (*sql.DB, error) {
 	}
 
 	// ID Not Exists
+	mock.
+		ExpectPrepare(regexp.QuoteMeta(queries.SelectIdLocality))
 	mock.
 		ExpectQuery(regexp.QuoteMeta(queries.SelectIdLocality)).
 		WithArgs(LocalityTest.ID).
@@ -76,6 +78,8 @@ func LocalityIdWithflictMockDB() (*sql.DB, error) {
 	rows := sqlmock.NewRows([]string{"id"})
 	rows.AddRow(LocalityTest.ID)
 
+	mock.
+		ExpectPrepare(regexp.QuoteMeta(queries.SelectIdLocality))
 	mock.
 		ExpectQuery(regexp.QuoteMeta(queries.SelectIdLocality)).
 		WithArgs(LocalityTest.ID).
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+type MockOutboxRepository struct {
+	MockData []domain.OutboxEvent
+	ErrSave  error
+}
+
+func (m *MockOutboxRepository) Save(ctx context.Context, e domain.OutboxEvent) (int, error) {
+	if m.ErrSave != nil {
+		return 0, m.ErrSave
+	}
+	e.ID = len(m.MockData) + 1
+	m.MockData = append(m.MockData, e)
+	return e.ID, nil
+}
+
+func (m *MockOutboxRepository) Pending(ctx context.Context, now string, limit int) ([]domain.OutboxEvent, error) {
+	events := []domain.OutboxEvent{}
+	for _, e := range m.MockData {
+		if e.Status == "pending" && e.NextAttemptAt <= now && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, id int) error {
+	m.MockData[id-1].Status = "delivered"
+	m.MockData[id-1].Attempts++
+	return nil
+}
+
+func (m *MockOutboxRepository) SaveAttempt(ctx context.Context, e domain.OutboxEvent) error {
+	m.MockData[e.ID-1] = e
+	return nil
+}
@@ -13,7 +13,7 @@ func CreateMockDBSave(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 
 	// añadir mocks de product a db
-	expectedProduct := mock.ExpectQuery("SELECT id FROM products")
+	expectedProduct := mock.ExpectPrepare("SELECT id FROM products").ExpectQuery()
 
 	productMockRows := sqlmock.NewRows([]string{"id"})
 	productMockRows.AddRow(1)
@@ -25,7 +25,7 @@ func CreateMockDBSave(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 	}
 	sectionMockRows := sqlmock.NewRows(sectionRows)
 	sectionMockRows.AddRow(1)
-	mock.ExpectQuery("SELECT id FROM sections").WithArgs(MockProductBatch.SectionId).WillReturnRows(sectionMockRows)
+	mock.ExpectPrepare("SELECT id FROM sections").ExpectQuery().WithArgs(MockProductBatch.SectionId).WillReturnRows(sectionMockRows)
 
 	// añadir mock INSERT product_batches
 	prep := mock.ExpectPrepare("^INSERT INTO product_batches*")
@@ -51,7 +51,7 @@ func CreateMockDBSaveNonExistantProduct(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
 	db, mock, err := sqlmock.New()
 
 	// añadir mocks de product a db
-	expectedProduct := mock.ExpectQuery("SELECT id FROM products")
+	expectedProduct := mock.ExpectPrepare("SELECT id FROM products").ExpectQuery()
 
 	productMockRows := sqlmock.NewRows([]string{"id"})
 	expectedProduct.WithArgs(2).WillReturnRows(productMockRows)
@@ -64,13 +64,13 @@ func CreateMockDBSaveNonExistantSection(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
 	db, mock, err := sqlmock.New()
 
 	// añadir mocks de product a db
-	expectedProduct := mock.ExpectQuery("SELECT id FROM products")
+	expectedProduct := mock.ExpectPrepare("SELECT id FROM products").ExpectQuery()
 
 	productMockRows := sqlmock.NewRows([]string{"id"})
 	productMockRows.AddRow(1)
 	expectedProduct.WithArgs(1).WillReturnRows(productMockRows)
 
-	expectedSection := mock.ExpectQuery("SELECT id FROM sections")
+	expectedSection := mock.ExpectPrepare("SELECT id FROM sections").ExpectQuery()
 
 	sectionMockRows := sqlmock.NewRows([]string{"id"})
 	expectedSection.WillReturnRows(sectionMockRows)
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
@@ -112,17 +113,32 @@ const ( //	ERRORS	 = messages
 
 // MockRepositoryProduct ...
 type MockRepositoryProduct struct {
-	Data []domain.Product
+	Data           []domain.Product
+	MockDependents map[int][]domain.Dependent
+	// ErrNotFound, when set, is returned by Delete for unknown ids.
+	ErrNotFound error
+	// MockMissingSellers are the seller ids ExistingSellers leaves out; every
+	// other seller and product type exists.
+	MockMissingSellers map[int]bool
 }
 
 // Delete ...
-func (r *MockRepositoryProduct) Delete(ctx context.Context, id int) error {
+func (r *MockRepositoryProduct) Delete(ctx context.Context, id, version int) error {
+	if dependents := r.MockDependents[id]; len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "product", ID: id, Dependents: dependents}
+	}
 	for i, product := range r.Data {
 		if product.ID == id {
+			if version != 0 && product.Version != version {
+				return domain.ErrVersionConflict
+			}
 			r.Data = append(r.Data[:i], r.Data[i+1:]...)
 			return nil
 		}
 	}
+	if r.ErrNotFound != nil {
+		return r.ErrNotFound
+	}
 	return fmt.Errorf(ProductNotFound, id)
 }
 
@@ -147,7 +163,7 @@ func (r *MockRepositoryProduct) Get(ctx context.Context, id int) (domain.Product
 }
 
 // GetAll ...
-func (r *MockRepositoryProduct) GetAll(ctx context.Context) ([]domain.Product, error) {
+func (r *MockRepositoryProduct) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error) {
 	return r.Data, nil
 }
 
@@ -173,3 +189,94 @@ func (r *MockRepositoryProduct) Update(ctx context.Context, p domain.Product) er
 	}
 	return fmt.Errorf(ProductNotFound, p.ID)
 }
+
+func (r *MockRepositoryProduct) Restore(ctx context.Context, id int) error {
+	for i, item := range r.Data {
+		if item.ID == id && item.DeletedAt != nil {
+			r.Data[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted product not found")
+}
+
+func (r *MockRepositoryProduct) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	return r.MockDependents[id], nil
+}
+
+// ExistingCodes ...
+func (r *MockRepositoryProduct) ExistingCodes(ctx context.Context, codes []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	for _, code := range codes {
+		if r.Exists(ctx, code) {
+			existing[code] = true
+		}
+	}
+	return existing, nil
+}
+
+// ExistingSellers ...
+func (r *MockRepositoryProduct) ExistingSellers(ctx context.Context, ids []int) (map[int]bool, error) {
+	existing := map[int]bool{}
+	for _, id := range ids {
+		if !r.MockMissingSellers[id] {
+			existing[id] = true
+		}
+	}
+	return existing, nil
+}
+
+// ExistingProductTypes ...
+func (r *MockRepositoryProduct) ExistingProductTypes(ctx context.Context, ids []int) (map[int]bool, error) {
+	existing := map[int]bool{}
+	for _, id := range ids {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// SaveBatch ...
+func (r *MockRepositoryProduct) SaveBatch(ctx context.Context, products []domain.Product) ([]int, error) {
+	ids := make([]int, len(products))
+	for i, p := range products {
+		id, err := r.Save(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// Import ...
+func (r *MockRepositoryProduct) Import(ctx context.Context, products []domain.Product, dryRun bool) ([]domain.BulkResult, error) {
+	codes := make([]string, len(products))
+	invalid := make([]string, len(products))
+	for i, p := range products {
+		codes[i] = p.ProductCode
+		if r.MockMissingSellers[p.SellerID] {
+			invalid[i] = fmt.Sprintf("seller %d does not exist", p.SellerID)
+		}
+	}
+	existing, _ := r.ExistingCodes(ctx, codes)
+	return domain.BulkImport(codes, existing, invalid, dryRun, func(rows []int) ([]int, error) {
+		batch := make([]domain.Product, len(rows))
+		for j, i := range rows {
+			batch[j] = products[i]
+		}
+		return r.SaveBatch(ctx, batch)
+	}), nil
+}
+
+func (r *MockRepositoryProduct) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error {
+	items, err := r.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
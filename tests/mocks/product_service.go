@@ -8,12 +8,14 @@ import (
 
 // ServiceTestProduct ...
 type ServiceTestProduct interface {
-	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error)
 	Exists(ctx context.Context, productCode string) bool
 	Get(ctx context.Context, id int) (domain.Product, error)
 	Save(ctx context.Context, p domain.Product) (int, error)
+	Import(ctx context.Context, products []domain.Product, dryRun bool) ([]domain.BulkResult, error)
 	Update(ctx context.Context, p domain.Product) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
 }
 
 // MockServiceProduct ...
@@ -22,8 +24,8 @@ type MockServiceProduct struct {
 }
 
 // GetAll ... | Get all 'products'
-func (s *MockServiceProduct) GetAll(ctx context.Context) ([]domain.Product, error) {
-	return s.MockProductRepository.GetAll(ctx)
+func (s *MockServiceProduct) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Product, error) {
+	return s.MockProductRepository.GetAll(ctx, includeDeleted)
 }
 
 // Get ... | Get a 'product' with id
@@ -47,6 +49,29 @@ func (s *MockServiceProduct) Update(ctx context.Context, p domain.Product) error
 }
 
 // Delete ... | Delete a 'product'
-func (s *MockServiceProduct) Delete(ctx context.Context, id int) error {
-	return s.MockProductRepository.Delete(ctx, id)
+func (s *MockServiceProduct) Delete(ctx context.Context, id, version int) error {
+	return s.MockProductRepository.Delete(ctx, id, version)
+}
+
+// Restore ... | Restore a deleted 'product'
+func (s *MockServiceProduct) Restore(ctx context.Context, id int) error {
+	return s.MockProductRepository.Restore(ctx, id)
+}
+
+// Import ... | Save 'products' in batches
+func (s *MockServiceProduct) Import(ctx context.Context, products []domain.Product, dryRun bool) ([]domain.BulkResult, error) {
+	return s.MockProductRepository.Import(ctx, products, dryRun)
+}
+
+func (s *MockServiceProduct) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Product) error) error {
+	items, err := s.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
 }
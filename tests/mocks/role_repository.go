@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+type MockRoleRepository struct {
+	MockRoles       []domain.Role
+	MockAssignments map[int][]int
+	ErrNotFound     error
+	ErrNotAssigned  error
+}
+
+func (m *MockRoleRepository) GetAll(ctx context.Context) ([]domain.Role, error) {
+	return m.MockRoles, nil
+}
+
+func (m *MockRoleRepository) Get(ctx context.Context, id int) (domain.Role, error) {
+	for _, rl := range m.MockRoles {
+		if rl.ID == id {
+			return rl, nil
+		}
+	}
+	if m.ErrNotFound != nil {
+		return domain.Role{}, m.ErrNotFound
+	}
+	return domain.Role{}, errors.New("role not found")
+}
+
+func (m *MockRoleRepository) GetByUser(ctx context.Context, userID int) ([]domain.Role, error) {
+	var roles []domain.Role
+	for _, id := range m.MockAssignments[userID] {
+		rl, err := m.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, rl)
+	}
+	return roles, nil
+}
+
+func (m *MockRoleRepository) IsAssigned(ctx context.Context, userID, roleID int) bool {
+	for _, id := range m.MockAssignments[userID] {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockRoleRepository) Assign(ctx context.Context, userID, roleID int) error {
+	m.MockAssignments[userID] = append(m.MockAssignments[userID], roleID)
+	return nil
+}
+
+func (m *MockRoleRepository) Revoke(ctx context.Context, userID, roleID int) error {
+	for i, id := range m.MockAssignments[userID] {
+		if id == roleID {
+			m.MockAssignments[userID] = append(m.MockAssignments[userID][:i], m.MockAssignments[userID][i+1:]...)
+			return nil
+		}
+	}
+	if m.ErrNotAssigned != nil {
+		return m.ErrNotAssigned
+	}
+	return errors.New("role not assigned to the user")
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -12,19 +13,30 @@ import (
 )
 
 type MockSectionRepository struct {
-	MockData []domain.Section
+	MockData       []domain.Section
+	MockDependents map[int][]domain.Dependent
+	// ErrNotFound, when set, is returned by Get for unknown ids.
+	ErrNotFound error
+	// ErrGet, when set, fails every Get.
+	ErrGet error
 }
 
-func (mk *MockSectionRepository) GetAll(ctx context.Context) ([]domain.Section, error) {
+func (mk *MockSectionRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
 	return mk.MockData, nil
 }
 
 func (mk *MockSectionRepository) Get(ctx context.Context, id int) (domain.Section, error) {
+	if mk.ErrGet != nil {
+		return domain.Section{}, mk.ErrGet
+	}
 	for _, section := range mk.MockData {
 		if section.ID == id {
 			return section, nil
 		}
 	}
+	if mk.ErrNotFound != nil {
+		return domain.Section{}, mk.ErrNotFound
+	}
 	return domain.Section{}, fmt.Errorf("no se encontro seccion: %d", id)
 }
 
@@ -54,9 +66,15 @@ func (mk *MockSectionRepository) Update(ctx context.Context, s domain.Section) e
 	return fmt.Errorf("no se encontro seccion: %d", s.ID)
 }
 
-func (mk *MockSectionRepository) Delete(ctx context.Context, id int) error {
+func (mk *MockSectionRepository) Delete(ctx context.Context, id, version int) error {
+	if dependents := mk.MockDependents[id]; len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "section", ID: id, Dependents: dependents}
+	}
 	for i, section := range mk.MockData {
 		if section.ID == id {
+			if version != 0 && section.Version != version {
+				return domain.ErrVersionConflict
+			}
 			mk.MockData = append(mk.MockData[:i], mk.MockData[i+1:]...)
 			return nil
 		}
@@ -76,7 +94,7 @@ type MockSectionErrorRepository struct {
 	MockData []domain.Section
 }
 
-func (mk *MockSectionErrorRepository) GetAll(ctx context.Context) ([]domain.Section, error) {
+func (mk *MockSectionErrorRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Section, error) {
 	return []domain.Section{}, fmt.Errorf("no se pueden obtener las secciones")
 }
 
@@ -101,7 +119,7 @@ func (mk *MockSectionErrorRepository) Update(ctx context.Context, s domain.Secti
 	return fmt.Errorf("no se actualizo seccion: %d", s.ID)
 }
 
-func (mk *MockSectionErrorRepository) Delete(ctx context.Context, id int) error {
+func (mk *MockSectionErrorRepository) Delete(ctx context.Context, id, version int) error {
 	return fmt.Errorf("no se encontro elimino: %d", id)
 }
 
@@ -118,7 +136,7 @@ func CreateMockDBProductsGetAll(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 
 	// añadir mocks de product a db
 	query := "SELECT .* FROM sections s *"
-	expectedProduct := mock.ExpectQuery(query)
+	expectedProduct := mock.ExpectPrepare(query).ExpectQuery()
 
 	productMockRows := sqlmock.NewRows([]string{"id", "section_number", "product_count"})
 	productMockRows.AddRow(1, 250, 100)
@@ -219,3 +237,77 @@ var MockGetAllProductReport []domain.ProductReport = []domain.ProductReport{
 		ProductCount:  10,
 	},
 }
+
+func (mk *MockSectionRepository) Restore(ctx context.Context, id int) error {
+	for i, item := range mk.MockData {
+		if item.ID == id && item.DeletedAt != nil {
+			mk.MockData[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted section not found")
+}
+
+func (mk *MockSectionErrorRepository) Restore(ctx context.Context, id int) error {
+	return fmt.Errorf("no se restauro seccion: %d", id)
+}
+
+func (mk *MockSectionRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	return mk.MockDependents[id], nil
+}
+
+func (mk *MockSectionErrorRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	return nil, nil
+}
+
+func (mk *MockSectionRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	items, err := mk.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mk *MockSectionRepository) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	items, err := mk.ReportProductsAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mk *MockSectionErrorRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Section) error) error {
+	items, err := mk.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mk *MockSectionErrorRepository) StreamReportProducts(ctx context.Context, fn func(domain.ProductReport) error) error {
+	items, err := mk.ReportProductsAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
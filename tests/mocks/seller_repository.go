@@ -54,6 +54,7 @@ var MockUpdateSeller domain.Seller = domain.Seller{
 	CompanyName: "MELI",
 	Address:     "Argentina",
 	Telephone:   "123",
+	LocalityId:  1,
 }
 
 var MockMissingCid domain.Seller = domain.Seller{
@@ -69,11 +70,18 @@ const (
 )
 
 type MockSellerRepo struct {
-	MockSeller []domain.Seller
+	MockSeller     []domain.Seller
+	MockDependents map[int][]domain.Dependent
+	MockReports    []domain.SellerReport
+	// ErrNotFound, when set, is returned by Delete for unknown ids.
+	ErrNotFound error
+	// MockMissingLocalities are the locality ids ExistingLocalities leaves
+	// out; every other locality exists.
+	MockMissingLocalities map[int]bool
 }
 
 // GetAll
-func (d *MockSellerRepo) GetAll(ctx context.Context) ([]domain.Seller, error) {
+func (d *MockSellerRepo) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error) {
 	return d.MockSeller, nil
 }
 
@@ -124,6 +132,9 @@ func (d *MockSellerRepo) Update(ctx context.Context, s domain.Seller) error {
 
 //Delete
 func (d *MockSellerRepo) Delete(ctx context.Context, id int) error {
+	if dependents := d.MockDependents[id]; len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "seller", ID: id, Dependents: dependents}
+	}
 	for i, seller := range d.MockSeller {
 		if seller.ID == id {
 			if i == len(d.MockSeller)-1 {
@@ -134,9 +145,93 @@ func (d *MockSellerRepo) Delete(ctx context.Context, id int) error {
 			return nil
 		}
 	}
+	if d.ErrNotFound != nil {
+		return d.ErrNotFound
+	}
 	return errors.New(SellerNotFound)
 }
 
 func (d *MockSellerRepo) CIDExist(ctx context.Context, cid int) bool {
 	return false
 }
+
+func (d *MockSellerRepo) Restore(ctx context.Context, id int) error {
+	for i, item := range d.MockSeller {
+		if item.ID == id && item.DeletedAt != nil {
+			d.MockSeller[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted seller not found")
+}
+
+func (d *MockSellerRepo) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	return d.MockDependents[id], nil
+}
+
+func (d *MockSellerRepo) ExistingCIDs(ctx context.Context, cids []int) (map[int]bool, error) {
+	existing := map[int]bool{}
+	for _, cid := range cids {
+		if d.Exists(ctx, cid) {
+			existing[cid] = true
+		}
+	}
+	return existing, nil
+}
+
+func (d *MockSellerRepo) ExistingLocalities(ctx context.Context, ids []int) (map[int]bool, error) {
+	existing := map[int]bool{}
+	for _, id := range ids {
+		if !d.MockMissingLocalities[id] {
+			existing[id] = true
+		}
+	}
+	return existing, nil
+}
+
+func (d *MockSellerRepo) SaveBatch(ctx context.Context, sellers []domain.Seller) ([]int, error) {
+	ids := make([]int, len(sellers))
+	for i, s := range sellers {
+		id, err := d.Save(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (d *MockSellerRepo) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error {
+	items, err := d.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *MockSellerRepo) Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error) {
+	for _, report := range d.MockReports {
+		if report.SellerID == id {
+			return report, nil
+		}
+	}
+	return domain.SellerReport{}, fmt.Errorf(SellerNotFound, id)
+}
+
+func (d *MockSellerRepo) Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error) {
+	return append([]domain.SellerReport{}, d.MockReports...), nil
+}
+
+func (d *MockSellerRepo) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error {
+	for _, report := range d.MockReports {
+		if err := fn(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
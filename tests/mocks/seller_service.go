@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
 )
@@ -10,8 +12,8 @@ type MockServiceSeller struct {
 	MockRepo MockSellerRepo
 }
 
-func (m *MockServiceSeller) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	return m.MockRepo.GetAll(ctx)
+func (m *MockServiceSeller) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Seller, error) {
+	return m.MockRepo.GetAll(ctx, includeDeleted)
 }
 
 func (m *MockServiceSeller) Get(ctx context.Context, id int) (domain.Seller, error) {
@@ -34,6 +36,55 @@ func (m *MockServiceSeller) Delete(ctx context.Context, id int) error {
 	return m.MockRepo.Delete(ctx, id)
 }
 
+func (m *MockServiceSeller) Restore(ctx context.Context, id int) error {
+	return m.MockRepo.Restore(ctx, id)
+}
+
 func (m *MockServiceSeller) CIDExist(ctx context.Context, cid int) bool {
 	return m.MockRepo.CIDExist(ctx, cid)
 }
+
+func (m *MockServiceSeller) Import(ctx context.Context, sellers []domain.Seller, dryRun bool) ([]domain.BulkResult, error) {
+	keys := make([]string, len(sellers))
+	existing := map[string]bool{}
+	invalid := make([]string, len(sellers))
+	for i, s := range sellers {
+		keys[i] = strconv.Itoa(s.CID)
+		existing[keys[i]] = m.MockRepo.Exists(ctx, s.CID)
+		if m.MockRepo.MockMissingLocalities[s.LocalityId] {
+			invalid[i] = fmt.Sprintf("locality %d does not exist", s.LocalityId)
+		}
+	}
+	return domain.BulkImport(keys, existing, invalid, dryRun, func(rows []int) ([]int, error) {
+		batch := make([]domain.Seller, len(rows))
+		for j, i := range rows {
+			batch[j] = sellers[i]
+		}
+		return m.MockRepo.SaveBatch(ctx, batch)
+	}), nil
+}
+
+func (m *MockServiceSeller) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Seller) error) error {
+	items, err := m.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockServiceSeller) Report(ctx context.Context, id int, dates domain.DateRange) (domain.SellerReport, error) {
+	return m.MockRepo.Report(ctx, id, dates)
+}
+
+func (m *MockServiceSeller) Reports(ctx context.Context, dates domain.DateRange) ([]domain.SellerReport, error) {
+	return m.MockRepo.Reports(ctx, dates)
+}
+
+func (m *MockServiceSeller) StreamReports(ctx context.Context, dates domain.DateRange, fn func(domain.SellerReport) error) error {
+	return m.MockRepo.StreamReports(ctx, dates, fn)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extmatperez/meli_bootcamp_go_w5-5/internal/domain"
+)
+
+type MockUserRepository struct {
+	MockData    []domain.User
+	ErrNotFound error
+}
+
+func (m *MockUserRepository) Get(ctx context.Context, id int) (domain.User, error) {
+	for _, u := range m.MockData {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return domain.User{}, m.notFound()
+}
+
+func (m *MockUserRepository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	for _, u := range m.MockData {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return domain.User{}, m.notFound()
+}
+
+func (m *MockUserRepository) Exists(ctx context.Context, username string) bool {
+	_, err := m.GetByUsername(ctx, username)
+	return err == nil
+}
+
+func (m *MockUserRepository) Save(ctx context.Context, u domain.User) (int, error) {
+	u.ID = len(m.MockData) + 1
+	m.MockData = append(m.MockData, u)
+	return u.ID, nil
+}
+
+func (m *MockUserRepository) notFound() error {
+	if m.ErrNotFound != nil {
+		return m.ErrNotFound
+	}
+	return errors.New("user not found")
+}
@@ -8,10 +8,15 @@ import (
 )
 
 type MockWarehouseRepository struct {
-	MockData []domain.Warehouse
+	MockData       []domain.Warehouse
+	MockDependents map[int][]domain.Dependent
+	MockSections   []domain.SectionUtilization
+	MockMix        []domain.ProductTypeMix
+	// ErrNotFound, when set, is returned by Delete for unknown ids.
+	ErrNotFound error
 }
 
-func (s *MockWarehouseRepository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
+func (s *MockWarehouseRepository) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
 	return s.MockData, nil
 }
 
@@ -51,6 +56,9 @@ func (s *MockWarehouseRepository) Update(ctx context.Context, w domain.Warehouse
 			if w.WarehouseCode != testWH.WarehouseCode && s.Exists(ctx, w.WarehouseCode) {
 				return errors.New("warehouseCode must be unique")
 			}
+			if w.Version != testWH.Version {
+				return domain.ErrVersionConflict
+			}
 			s.MockData[i] = w
 			return nil
 		}
@@ -58,13 +66,22 @@ func (s *MockWarehouseRepository) Update(ctx context.Context, w domain.Warehouse
 	return errors.New("warehouse not found")
 }
 
-func (s *MockWarehouseRepository) Delete(ctx context.Context, id int) error {
+func (s *MockWarehouseRepository) Delete(ctx context.Context, id, version int) error {
+	if dependents := s.MockDependents[id]; len(dependents) > 0 {
+		return &domain.DependentsError{Entity: "warehouse", ID: id, Dependents: dependents}
+	}
 	for i, testWH := range s.MockData {
 		if testWH.ID == id {
+			if version != 0 && testWH.Version != version {
+				return domain.ErrVersionConflict
+			}
 			s.MockData = append(s.MockData[:i], s.MockData[i+1:]...)
 			return nil
 		}
 	}
+	if s.ErrNotFound != nil {
+		return s.ErrNotFound
+	}
 	return errors.New("warehouse not found")
 }
 
@@ -88,3 +105,50 @@ var MockDataWarehouse []domain.Warehouse = []domain.Warehouse{
 }
 
 var MockEmptyDataWarehouse []domain.Warehouse = []domain.Warehouse{}
+
+func (s *MockWarehouseRepository) Restore(ctx context.Context, id int) error {
+	for i, item := range s.MockData {
+		if item.ID == id && item.DeletedAt != nil {
+			s.MockData[i].DeletedAt = nil
+			return nil
+		}
+	}
+	return errors.New("deleted warehouse not found")
+}
+
+func (s *MockWarehouseRepository) Dependents(ctx context.Context, id int) ([]domain.Dependent, error) {
+	return s.MockDependents[id], nil
+}
+
+func (s *MockWarehouseRepository) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	items, err := s.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MockWarehouseRepository) SectionUsage(ctx context.Context, warehouseID int) ([]domain.SectionUtilization, error) {
+	sections := []domain.SectionUtilization{}
+	for _, section := range s.MockSections {
+		if warehouseID == 0 || section.WarehouseID == warehouseID {
+			sections = append(sections, section)
+		}
+	}
+	return sections, nil
+}
+
+func (s *MockWarehouseRepository) ProductTypeMix(ctx context.Context, warehouseID int) ([]domain.ProductTypeMix, error) {
+	mix := []domain.ProductTypeMix{}
+	for _, m := range s.MockMix {
+		if warehouseID == 0 || m.WarehouseID == warehouseID {
+			mix = append(mix, m)
+		}
+	}
+	return mix, nil
+}
@@ -11,8 +11,8 @@ type MockWarehouseService struct {
 	MockRepository MockWarehouseRepository
 }
 
-func (s *MockWarehouseService) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	return s.MockRepository.GetAll(ctx)
+func (s *MockWarehouseService) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
+	return s.MockRepository.GetAll(ctx, includeDeleted)
 }
 
 func (s *MockWarehouseService) Get(ctx context.Context, id int) (domain.Warehouse, error) {
@@ -31,15 +31,19 @@ func (s *MockWarehouseService) Update(ctx context.Context, w domain.Warehouse) e
 	return s.MockRepository.Update(ctx, w)
 }
 
-func (s *MockWarehouseService) Delete(ctx context.Context, id int) error {
-	return s.MockRepository.Delete(ctx, id)
+func (s *MockWarehouseService) Delete(ctx context.Context, id, version int) error {
+	return s.MockRepository.Delete(ctx, id, version)
+}
+
+func (s *MockWarehouseService) Restore(ctx context.Context, id int) error {
+	return s.MockRepository.Restore(ctx, id)
 }
 
 type MockWarehouseServiceError struct {
 	MockRepository MockWarehouseRepository
 }
 
-func (s *MockWarehouseServiceError) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
+func (s *MockWarehouseServiceError) GetAll(ctx context.Context, includeDeleted bool) ([]domain.Warehouse, error) {
 	return []domain.Warehouse{}, errors.New("communication error with the database")
 }
 
@@ -59,6 +63,67 @@ func (s *MockWarehouseServiceError) Update(ctx context.Context, w domain.Warehou
 	return errors.New("communication error with the database")
 }
 
-func (s *MockWarehouseServiceError) Delete(ctx context.Context, id int) error {
+func (s *MockWarehouseServiceError) Delete(ctx context.Context, id, version int) error {
+	return errors.New("communication error with the database")
+}
+
+func (s *MockWarehouseServiceError) Restore(ctx context.Context, id int) error {
 	return errors.New("communication error with the database")
 }
+
+func (s *MockWarehouseService) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	items, err := s.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MockWarehouseServiceError) StreamAll(ctx context.Context, includeDeleted bool, fn func(domain.Warehouse) error) error {
+	items, err := s.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MockWarehouseService) Utilization(ctx context.Context, id int) (domain.WarehouseUtilization, error) {
+	w, err := s.MockRepository.Get(ctx, id)
+	if err != nil {
+		return domain.WarehouseUtilization{}, err
+	}
+	sections, _ := s.MockRepository.SectionUsage(ctx, id)
+	mix, _ := s.MockRepository.ProductTypeMix(ctx, id)
+	return domain.NewWarehouseUtilization(w, sections, mix), nil
+}
+
+func (s *MockWarehouseService) Utilizations(ctx context.Context) ([]domain.WarehouseUtilization, error) {
+	warehouses, _ := s.MockRepository.GetAll(ctx, false)
+	utilizations := []domain.WarehouseUtilization{}
+	for _, w := range warehouses {
+		u, err := s.Utilization(ctx, w.ID)
+		if err != nil {
+			return nil, err
+		}
+		utilizations = append(utilizations, u)
+	}
+	return utilizations, nil
+}
+
+func (s *MockWarehouseServiceError) Utilization(ctx context.Context, id int) (domain.WarehouseUtilization, error) {
+	return domain.WarehouseUtilization{}, errors.New("communication error with the database")
+}
+
+func (s *MockWarehouseServiceError) Utilizations(ctx context.Context) ([]domain.WarehouseUtilization, error) {
+	return nil, errors.New("communication error with the database")
+}
@@ -0,0 +1,12 @@
+package queries
+
+const (
+	AuditSaveQuery = "INSERT INTO audit_log (entity, entity_id, action, before_data, after_data, actor, request_id) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	// AuditFindQuery is extended with the filter conditions and AuditFindOrder.
+	AuditFindQuery  = "SELECT id, entity, entity_id, action, before_data, after_data, actor, request_id, created_at FROM audit_log WHERE 1=1"
+	AuditFindOrder  = " ORDER BY created_at, id"
+	AuditByEntity   = " AND entity=?"
+	AuditByEntityID = " AND entity_id=?"
+	AuditFrom       = " AND created_at>=?"
+	AuditTo         = " AND created_at<=?"
+)
@@ -0,0 +1,37 @@
+package queries
+
+const (
+	BuyerGetAllQuery     = "SELECT id, card_number_id, first_name, last_name, deleted_at FROM buyers"
+	BuyerGetQuery        = "SELECT id, card_number_id, first_name, last_name, deleted_at FROM buyers WHERE id = ? AND deleted_at IS NULL;"
+	BuyerExistsQuery     = "SELECT card_number_id FROM buyers WHERE card_number_id=?;"
+	BuyerSaveQuery       = "INSERT INTO buyers(card_number_id,first_name,last_name) VALUES (?,?,?)"
+	BuyerUpdateQuery     = "UPDATE buyers SET first_name=?, last_name=?  WHERE id=? AND deleted_at IS NULL"
+	BuyerDeleteQuery     = "UPDATE buyers SET deleted_at=NOW() WHERE id = ? AND deleted_at IS NULL"
+	BuyerRestoreQuery    = "UPDATE buyers SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	BuyerDependentsQuery = "SELECT (SELECT COUNT(*) FROM purchase_orders WHERE buyer_id=?)"
+	// BuyerExistingCardNumbersQuery is completed with one placeholder per card
+	// number, so it is run without being cached.
+	BuyerExistingCardNumbersQuery = "SELECT card_number_id FROM buyers WHERE card_number_id IN (?"
+	BuyerPurchaseOrdersQuery      = "SELECT b.id, b.card_number_id, b.first_name, b.last_name, count(po.buyer_id) FROM buyers AS b " +
+		"LEFT JOIN purchase_orders AS po " +
+		"ON b.id=po.buyer_id WHERE b.id=? AND b.deleted_at IS NULL GROUP BY b.id;"
+	BuyerAllPurchaseOrdersQuery = "SELECT b.id,b.card_number_id,b.first_name,b.last_name, count(po.buyer_id) " +
+		"FROM buyers AS b LEFT JOIN purchase_orders AS po ON po.buyer_id=b.id WHERE b.deleted_at IS NULL GROUP BY b.id;"
+	// BuyerSpendQuery sums the purchase orders of each buyer, pricing their
+	// details at the sale price of the product record.
+	BuyerSpendQuery = "SELECT b.id, b.card_number_id, b.first_name, b.last_name, COUNT(DISTINCT po.id), COALESCE(SUM(od.quantity*pr.sale_price), 0) AS total_spent, MAX(po.order_date) " +
+		"FROM buyers AS b LEFT JOIN purchase_orders AS po ON po.buyer_id=b.id " +
+		"LEFT JOIN order_details AS od ON od.purchase_order_id=po.id " +
+		"LEFT JOIN product_records AS pr ON pr.id=od.product_record_id WHERE b.deleted_at IS NULL"
+	BuyerSpendByIDQuery = BuyerSpendQuery + " AND b.id=? GROUP BY b.id"
+	BuyerTopBuyersQuery = BuyerSpendQuery + " GROUP BY b.id HAVING COUNT(po.id) > 0 ORDER BY total_spent DESC, b.id LIMIT ? OFFSET ?"
+	BuyerCountTopQuery  = "SELECT COUNT(DISTINCT po.buyer_id) FROM purchase_orders AS po JOIN buyers AS b ON b.id=po.buyer_id WHERE b.deleted_at IS NULL"
+	BuyerHistoryQuery   = "SELECT po.id, po.order_number, po.order_date, po.tracking_code, po.order_status_id, COALESCE(os.description, ''), " +
+		"od.id, od.product_record_id, pr.product_id, od.quantity, pr.sale_price " +
+		"FROM purchase_orders AS po LEFT JOIN order_status AS os ON os.id=po.order_status_id " +
+		"LEFT JOIN order_details AS od ON od.purchase_order_id=po.id " +
+		"LEFT JOIN product_records AS pr ON pr.id=od.product_record_id " +
+		"WHERE po.buyer_id=? ORDER BY po.order_date DESC, po.id DESC, od.id"
+	// BuyerNotDeleted is appended to BuyerGetAllQuery to hide deleted rows.
+	BuyerNotDeleted = " WHERE deleted_at IS NULL"
+)
@@ -0,0 +1,19 @@
+package queries
+
+const (
+	EmployeeGetAllQuery        = "SELECT id, card_number_id, first_name, last_name, warehouse_id, user_id, version, deleted_at FROM employees"
+	EmployeeGetQuery           = "SELECT id, card_number_id, first_name, last_name, warehouse_id, user_id, version, deleted_at FROM employees WHERE id=? AND deleted_at IS NULL;"
+	EmployeeGetByUserIDQuery   = "SELECT id, card_number_id, first_name, last_name, warehouse_id, user_id, version, deleted_at FROM employees WHERE user_id=? AND deleted_at IS NULL;"
+	EmployeeExistsQuery        = "SELECT card_number_id FROM employees WHERE card_number_id=?;"
+	EmployeeSaveQuery          = "INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id,user_id) VALUES (?,?,?,?,?)"
+	EmployeeUpdateQuery        = "UPDATE employees SET card_number_id=?, first_name=?, last_name=?, warehouse_id=?, user_id=?, version=version+1  WHERE id=? AND version=? AND deleted_at IS NULL"
+	EmployeeDeleteQuery        = "UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	EmployeeDeleteVersionQuery = "UPDATE employees SET deleted_at=NOW() WHERE id=? AND version=? AND deleted_at IS NULL"
+	EmployeeRestoreQuery       = "UPDATE employees SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	EmployeeInboundOrdersQuery = "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(io.employe_id) FROM employees AS e " +
+		"LEFT JOIN inbound_orders AS io " +
+		"ON e.id=io.employe_id WHERE e.id=? AND e.deleted_at IS NULL GROUP BY e.id;"
+	EmployeeAllInboundOrdersQuery = "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(io.employe_id) FROM employees AS e LEFT JOIN inbound_orders AS io ON io.employe_id=e.id WHERE e.deleted_at IS NULL GROUP BY e.id;"
+	// EmployeeNotDeleted is appended to EmployeeGetAllQuery to hide deleted rows.
+	EmployeeNotDeleted = " WHERE deleted_at IS NULL"
+)
@@ -0,0 +1,9 @@
+package queries
+
+const (
+	IdempotencyGetQuery      = "SELECT username, idempotency_key, method, path, request_hash, status_code, response_body FROM idempotency_keys WHERE username=? AND idempotency_key=?"
+	IdempotencyReserveQuery  = "INSERT INTO idempotency_keys (username, idempotency_key, method, path, request_hash, status_code) VALUES (?, ?, ?, ?, ?, 0)"
+	IdempotencyCompleteQuery = "UPDATE idempotency_keys SET status_code=?, response_body=? WHERE username=? AND idempotency_key=?"
+	IdempotencyDeleteQuery   = "DELETE FROM idempotency_keys WHERE username=? AND idempotency_key=?"
+	IdempotencyExpireQuery   = "DELETE FROM idempotency_keys WHERE created_at<?"
+)
@@ -0,0 +1,6 @@
+package queries
+
+const (
+	InboundOrderEmployeeExistsQuery = "SELECT id FROM employees WHERE id=?;"
+	InboundOrderSaveQuery           = "INSERT INTO inbound_orders(order_date, order_number, employe_id, product_batch_id, wareHouse_id) VALUES (?,?,?,?,?)"
+)
@@ -1,8 +1,8 @@
 package queries
 
 const (
-	LocalityGetSellerReportQuery     = "SELECT l.id, l.locality_name, count(c.id) FROM localities l LEFT JOIN sellers c ON c.locality_id = l.id WHERE l.id = ? GROUP BY l.id"
-	LocalityGetAllSellerReportsQuery = "SELECT l.id, l.locality_name, count(c.id) FROM localities l LEFT JOIN sellers c ON c.locality_id = l.id GROUP BY l.id"
+	LocalityGetSellerReportQuery     = "SELECT l.id, l.locality_name, count(c.id) FROM localities l LEFT JOIN sellers c ON c.locality_id = l.id AND c.deleted_at IS NULL WHERE l.id = ? GROUP BY l.id"
+	LocalityGetAllSellerReportsQuery = "SELECT l.id, l.locality_name, count(c.id) FROM localities l LEFT JOIN sellers c ON c.locality_id = l.id AND c.deleted_at IS NULL GROUP BY l.id"
 	LocalityGetAll                   = "SELECT id, locality_name FROM localities;"
 	InsertLocality                   = "INSERT INTO localities (id, locality_name) VALUES (?, ?);"
 	InsertProvince                   = "INSERT INTO provinces (province_name) VALUES (?);"
@@ -0,0 +1,8 @@
+package queries
+
+const (
+	OutboxSaveQuery          = "INSERT INTO outbox_events (type, entity, entity_id, payload, request_id, status, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	OutboxPendingQuery       = "SELECT id, type, entity, entity_id, payload, request_id, created_at, status, attempts, next_attempt_at, last_error FROM outbox_events WHERE status=? AND next_attempt_at<=? ORDER BY id LIMIT ?"
+	OutboxMarkDeliveredQuery = "UPDATE outbox_events SET status=?, attempts=attempts+1, last_error=NULL, delivered_at=NOW() WHERE id=?"
+	OutboxSaveAttemptQuery   = "UPDATE outbox_events SET status=?, attempts=?, next_attempt_at=?, last_error=? WHERE id=?"
+)
@@ -0,0 +1,22 @@
+package queries
+
+const (
+	ProductGetAllQuery        = "SELECT id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, id_product_type, id_seller, version, deleted_at FROM products"
+	ProductGetQuery           = "SELECT id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, id_product_type, id_seller, version, deleted_at FROM products WHERE id=? AND deleted_at IS NULL;"
+	ProductExistsQuery        = "SELECT product_code FROM products WHERE product_code=?;"
+	ProductSaveQuery          = "INSERT INTO products(description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	ProductUpdateQuery        = "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, length=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, id_product_type=?, id_seller=?, version=version+1  WHERE id=? AND version=? AND deleted_at IS NULL"
+	ProductDeleteQuery        = "UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	ProductDeleteVersionQuery = "UPDATE products SET deleted_at=NOW() WHERE id=? AND version=? AND deleted_at IS NULL"
+	ProductRestoreQuery       = "UPDATE products SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	ProductDependentsQuery    = "SELECT (SELECT COUNT(*) FROM product_batches WHERE product_id=?)"
+	// ProductExistingCodesQuery is completed with one placeholder per code,
+	// so it is run without being cached.
+	ProductExistingCodesQuery = "SELECT product_code FROM products WHERE product_code IN (?"
+	// ProductExistingSellersQuery and ProductExistingProductTypesQuery are
+	// completed like ProductExistingCodesQuery.
+	ProductExistingSellersQuery      = "SELECT id FROM sellers WHERE deleted_at IS NULL AND id IN (?"
+	ProductExistingProductTypesQuery = "SELECT id FROM products_types WHERE id IN (?"
+	// ProductNotDeleted is appended to ProductGetAllQuery to hide deleted rows.
+	ProductNotDeleted = " WHERE deleted_at IS NULL"
+)
@@ -0,0 +1,20 @@
+package queries
+
+const (
+	ProductBatchSectionExistsQuery = "SELECT id FROM sections WHERE id=? AND deleted_at IS NULL;"
+	ProductBatchProductExistsQuery = "SELECT id FROM products WHERE id=? AND deleted_at IS NULL;"
+	ProductBatchSaveQuery          = "INSERT INTO product_batches " +
+		"(" +
+		"batch_number, " +
+		"current_quantity, " +
+		"current_temperature, " +
+		"due_date, " +
+		"initial_quantity, " +
+		"manufacturing_date, " +
+		"manufacturing_hour, " +
+		"minimum_temperature, " +
+		"product_id, " +
+		"section_id" +
+		")" +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+)
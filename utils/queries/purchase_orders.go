@@ -2,6 +2,9 @@ package queries
 
 const (
 	PurchaseOrderInsertIntoPO      = "INSERT INTO purchase_orders(order_number,order_date,tracking_code,buyer_id,order_status_id) VALUES (?,?,?,?,?)"
-	PurchaseOrderInsertIntoOD      = "INSERT INTO order_details(product_record_id) VALUES (?)"
+	PurchaseOrderInsertIntoOD      = "INSERT INTO order_details(product_record_id,purchase_order_id,quantity) VALUES (?,?,?)"
 	PurchaseOrderSelectOrderNumber = "SELECT order_number FROM purchase_orders WHERE order_number=?"
+	PurchaseOrderStatusQuery       = "SELECT order_status_id FROM purchase_orders WHERE id=?"
+	PurchaseOrderStatusExistsQuery = "SELECT id FROM order_status WHERE id=?"
+	PurchaseOrderUpdateStatusQuery = "UPDATE purchase_orders SET order_status_id=? WHERE id=?"
 )
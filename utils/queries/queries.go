@@ -0,0 +1,133 @@
+// Package queries holds the SQL run by the repositories.
+package queries
+
+// Static lists the queries that run as they are written, not completed with
+// filters at run time, so the server can prepare them when it starts.
+var Static = []string{
+	AuditSaveQuery,
+	AuditFindQuery + AuditFindOrder,
+
+	BuyerGetAllQuery,
+	BuyerGetAllQuery + BuyerNotDeleted,
+	BuyerGetQuery,
+	BuyerExistsQuery,
+	BuyerSaveQuery,
+	BuyerUpdateQuery,
+	BuyerDeleteQuery,
+	BuyerRestoreQuery,
+	BuyerDependentsQuery,
+	BuyerPurchaseOrdersQuery,
+	BuyerAllPurchaseOrdersQuery,
+	BuyerSpendByIDQuery,
+	BuyerTopBuyersQuery,
+	BuyerCountTopQuery,
+	BuyerHistoryQuery,
+
+	CarrySaveQuery,
+	CarryCIDExistsQuery,
+	CarryLocalityExistsQuery,
+
+	EmployeeGetAllQuery,
+	EmployeeGetAllQuery + EmployeeNotDeleted,
+	EmployeeGetQuery,
+	EmployeeGetByUserIDQuery,
+	EmployeeExistsQuery,
+	EmployeeSaveQuery,
+	EmployeeUpdateQuery,
+	EmployeeDeleteQuery,
+	EmployeeDeleteVersionQuery,
+	EmployeeRestoreQuery,
+	EmployeeInboundOrdersQuery,
+	EmployeeAllInboundOrdersQuery,
+
+	IdempotencyGetQuery,
+	IdempotencyReserveQuery,
+	IdempotencyCompleteQuery,
+	IdempotencyDeleteQuery,
+	IdempotencyExpireQuery,
+
+	InboundOrderEmployeeExistsQuery,
+	InboundOrderSaveQuery,
+
+	LocalityGetSellerReportQuery,
+	LocalityGetAllSellerReportsQuery,
+	LocalityGetCarryReportQuery,
+	LocalityGetAllCarryReportsQuery,
+	InsertLocality,
+	InsertProvince,
+	InsertCountry,
+	SelectIdLocality,
+
+	OutboxSaveQuery,
+	OutboxPendingQuery,
+	OutboxMarkDeliveredQuery,
+	OutboxSaveAttemptQuery,
+
+	ProductGetAllQuery,
+	ProductGetAllQuery + ProductNotDeleted,
+	ProductGetQuery,
+	ProductExistsQuery,
+	ProductSaveQuery,
+	ProductUpdateQuery,
+	ProductDeleteQuery,
+	ProductDeleteVersionQuery,
+	ProductRestoreQuery,
+	ProductDependentsQuery,
+
+	ProductBatchSectionExistsQuery,
+	ProductBatchProductExistsQuery,
+	ProductBatchSaveQuery,
+
+	PurchaseOrderInsertIntoPO,
+	PurchaseOrderInsertIntoOD,
+	PurchaseOrderSelectOrderNumber,
+	PurchaseOrderStatusQuery,
+	PurchaseOrderStatusExistsQuery,
+	PurchaseOrderUpdateStatusQuery,
+
+	RoleGetAllQuery,
+	RoleGetQuery,
+	RoleGetByUserQuery,
+	RoleAssignedQuery,
+	RoleAssignQuery,
+	RoleRevokeQuery,
+
+	SectionGetAllQuery,
+	SectionGetAllQuery + SectionNotDeleted,
+	SectionGetQuery,
+	SectionExistsQuery,
+	SectionSaveQuery,
+	SectionUpdateQuery,
+	SectionDeleteQuery,
+	SectionDeleteVersionQuery,
+	SectionRestoreQuery,
+	SectionDependentsQuery,
+	SectionReportAllQuery,
+	SectionReportGetQuery,
+
+	SellerGetAllQuery,
+	SellerGetAllQuery + SellerNotDeleted,
+	SellerGetQuery,
+	SellerUpdateQuery,
+	SellerDeleteQuery,
+	SellerRestoreQuery,
+	SellerDependentsQuery,
+	InsertSeller,
+	SelectCidSeller,
+
+	UserGetQuery,
+	UserGetByUsernameQuery,
+	UserExistsQuery,
+	UserSaveQuery,
+
+	WarehouseGetAllQuery,
+	WarehouseGetAllQuery + WarehouseNotDeleted,
+	WarehouseGetQuery,
+	WarehouseExistsQuery,
+	WarehouseSaveQuery,
+	WarehouseUpdateQuery,
+	WarehouseDeleteQuery,
+	WarehouseDeleteVersionQuery,
+	WarehouseRestoreQuery,
+	WarehouseDependentsQuery,
+}
@@ -0,0 +1,10 @@
+package queries
+
+const (
+	RoleGetAllQuery    = "SELECT id, rol_name, description FROM rol"
+	RoleGetQuery       = "SELECT id, rol_name, description FROM rol WHERE id=?"
+	RoleGetByUserQuery = "SELECT r.id, r.rol_name, r.description FROM rol r JOIN user_rol ur ON ur.rol_id = r.id WHERE ur.usuario_id=?"
+	RoleAssignedQuery  = "SELECT rol_id FROM user_rol WHERE usuario_id=? AND rol_id=?"
+	RoleAssignQuery    = "INSERT INTO user_rol (usuario_id, rol_id) VALUES (?, ?)"
+	RoleRevokeQuery    = "DELETE FROM user_rol WHERE usuario_id=? AND rol_id=?"
+)
@@ -0,0 +1,17 @@
+package queries
+
+const (
+	SectionGetAllQuery        = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type, version, deleted_at FROM sections"
+	SectionGetQuery           = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type, version, deleted_at FROM sections WHERE id=? AND deleted_at IS NULL;"
+	SectionExistsQuery        = "SELECT section_number FROM sections WHERE section_number=?;"
+	SectionSaveQuery          = "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
+	SectionUpdateQuery        = "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=?, version=version+1 WHERE id=? AND version=? AND deleted_at IS NULL;"
+	SectionDeleteQuery        = "UPDATE sections SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL;"
+	SectionDeleteVersionQuery = "UPDATE sections SET deleted_at=NOW() WHERE id=? AND version=? AND deleted_at IS NULL"
+	SectionRestoreQuery       = "UPDATE sections SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	SectionDependentsQuery    = "SELECT (SELECT COUNT(*) FROM product_batches WHERE section_id=?)"
+	SectionReportAllQuery     = "SELECT s.id, s.section_number, SUM(pb.current_quantity)  as product_count FROM sections s JOIN product_batches pb ON pb.section_id = s.id JOIN products p  ON pb.product_id = p.id WHERE s.deleted_at IS NULL GROUP BY s.id"
+	SectionReportGetQuery     = "SELECT s.id, s.section_number, SUM(pb.current_quantity)  as product_count FROM sections s JOIN product_batches pb ON pb.section_id = s.id JOIN products p  ON pb.product_id = p.id WHERE s.id = ? AND s.deleted_at IS NULL GROUP BY s.id"
+	// SectionNotDeleted is appended to SectionGetAllQuery to hide deleted rows.
+	SectionNotDeleted = " WHERE deleted_at IS NULL"
+)
@@ -0,0 +1,27 @@
+package queries
+
+const (
+	SellerGetAllQuery     = "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM sellers"
+	SellerGetQuery        = "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM sellers WHERE id=? AND deleted_at IS NULL;"
+	SellerUpdateQuery     = "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=? AND deleted_at IS NULL"
+	SellerDeleteQuery     = "UPDATE sellers SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	SellerRestoreQuery    = "UPDATE sellers SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	SellerDependentsQuery = "SELECT (SELECT COUNT(*) FROM products WHERE id_seller=? AND deleted_at IS NULL)"
+	// SellerExistingCIDsQuery is completed with one placeholder per cid, so
+	// it is run without being cached.
+	SellerExistingCIDsQuery = "SELECT cid FROM sellers WHERE cid IN (?"
+	// SellerExistingLocalitiesQuery is completed like SellerExistingCIDsQuery.
+	SellerExistingLocalitiesQuery = "SELECT id FROM localities WHERE id IN (?"
+	// SellerNotDeleted is appended to SellerGetAllQuery to hide deleted rows.
+	SellerNotDeleted = " WHERE deleted_at IS NULL"
+	// SellerReportQuery sums up the products, batches and purchase orders of
+	// every seller; the %s verb takes the conditions on the orders.
+	SellerReportQuery = "SELECT s.id, s.cid, s.company_name, COALESCE(p.products_count, 0), COALESCE(p.stock, 0), COALESCE(o.orders_count, 0), COALESCE(o.revenue, 0) AS revenue FROM sellers s" +
+		" LEFT JOIN (SELECT pr.id_seller, COUNT(DISTINCT pr.id) AS products_count, SUM(pb.current_quantity) AS stock FROM products pr LEFT JOIN product_batches pb ON pb.product_id = pr.id WHERE pr.deleted_at IS NULL GROUP BY pr.id_seller) p ON p.id_seller = s.id" +
+		" LEFT JOIN (SELECT pr.id_seller, COUNT(DISTINCT po.id) AS orders_count, SUM(od.quantity * rc.sale_price) AS revenue FROM order_details od JOIN purchase_orders po ON po.id = od.purchase_order_id JOIN product_records rc ON rc.id = od.product_record_id JOIN products pr ON pr.id = rc.product_id WHERE 1=1%s GROUP BY pr.id_seller) o ON o.id_seller = s.id" +
+		" WHERE s.deleted_at IS NULL"
+	SellerReportFrom    = " AND po.order_date>=?"
+	SellerReportTo      = " AND po.order_date<=?"
+	SellerReportByID    = " AND s.id=?"
+	SellerReportRanking = " ORDER BY revenue DESC, s.id"
+)
@@ -0,0 +1,8 @@
+package queries
+
+const (
+	UserGetQuery           = "SELECT id, username, password FROM users WHERE id=?"
+	UserGetByUsernameQuery = "SELECT id, username, password FROM users WHERE username=?"
+	UserExistsQuery        = "SELECT username FROM users WHERE username=?"
+	UserSaveQuery          = "INSERT INTO users (username, password) VALUES (?, ?)"
+)
@@ -1,10 +1,20 @@
 package queries
 
 const (
-	WarehouseGetAllQuery = "SELECT * FROM warehouses"
-	WarehouseGetQuery    = "SELECT * FROM warehouses WHERE id=?;"
-	WarehouseExistsQuery = "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;"
-	WarehouseSaveQuery   = "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"
-	WarehouseUpdateQuery = "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"
-	WarehouseDeleteQuery = "DELETE FROM warehouses WHERE id=?"
+	WarehouseGetAllQuery        = "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, version, deleted_at FROM warehouses"
+	WarehouseGetQuery           = "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, version, deleted_at FROM warehouses WHERE id=? AND deleted_at IS NULL;"
+	WarehouseExistsQuery        = "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;"
+	WarehouseSaveQuery          = "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"
+	WarehouseUpdateQuery        = "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, version=version+1 WHERE id=? AND version=? AND deleted_at IS NULL"
+	WarehouseDeleteQuery        = "UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	WarehouseDeleteVersionQuery = "UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND version=? AND deleted_at IS NULL"
+	WarehouseRestoreQuery       = "UPDATE warehouses SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	WarehouseDependentsQuery    = "SELECT (SELECT COUNT(*) FROM sections WHERE warehouse_id=? AND deleted_at IS NULL), (SELECT COUNT(*) FROM employees WHERE warehouse_id=? AND deleted_at IS NULL)"
+	// WarehouseSectionUsageQuery and WarehouseProductTypeMixQuery take the
+	// warehouse filter, empty or WarehouseUsageFilter, in their %s verb.
+	WarehouseSectionUsageQuery   = "SELECT s.warehouse_id, s.id, s.section_number, s.id_product_type, s.minimum_capacity, s.maximum_capacity, COALESCE(SUM(pb.current_quantity), 0) FROM sections s LEFT JOIN product_batches pb ON pb.section_id = s.id WHERE s.deleted_at IS NULL%s GROUP BY s.id ORDER BY s.warehouse_id, s.section_number"
+	WarehouseProductTypeMixQuery = "SELECT s.warehouse_id, p.id_product_type, COALESCE(MAX(pt.description), ''), SUM(pb.current_quantity) AS quantity FROM product_batches pb JOIN sections s ON s.id = pb.section_id JOIN products p ON p.id = pb.product_id LEFT JOIN products_types pt ON pt.id = p.id_product_type WHERE s.deleted_at IS NULL%s GROUP BY s.warehouse_id, p.id_product_type ORDER BY s.warehouse_id, quantity DESC"
+	WarehouseUsageFilter         = " AND s.warehouse_id=?"
+	// WarehouseNotDeleted is appended to WarehouseGetAllQuery to hide deleted rows.
+	WarehouseNotDeleted = " WHERE deleted_at IS NULL"
 )